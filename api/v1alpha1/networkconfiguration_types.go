@@ -40,6 +40,12 @@ type NetworkClusterPolicySpec struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=8
 	LogLevel int `json:"logLevel,omitempty"`
+
+	// Taint the selected nodes with intel.com/scale-out-not-ready:NoSchedule
+	// until the discover agent reports their scale-out interfaces as
+	// configured. Only valid when configuration type is 'gaudi-so'. Not
+	// applied in dry run mode, where the interfaces are never configured.
+	TaintNotReadyNodes bool `json:"taintNotReadyNodes,omitempty"`
 }
 
 // GaudiScaleOutSpec defines the desired state of GaudiScaleOut
@@ -69,14 +75,213 @@ type GaudiScaleOutSpec struct {
 	// running on the host
 	EnableLLDPAD bool `json:"enableLLDPAD,omitempty"`
 
-	// Bitmask of Priority Flow Control priorities to enable
-	// Requires lldpad on the host, or enabling the above EnableLLDPAD boolean
-	// Currently accepted values are 00000000 or 11110000
-	// +kubebuilder:validation:Enum="00000000";"11110000"
+	// Transmit LLDP frames with the node name and the interface module ID
+	// from the discover agent, e.g. for back-to-back topologies without a
+	// switch. Does not require lldpad.
+	LLDPTransmit bool `json:"lldpTransmit,omitempty"`
+
+	// Priority Flow Control priorities to enable, either as a bitmask of
+	// eight digits where the first digit is priority 0, e.g. 00010000, or as
+	// a comma separated list of priorities, e.g. 3 or 0,1,2,3.
+	// Requires lldpad on the host, or enabling the above EnableLLDPAD boolean,
+	// unless the netlink PFC backend is used
+	// +kubebuilder:validation:Pattern=`^([01]{8}|[0-7](,[0-7]){0,7})$`
 	PFCPriorities string `json:"pfcPriorities,omitempty"`
 
+	// How Priority Flow Control, Enhanced Transmission Selection and DSCP
+	// priorities are configured: with lldptool, requiring lldpad (default), or directly
+	// through the kernel DCB netlink interface without DCBX negotiation
+	// (netlink).
+	// +kubebuilder:validation:Enum=lldptool;netlink
+	PFCBackend string `json:"pfcBackend,omitempty"`
+
+	// Enhanced Transmission Selection on the scale-out interfaces. The
+	// default configuration is restored when the configuration Pod exits.
+	ETS *ETSSpec `json:"ets,omitempty"`
+
+	// DSCP to priority mapping for traffic classified by DSCP, e.g. RoCEv2.
+	// The interfaces are set to trust DSCP and the mapping is added to
+	// their APP table. Both are reverted when the configuration Pod exits.
+	DSCPPriorities []DSCPPriority `json:"dscpPriorities,omitempty"`
+
 	// Enable scale-out network metrics support.
 	NetworkMetrics bool `json:"networkMetrics,omitempty"`
+
+	// Minimum number of working scale-out ports for the node to be
+	// labeled ready. A port works when it is configured and its link is
+	// up. Defaults to all the scale-out ports of the node.
+	// +kubebuilder:validation:Minimum=1
+	MinReadyPorts int `json:"minReadyPorts,omitempty"`
+
+	// How the scale-out readiness of the node is published: as Node
+	// Feature Discovery labels (nfd), or by patching the labels and the
+	// GaudiScaleOutReady condition of the Node object directly for
+	// clusters without NFD (node).
+	// +kubebuilder:validation:Enum=nfd;node
+	Readiness string `json:"readiness,omitempty"`
+
+	// Prefix length of the routed scale-out network reached through the
+	// switch port of each interface in L3 mode. The route destination is the
+	// network of that size containing the interface address. Defaults to 16
+	// for IPv4 and 64 for IPv6. The same length is used for all interfaces,
+	// so it must not be longer than 32 with IPv4 addresses.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=128
+	RoutedPrefixLength int `json:"routedPrefixLength,omitempty"`
+
+	// Explicit destination networks in CIDR notation to route through the
+	// switch port of each interface in L3 mode. The destinations are global,
+	// each interface gets the ones matching its address family. Overrides
+	// RoutedPrefixLength when set. Per-interface destinations, e.g. per
+	// rail, are set in the address plan.
+	RouteDestinations []string `json:"routeDestinations,omitempty"`
+
+	// Where the switch port address is advertised in LLDP in L3 mode.
+	LLDPAddress *LLDPAddressSpec `json:"lldpAddress,omitempty"`
+
+	// Static address plan used in L3 mode instead of the addresses
+	// advertised in LLDP.
+	AddressPlan *AddressPlanSpec `json:"addressPlan,omitempty"`
+
+	// Name of a ScaleOutAddressPool to allocate the point-to-point
+	// addresses from in L3 mode instead of using the addresses advertised
+	// in LLDP.
+	AddressPool string `json:"addressPool,omitempty"`
+
+	// 802.1Q VLAN ID of the scale-out traffic in L3 mode. The addresses
+	// and routes are configured on a VLAN sub-interface of each scale-out
+	// interface instead of the interface itself. Address plan entries may
+	// override it per port.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4094
+	VLAN int `json:"vlan,omitempty"`
+
+	// Source of the scale-out interface addresses in L2 mode: 'static'
+	// from the address plan, 'dhcp' from a DHCP client on each interface or
+	// 'link-local' for the IPv6 link-local addresses. The addresses are
+	// written to gaudinet.json. Without it the interfaces are only set up.
+	// +kubebuilder:validation:Enum=static;dhcp;link-local
+	L2AddressSource string `json:"l2AddressSource,omitempty"`
+
+	// Only discover the interfaces and print the planned changes to the
+	// agent logs without applying them.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// ETSSpec defines the traffic class mapping and bandwidth allocation of
+// Enhanced Transmission Selection
+type ETSSpec struct {
+	// Traffic class of each priority starting from priority 0. Unlisted
+	// priorities use traffic class 0.
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:items:Minimum=0
+	// +kubebuilder:validation:items:Maximum=7
+	PriorityTrafficClasses []int `json:"priorityTrafficClasses,omitempty"`
+
+	// Bandwidth percentage of each traffic class starting from traffic
+	// class 0. The percentages must add up to 100 and the traffic classes
+	// of the priorities must have bandwidth.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:items:Minimum=0
+	// +kubebuilder:validation:items:Maximum=100
+	Bandwidths []int `json:"bandwidths"`
+}
+
+// DSCPPriority maps a DSCP value to a priority
+type DSCPPriority struct {
+	// DSCP value.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=63
+	DSCP int `json:"dscp"`
+
+	// Priority of the traffic with the DSCP value.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=7
+	Priority int `json:"priority"`
+}
+
+// LLDPAddressSpec defines how the switch port address is parsed from LLDP
+type LLDPAddressSpec struct {
+	// Format of the address. Possible options:
+	// no-alert: "<word> <CIDR>" in the Port Description (default).
+	// ip-equals: "ip=<CIDR>" in the Port Description or System Description.
+	// sys-description: first CIDR in the System Description.
+	// org-tlv: CIDR as text in an organizationally specific TLV.
+	// regex: first capture group of a regular expression matching the
+	// Port Description or System Description.
+	// +kubebuilder:validation:Enum=no-alert;ip-equals;sys-description;org-tlv;regex
+	Format string `json:"format,omitempty"`
+
+	// Regular expression with exactly one capture group matching the CIDR.
+	// Required with the regex format.
+	Regex string `json:"regex,omitempty"`
+
+	// Organizationally specific TLV OUI in hex and subtype in decimal as
+	// 'aabbcc:N'. Required with the org-tlv format.
+	// +kubebuilder:validation:Pattern=`^[0-9A-Fa-f]{6}:[0-9]{1,3}$`
+	OrgTLV string `json:"orgTLV,omitempty"`
+}
+
+// AddressPlanSpec defines statically assigned scale-out addresses
+type AddressPlanSpec struct {
+	// Address plan of each node.
+	Nodes []NodeAddressPlan `json:"nodes,omitempty"`
+
+	// Name of a ConfigMap in the operator namespace containing the
+	// interface addresses of each node as YAML under a key matching the
+	// node name. Used instead of nodes.
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Verify the planned peer addresses against the addresses advertised
+	// in LLDP. Mismatches are logged, the planned addresses are used.
+	VerifyLLDP bool `json:"verifyLLDP,omitempty"`
+}
+
+// NodeAddressPlan lists the interface addresses of a node
+type NodeAddressPlan struct {
+	// Name of the node.
+	NodeName string `json:"nodeName"`
+
+	// Addresses of the node's scale-out interfaces.
+	Interfaces []InterfaceAddress `json:"interfaces"`
+}
+
+// InterfaceAddress defines the address of a scale-out interface. The
+// interface is selected either by name or by Gaudi module ID and port.
+type InterfaceAddress struct {
+	// Name of the interface.
+	Interface string `json:"interface,omitempty"`
+
+	// Gaudi module ID of the interface.
+	// +kubebuilder:validation:Pattern=`^[0-9]+$`
+	Module string `json:"module,omitempty"`
+
+	// Index of the interface among the module's scale-out interfaces
+	// ordered by name.
+	// +kubebuilder:validation:Minimum=0
+	Port int `json:"port,omitempty"`
+
+	// Local address in CIDR notation, e.g. '10.210.8.121/30'.
+	Address string `json:"address"`
+
+	// Address of the switch port. Defaults to the other address of the
+	// point-to-point network. In L2 mode the optional gateway address.
+	Peer string `json:"peer,omitempty"`
+
+	// MAC address of the switch port, or of the gateway in L2 mode, for
+	// gaudinet.json. Learned from LLDP if not set.
+	PeerMAC string `json:"peerMAC,omitempty"`
+
+	// 802.1Q VLAN ID of the interface. Overrides the VLAN of the policy.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4094
+	VLAN int `json:"vlan,omitempty"`
+
+	// Destination networks in CIDR notation to route through the switch
+	// port of the interface in L3 mode. Overrides the route destinations
+	// and the routed prefix length of the policy for this interface.
+	RouteDestinations []string `json:"routeDestinations,omitempty"`
 }
 
 // RDMA device specification
@@ -110,12 +315,52 @@ type HostNicScaleOutSpec struct {
 	Dranet DranetSpec `json:"dranet,omitempty"`
 }
 
+const (
+	// ConditionAvailable is true when the configuration has been rolled out
+	// to all targeted nodes.
+	ConditionAvailable = "Available"
+
+	// ConditionProgressing is true while the configuration is being rolled
+	// out to the targeted nodes.
+	ConditionProgressing = "Progressing"
+
+	// ConditionDegraded is true when one or more targeted nodes failed to
+	// apply the configuration.
+	ConditionDegraded = "Degraded"
+
+	// ConditionDeviceClassReady is true when the DeviceClass requested in
+	// a hostnic-so policy exists.
+	ConditionDeviceClassReady = "DeviceClassReady"
+)
+
+// FailedNode describes a node that failed to apply the configuration
+type FailedNode struct {
+	// Name of the node.
+	Name string `json:"name"`
+
+	// Reason for the failure as reported by the node.
+	Message string `json:"message,omitempty"`
+}
+
 // NetworkClusterPolicyStatus defines the observed state of NetworkClusterPolicy
 type NetworkClusterPolicyStatus struct {
 	Targets    int32    `json:"targets"`
 	ReadyNodes int32    `json:"ready"`
 	State      string   `json:"state"`
 	Errors     []string `json:"errors"`
+
+	// Nodes that failed to apply the configuration.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	FailedNodes []FailedNode `json:"failedNodes,omitempty"`
+
+	// Standard conditions: Available, Progressing and Degraded. The
+	// hostnic-so policies also report DeviceClassReady.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
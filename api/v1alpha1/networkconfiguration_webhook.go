@@ -15,7 +15,10 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"net"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -58,6 +61,66 @@ func (e missingDeviceClassNameError) Error() string {
 	return "missing device class name"
 }
 
+type invalidRouteDestinationError struct{}
+
+func (e invalidRouteDestinationError) Error() string {
+	return "invalid route destination"
+}
+
+type invalidRoutedPrefixError struct{}
+
+func (e invalidRoutedPrefixError) Error() string {
+	return "routed prefix length is too long for the IPv4 addresses"
+}
+
+type invalidLLDPAddressError struct{}
+
+func (e invalidLLDPAddressError) Error() string {
+	return "invalid LLDP address format"
+}
+
+type invalidAddressPlanError struct{}
+
+func (e invalidAddressPlanError) Error() string {
+	return "invalid address plan"
+}
+
+type unsupportedNodeTaintError struct{}
+
+func (e unsupportedNodeTaintError) Error() string {
+	return "node taint is only supported with gaudi-so"
+}
+
+type invalidPFCPrioritiesError struct{}
+
+func (e invalidPFCPrioritiesError) Error() string {
+	return "invalid PFC priorities"
+}
+
+type invalidETSError struct{}
+
+func (e invalidETSError) Error() string {
+	return "invalid ETS configuration"
+}
+
+type invalidL2AddressSourceError struct{}
+
+func (e invalidL2AddressSourceError) Error() string {
+	return "invalid L2 address source"
+}
+
+type invalidVLANError struct{}
+
+func (e invalidVLANError) Error() string {
+	return "invalid VLAN ID"
+}
+
+type invalidDSCPPrioritiesError struct{}
+
+func (e invalidDSCPPrioritiesError) Error() string {
+	return "invalid DSCP priorities"
+}
+
 // SetupWebhookWithManager will setup the manager to manage the webhooks
 func (r *NetworkClusterPolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
@@ -97,8 +160,241 @@ var _ webhook.Validator = &NetworkClusterPolicy{}
 var labelHostRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9_\.]*)?[A-Za-z0-9]$`)
 var labelPathRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-\._\/]*)?[A-Za-z0-9]$`)
 var labelValueRegex = regexp.MustCompile(`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`)
+var orgTLVRegex = regexp.MustCompile(`^[0-9A-Fa-f]{6}:([0-9]{1,2}|1[0-9]{2}|2[0-4][0-9]|25[0-5])$`)
+var pfcBitmaskRegex = regexp.MustCompile(`^[01]{8}$`)
+
+// ParsePFCPriorities returns the ordered priorities enabled in a bitmask
+// such as '00010000', where the first digit is priority 0, or in a comma
+// separated list of priorities such as '0,1,2,3'.
+func ParsePFCPriorities(priorities string) ([]int, error) {
+	enabled := [8]bool{}
+
+	if pfcBitmaskRegex.MatchString(priorities) {
+		for i, c := range priorities {
+			enabled[i] = c == '1'
+		}
+	} else {
+		for _, s := range strings.Split(priorities, ",") {
+			prio, err := strconv.Atoi(s)
+			if err != nil || prio < 0 || prio > 7 || enabled[prio] {
+				return nil, invalidPFCPrioritiesError{}
+			}
+			enabled[prio] = true
+		}
+	}
+
+	result := []int{}
+	for prio, e := range enabled {
+		if e {
+			result = append(result, prio)
+		}
+	}
+
+	return result, nil
+}
 
 func validateGaudiSoSpec(s GaudiScaleOutSpec) error {
+	for _, destination := range s.RouteDestinations {
+		if _, _, err := net.ParseCIDR(destination); err != nil {
+			return invalidRouteDestinationError{}
+		}
+	}
+
+	if s.RoutedPrefixLength > 32 && planHasIPv4Address(s.AddressPlan) {
+		return invalidRoutedPrefixError{}
+	}
+
+	if err := validateLLDPAddress(s.LLDPAddress); err != nil {
+		return err
+	}
+
+	if s.PFCPriorities != "" {
+		if _, err := ParsePFCPriorities(s.PFCPriorities); err != nil {
+			return err
+		}
+	}
+
+	if err := validateETS(s.ETS); err != nil {
+		return err
+	}
+
+	if err := validateDSCPPriorities(s.DSCPPriorities); err != nil {
+		return err
+	}
+
+	// VLAN sub-interfaces carry the L3 configuration
+	if s.VLAN != 0 && (s.Layer != "L3" || !validVLAN(s.VLAN)) {
+		return invalidVLANError{}
+	}
+
+	if s.L2AddressSource != "" && (s.Layer != "L2" || (s.L2AddressSource == "static") != (s.AddressPlan != nil)) {
+		return invalidL2AddressSourceError{}
+	}
+
+	if s.AddressPool != "" && (s.Layer != "L3" || s.AddressPlan != nil) {
+		return invalidAddressPlanError{}
+	}
+
+	return validateAddressPlan(s)
+}
+
+func validateETS(e *ETSSpec) error {
+	if e == nil {
+		return nil
+	}
+
+	if len(e.PriorityTrafficClasses) > 8 || len(e.Bandwidths) == 0 || len(e.Bandwidths) > 8 {
+		return invalidETSError{}
+	}
+
+	total := 0
+	for _, bw := range e.Bandwidths {
+		if bw < 0 || bw > 100 {
+			return invalidETSError{}
+		}
+		total += bw
+	}
+	if total != 100 {
+		return invalidETSError{}
+	}
+
+	// unlisted priorities are in traffic class 0
+	trafficClasses := append([]int{0}, e.PriorityTrafficClasses...)
+	if len(e.PriorityTrafficClasses) == 8 {
+		trafficClasses = e.PriorityTrafficClasses
+	}
+
+	for _, tc := range trafficClasses {
+		if tc < 0 || tc > 7 || tc >= len(e.Bandwidths) || e.Bandwidths[tc] == 0 {
+			return invalidETSError{}
+		}
+	}
+
+	return nil
+}
+
+func validateDSCPPriorities(priorities []DSCPPriority) error {
+	dscps := map[int]bool{}
+	for _, p := range priorities {
+		if p.DSCP < 0 || p.DSCP > 63 || p.Priority < 0 || p.Priority > 7 || dscps[p.DSCP] {
+			return invalidDSCPPrioritiesError{}
+		}
+		dscps[p.DSCP] = true
+	}
+
+	return nil
+}
+
+func validateAddressPlan(s GaudiScaleOutSpec) error {
+	p := s.AddressPlan
+	if p == nil {
+		return nil
+	}
+
+	// L2 static addresses come from the address plan as well
+	usesPlan := s.Layer == "L3" || (s.Layer == "L2" && s.L2AddressSource == "static")
+
+	if !usesPlan || (len(p.Nodes) > 0) == (p.ConfigMapName != "") {
+		return invalidAddressPlanError{}
+	}
+
+	nodes := map[string]bool{}
+	for _, node := range p.Nodes {
+		if node.NodeName == "" || nodes[node.NodeName] {
+			return invalidAddressPlanError{}
+		}
+		nodes[node.NodeName] = true
+
+		if err := ValidateInterfaceAddresses(node.Interfaces); err != nil {
+			return invalidAddressPlanError{}
+		}
+	}
+
+	return nil
+}
+
+// planHasIPv4Address returns true if any interface of the address plan
+// has an IPv4 address. The addresses in a ConfigMap are only checked by
+// the discover agent.
+func planHasIPv4Address(p *AddressPlanSpec) bool {
+	if p == nil {
+		return false
+	}
+
+	for _, node := range p.Nodes {
+		for _, iface := range node.Interfaces {
+			if local, _, err := net.ParseCIDR(iface.Address); err == nil && local.To4() != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// validVLAN returns true if the ID is a usable 802.1Q VLAN ID.
+func validVLAN(id int) bool {
+	return id >= 1 && id <= 4094
+}
+
+// ValidateInterfaceAddresses checks the interface selectors and addresses
+// of a node address plan.
+func ValidateInterfaceAddresses(interfaces []InterfaceAddress) error {
+	for _, iface := range interfaces {
+		if (iface.Interface == "") == (iface.Module == "") {
+			return fmt.Errorf("either interface name or module must be set")
+		}
+
+		local, _, err := net.ParseCIDR(iface.Address)
+		if err != nil {
+			return fmt.Errorf("invalid address '%s': %v", iface.Address, err)
+		}
+
+		if iface.Peer != "" {
+			peer := net.ParseIP(iface.Peer)
+			if peer == nil || (peer.To4() == nil) != (local.To4() == nil) {
+				return fmt.Errorf("invalid peer address '%s'", iface.Peer)
+			}
+		}
+
+		if iface.PeerMAC != "" {
+			if _, err := net.ParseMAC(iface.PeerMAC); err != nil {
+				return fmt.Errorf("invalid peer MAC address '%s': %v", iface.PeerMAC, err)
+			}
+		}
+
+		if iface.VLAN != 0 && !validVLAN(iface.VLAN) {
+			return fmt.Errorf("invalid VLAN ID %d", iface.VLAN)
+		}
+
+		for _, dst := range iface.RouteDestinations {
+			dstNet, _, err := net.ParseCIDR(dst)
+			if err != nil || (dstNet.To4() == nil) != (local.To4() == nil) {
+				return fmt.Errorf("invalid route destination '%s'", dst)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateLLDPAddress(a *LLDPAddressSpec) error {
+	if a == nil {
+		return nil
+	}
+
+	switch a.Format {
+	case "regex":
+		re, err := regexp.Compile(a.Regex)
+		if err != nil || re.NumSubexp() != 1 {
+			return invalidLLDPAddressError{}
+		}
+	case "org-tlv":
+		if !orgTLVRegex.MatchString(a.OrgTLV) {
+			return invalidLLDPAddressError{}
+		}
+	}
+
 	return nil
 }
 
@@ -148,6 +444,9 @@ func validateSpec(s NetworkClusterPolicySpec) (admission.Warnings, error) {
 		}
 		return nil, validateGaudiSoSpec(s.GaudiScaleOut)
 	case hostNicScaleOut:
+		if s.TaintNotReadyNodes {
+			return nil, unsupportedNodeTaintError{}
+		}
 		return nil, validateHostNicSoSpec(s.HostNicScaleOut)
 	default:
 		return nil, unknownConfigurationError{}
@@ -135,6 +135,358 @@ var _ = Describe("NetworkClusterPolicy Webhook", func() {
 			Expect(nc2.ValidateUpdate(&nc)).Error().NotTo(BeNil())
 		})
 
+		It("Should validate route destinations InputVal", func() {
+			nc := NetworkClusterPolicy{
+				Spec: NetworkClusterPolicySpec{
+					ConfigurationType: gaudiScaleOut,
+					GaudiScaleOut: GaudiScaleOutSpec{
+						Layer:             "L3",
+						RouteDestinations: []string{"10.210.0.0/20", "2001:db8::/48"},
+					},
+					NodeSelector: map[string]string{
+						"foo": "bar",
+					},
+				},
+			}
+
+			Expect(nc.ValidateCreate()).Error().To(BeNil())
+
+			badValues := []string{"10.210.0.0", "10.210.0.0/33", "foo"}
+
+			for _, v := range badValues {
+				nc.Spec.GaudiScaleOut.RouteDestinations = []string{"10.210.0.0/20", v}
+
+				Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(invalidRouteDestinationError{}), "destination: %s", v)
+			}
+		})
+
+		It("Should validate LLDP address formats InputVal", func() {
+			nc := NetworkClusterPolicy{
+				Spec: NetworkClusterPolicySpec{
+					ConfigurationType: gaudiScaleOut,
+					GaudiScaleOut: GaudiScaleOutSpec{
+						Layer: "L3",
+					},
+					NodeSelector: map[string]string{
+						"foo": "bar",
+					},
+				},
+			}
+
+			goodValues := []LLDPAddressSpec{
+				{Format: "no-alert"},
+				{Format: "ip-equals"},
+				{Format: "regex", Regex: `addr:(\S+)`},
+				{Format: "org-tlv", OrgTLV: "0012bb:7"},
+			}
+
+			for _, v := range goodValues {
+				nc.Spec.GaudiScaleOut.LLDPAddress = &v
+
+				Expect(nc.ValidateCreate()).Error().To(BeNil(), "lldp address: %+v", v)
+			}
+
+			badValues := []LLDPAddressSpec{
+				{Format: "regex"},
+				{Format: "regex", Regex: "("},
+				{Format: "regex", Regex: "(a)(b)"},
+				{Format: "org-tlv"},
+				{Format: "org-tlv", OrgTLV: "0012bb:256"},
+			}
+
+			for _, v := range badValues {
+				nc.Spec.GaudiScaleOut.LLDPAddress = &v
+
+				Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(invalidLLDPAddressError{}), "lldp address: %+v", v)
+			}
+		})
+
+		It("Should validate VLAN IDs InputVal", func() {
+			nc := NetworkClusterPolicy{
+				Spec: NetworkClusterPolicySpec{
+					ConfigurationType: gaudiScaleOut,
+					GaudiScaleOut: GaudiScaleOutSpec{
+						Layer: "L3",
+					},
+					NodeSelector: map[string]string{
+						"foo": "bar",
+					},
+				},
+			}
+
+			for _, v := range []int{0, 1, 100, 4094} {
+				nc.Spec.GaudiScaleOut.VLAN = v
+
+				Expect(nc.ValidateCreate()).Error().To(BeNil(), "vlan: %d", v)
+			}
+
+			for _, v := range []int{-1, 4095} {
+				nc.Spec.GaudiScaleOut.VLAN = v
+
+				Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(invalidVLANError{}), "vlan: %d", v)
+			}
+
+			nc.Spec.GaudiScaleOut.Layer = "L2"
+			nc.Spec.GaudiScaleOut.VLAN = 100
+
+			Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(invalidVLANError{}))
+		})
+
+		It("Should validate L2 address sources InputVal", func() {
+			nc := NetworkClusterPolicy{
+				Spec: NetworkClusterPolicySpec{
+					ConfigurationType: gaudiScaleOut,
+					GaudiScaleOut: GaudiScaleOutSpec{
+						Layer: "L2",
+					},
+					NodeSelector: map[string]string{
+						"foo": "bar",
+					},
+				},
+			}
+
+			plan := &AddressPlanSpec{Nodes: []NodeAddressPlan{{
+				NodeName:   "node-a",
+				Interfaces: []InterfaceAddress{{Interface: "eth0", Address: "192.168.10.5/24", Peer: "192.168.10.1"}},
+			}}}
+
+			for _, v := range []string{"", "dhcp", "link-local"} {
+				nc.Spec.GaudiScaleOut.L2AddressSource = v
+
+				Expect(nc.ValidateCreate()).Error().To(BeNil(), "source: %s", v)
+			}
+
+			nc.Spec.GaudiScaleOut.L2AddressSource = "static"
+			nc.Spec.GaudiScaleOut.AddressPlan = plan
+
+			Expect(nc.ValidateCreate()).Error().To(BeNil())
+
+			// static addresses need the address plan and only come from it
+			for _, v := range []string{"dhcp", "link-local"} {
+				nc.Spec.GaudiScaleOut.L2AddressSource = v
+
+				Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(invalidL2AddressSourceError{}), "source: %s", v)
+			}
+
+			nc.Spec.GaudiScaleOut.L2AddressSource = "static"
+			nc.Spec.GaudiScaleOut.AddressPlan = nil
+
+			Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(invalidL2AddressSourceError{}))
+
+			nc.Spec.GaudiScaleOut.Layer = "L3"
+			nc.Spec.GaudiScaleOut.L2AddressSource = "dhcp"
+
+			Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(invalidL2AddressSourceError{}))
+		})
+
+		It("Should validate address plans InputVal", func() {
+			nc := NetworkClusterPolicy{
+				Spec: NetworkClusterPolicySpec{
+					ConfigurationType: gaudiScaleOut,
+					GaudiScaleOut: GaudiScaleOutSpec{
+						Layer: "L3",
+					},
+					NodeSelector: map[string]string{
+						"foo": "bar",
+					},
+				},
+			}
+
+			node := func(name string, interfaces ...InterfaceAddress) NodeAddressPlan {
+				return NodeAddressPlan{NodeName: name, Interfaces: interfaces}
+			}
+
+			goodValues := []AddressPlanSpec{
+				{ConfigMapName: "plan"},
+				{Nodes: []NodeAddressPlan{
+					node("node-a", InterfaceAddress{Interface: "eth0", Address: "10.210.8.121/30"}),
+					node("node-b", InterfaceAddress{Module: "3", Port: 1, Address: "2001:db8::1/127", Peer: "2001:db8::", PeerMAC: "01:01:02:02:03:03", VLAN: 100}),
+				}},
+				{Nodes: []NodeAddressPlan{
+					node("node-a", InterfaceAddress{Interface: "eth0", Address: "10.210.8.121/30", RouteDestinations: []string{"10.220.0.0/16"}}),
+				}},
+			}
+
+			for _, v := range goodValues {
+				nc.Spec.GaudiScaleOut.AddressPlan = &v
+
+				Expect(nc.ValidateCreate()).Error().To(BeNil(), "address plan: %+v", v)
+			}
+
+			badValues := []AddressPlanSpec{
+				{},
+				{ConfigMapName: "plan", Nodes: []NodeAddressPlan{node("node-a")}},
+				{Nodes: []NodeAddressPlan{node("")}},
+				{Nodes: []NodeAddressPlan{node("node-a"), node("node-a")}},
+				{Nodes: []NodeAddressPlan{node("node-a", InterfaceAddress{Address: "10.210.8.121/30"})}},
+				{Nodes: []NodeAddressPlan{node("node-a", InterfaceAddress{Interface: "eth0", Module: "1", Address: "10.210.8.121/30"})}},
+				{Nodes: []NodeAddressPlan{node("node-a", InterfaceAddress{Interface: "eth0", Address: "10.210.8.121"})}},
+				{Nodes: []NodeAddressPlan{node("node-a", InterfaceAddress{Interface: "eth0", Address: "10.210.8.121/30", Peer: "2001:db8::"})}},
+				{Nodes: []NodeAddressPlan{node("node-a", InterfaceAddress{Interface: "eth0", Address: "10.210.8.121/30", PeerMAC: "foo"})}},
+				{Nodes: []NodeAddressPlan{node("node-a", InterfaceAddress{Interface: "eth0", Address: "10.210.8.121/30", VLAN: 4095})}},
+				{Nodes: []NodeAddressPlan{node("node-a", InterfaceAddress{Interface: "eth0", Address: "10.210.8.121/30", RouteDestinations: []string{"foo"}})}},
+				{Nodes: []NodeAddressPlan{node("node-a", InterfaceAddress{Interface: "eth0", Address: "10.210.8.121/30", RouteDestinations: []string{"2001:db8::/48"}})}},
+			}
+
+			for _, v := range badValues {
+				nc.Spec.GaudiScaleOut.AddressPlan = &v
+
+				Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(invalidAddressPlanError{}), "address plan: %+v", v)
+			}
+
+			// IPv4 addresses with an IPv6 routed prefix length
+			nc.Spec.GaudiScaleOut.AddressPlan = &goodValues[1]
+			nc.Spec.GaudiScaleOut.RoutedPrefixLength = 48
+
+			Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(invalidRoutedPrefixError{}))
+
+			nc.Spec.GaudiScaleOut.RoutedPrefixLength = 0
+			nc.Spec.GaudiScaleOut.Layer = "L2"
+			nc.Spec.GaudiScaleOut.AddressPlan = &goodValues[0]
+
+			Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(invalidAddressPlanError{}))
+
+			// Address pool
+			nc.Spec.GaudiScaleOut.AddressPlan = nil
+			nc.Spec.GaudiScaleOut.AddressPool = "pool"
+
+			Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(invalidAddressPlanError{}))
+
+			nc.Spec.GaudiScaleOut.Layer = "L3"
+
+			Expect(nc.ValidateCreate()).Error().To(BeNil())
+
+			nc.Spec.GaudiScaleOut.AddressPlan = &goodValues[0]
+
+			Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(invalidAddressPlanError{}))
+		})
+
+		It("Should validate PFC priorities InputVal", func() {
+			nc := NetworkClusterPolicy{
+				Spec: NetworkClusterPolicySpec{
+					ConfigurationType: gaudiScaleOut,
+					GaudiScaleOut: GaudiScaleOutSpec{
+						Layer: "L3",
+					},
+					NodeSelector: map[string]string{
+						"foo": "bar",
+					},
+				},
+			}
+
+			goodValues := map[string][]int{
+				"00000000": {},
+				"11110000": {0, 1, 2, 3},
+				"00010000": {3},
+				"3":        {3},
+				"7,0,5":    {0, 5, 7},
+			}
+			for v, expected := range goodValues {
+				nc.Spec.GaudiScaleOut.PFCPriorities = v
+				Expect(nc.ValidateCreate()).Error().To(BeNil(), "PFC priorities: %s", v)
+				Expect(ParsePFCPriorities(v)).To(Equal(expected), "PFC priorities: %s", v)
+			}
+
+			badValues := []string{"0001000", "000100000", "8", "1,1", "1,", "1,,2", "foo", "-1"}
+			for _, v := range badValues {
+				nc.Spec.GaudiScaleOut.PFCPriorities = v
+				Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(invalidPFCPrioritiesError{}), "PFC priorities: %s", v)
+			}
+		})
+
+		It("Should validate ETS configurations InputVal", func() {
+			nc := NetworkClusterPolicy{
+				Spec: NetworkClusterPolicySpec{
+					ConfigurationType: gaudiScaleOut,
+					GaudiScaleOut: GaudiScaleOutSpec{
+						Layer: "L3",
+					},
+					NodeSelector: map[string]string{
+						"foo": "bar",
+					},
+				},
+			}
+
+			goodValues := []ETSSpec{
+				{Bandwidths: []int{100}},
+				{PriorityTrafficClasses: []int{0, 0, 0, 1}, Bandwidths: []int{50, 50}},
+				{PriorityTrafficClasses: []int{1, 1, 1, 1, 1, 1, 1, 1}, Bandwidths: []int{0, 100}},
+			}
+			for _, v := range goodValues {
+				nc.Spec.GaudiScaleOut.ETS = &v
+				Expect(nc.ValidateCreate()).Error().To(BeNil(), "ETS: %+v", v)
+			}
+
+			badValues := []ETSSpec{
+				{},
+				{Bandwidths: []int{50, 40}},
+				{Bandwidths: []int{110, -10}},
+				{PriorityTrafficClasses: []int{0, 2}, Bandwidths: []int{50, 50}},
+				{PriorityTrafficClasses: []int{1}, Bandwidths: []int{0, 100}},
+				{PriorityTrafficClasses: []int{0, 8}, Bandwidths: []int{100}},
+				{PriorityTrafficClasses: []int{0, 0, 0, 0, 0, 0, 0, 0, 0}, Bandwidths: []int{100}},
+			}
+			for _, v := range badValues {
+				nc.Spec.GaudiScaleOut.ETS = &v
+				Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(invalidETSError{}), "ETS: %+v", v)
+			}
+		})
+
+		It("Should validate DSCP priorities InputVal", func() {
+			nc := NetworkClusterPolicy{
+				Spec: NetworkClusterPolicySpec{
+					ConfigurationType: gaudiScaleOut,
+					GaudiScaleOut: GaudiScaleOutSpec{
+						Layer: "L3",
+						DSCPPriorities: []DSCPPriority{
+							{DSCP: 26, Priority: 3},
+							{DSCP: 48, Priority: 6},
+						},
+					},
+					NodeSelector: map[string]string{
+						"foo": "bar",
+					},
+				},
+			}
+
+			Expect(nc.ValidateCreate()).Error().To(BeNil())
+
+			badValues := [][]DSCPPriority{
+				{{DSCP: 26, Priority: 3}, {DSCP: 26, Priority: 4}},
+				{{DSCP: 64, Priority: 3}},
+				{{DSCP: 26, Priority: 8}},
+			}
+			for _, v := range badValues {
+				nc.Spec.GaudiScaleOut.DSCPPriorities = v
+				Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(invalidDSCPPrioritiesError{}), "DSCP priorities: %+v", v)
+			}
+		})
+
+		It("Should only accept node taints with Gaudi InputVal", func() {
+			nc := NetworkClusterPolicy{
+				Spec: NetworkClusterPolicySpec{
+					ConfigurationType: gaudiScaleOut,
+					GaudiScaleOut: GaudiScaleOutSpec{
+						Layer: "L3",
+					},
+					NodeSelector: map[string]string{
+						"foo": "bar",
+					},
+					TaintNotReadyNodes: true,
+				},
+			}
+
+			Expect(nc.ValidateCreate()).Error().To(BeNil())
+
+			nc.Spec.ConfigurationType = hostNicScaleOut
+
+			Expect(nc.ValidateCreate()).Error().To(BeEquivalentTo(unsupportedNodeTaintError{}))
+
+			nc.Spec.TaintNotReadyNodes = false
+
+			Expect(nc.ValidateCreate()).Error().To(BeNil())
+		})
+
 		It("Should always accept delete", func() {
 			nc := NetworkClusterPolicy{
 				Spec: NetworkClusterPolicySpec{
@@ -0,0 +1,138 @@
+// Copyright 2026 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// NodeStatePolicyLabel is set on NetworkNodeState objects to the name
+	// of the NetworkClusterPolicy they are reported for.
+	NodeStatePolicyLabel = "intel.com/network-cluster-policy"
+
+	// Node state values reported by the discover agent.
+	NodeStateDiscovered = "Discovered"
+	NodeStateConfigured = "Configured"
+	NodeStateFailed     = "Failed"
+	NodeStateStopped    = "Stopped"
+
+	// ScaleOutNotReadyTaint is the key of the NoSchedule taint set on the
+	// nodes whose scale-out network is not configured.
+	ScaleOutNotReadyTaint = "intel.com/scale-out-not-ready"
+
+	// ScaleOutReadyCondition is the Node condition and ScaleOutLabel the
+	// prefix of the Node labels the discover agent publishes the
+	// scale-out readiness with when the readiness is set to node.
+	ScaleOutReadyCondition = "GaudiScaleOutReady"
+	ScaleOutLabel          = "intel.feature.node.kubernetes.io/gaudi-scale-out"
+)
+
+// NetworkNodeStateSpec defines the policy a NetworkNodeState is reported for
+type NetworkNodeStateSpec struct {
+	// Name of the NetworkClusterPolicy that configured the node.
+	PolicyName string `json:"policyName"`
+}
+
+// InterfaceState describes one scale-out interface on the node
+type InterfaceState struct {
+	// Network interface name.
+	Name string `json:"name"`
+
+	// Accelerator module ID the interface belongs to.
+	ModuleID string `json:"moduleId,omitempty"`
+
+	// MAC address of the interface.
+	MAC string `json:"mac,omitempty"`
+
+	// Whether the interface is administratively up.
+	Up bool `json:"up"`
+
+	// MTU of the interface.
+	MTU int `json:"mtu,omitempty"`
+
+	// MAC address of the link peer as received via LLDP.
+	PeerMAC string `json:"peerMac,omitempty"`
+
+	// LLDP Port Description of the link peer.
+	PortDescription string `json:"portDescription,omitempty"`
+
+	// LLDP Port ID of the link peer, the switch port of the interface.
+	PortID string `json:"portId,omitempty"`
+
+	// LLDP System Name of the link peer, the switch of the interface.
+	SysName string `json:"sysName,omitempty"`
+
+	// LLDP System Description of the link peer.
+	SysDescription string `json:"sysDescription,omitempty"`
+
+	// Address of the link peer parsed from LLDP.
+	PeerAddress string `json:"peerAddress,omitempty"`
+
+	// Local address with prefix length selected for the interface.
+	LocalAddress string `json:"localAddress,omitempty"`
+
+	// Priority Flow Control priorities enabled on the interface.
+	PFC string `json:"pfc,omitempty"`
+
+	// Whether the address and routes were configured successfully.
+	Configured bool `json:"configured"`
+}
+
+// NetworkNodeStateStatus defines the observed state of NetworkNodeState
+type NetworkNodeStateStatus struct {
+	// Overall state of the node. One of Discovered, Configured, Failed or
+	// Stopped.
+	State string `json:"state,omitempty"`
+
+	// Reason for a failed state.
+	Message string `json:"message,omitempty"`
+
+	// Scale-out interfaces found on the node.
+	Interfaces []InterfaceState `json:"interfaces,omitempty"`
+
+	// Time of the last update from the node.
+	LastUpdated metav1.Time `json:"lastUpdated,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=networknodestates,scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Policy",type=string,JSONPath=`.spec.policyName`
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NetworkNodeState is the Schema for the networknodestates API. The object
+// is named after the node and written by the discover agent running on it.
+type NetworkNodeState struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NetworkNodeStateSpec   `json:"spec,omitempty"`
+	Status NetworkNodeStateStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NetworkNodeStateList contains a list of NetworkNodeState
+type NetworkNodeStateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NetworkNodeState `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NetworkNodeState{}, &NetworkNodeStateList{})
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScaleOutAddressPoolSpec defines the network point-to-point subnets are
+// allocated from
+type ScaleOutAddressPoolSpec struct {
+	// Network to allocate the point-to-point subnets from in CIDR notation,
+	// e.g. '10.210.0.0/16'.
+	CIDR string `json:"cidr"`
+
+	// Prefix length of the allocated point-to-point subnets. 30 for IPv4,
+	// 127 or 126 for IPv6. Defaults to 30 for IPv4 and 127 for IPv6.
+	// +kubebuilder:validation:Enum=30;126;127
+	PrefixLength int `json:"prefixLength,omitempty"`
+}
+
+// AddressAllocation is a point-to-point subnet allocated to a node interface
+type AddressAllocation struct {
+	// Name of the node.
+	NodeName string `json:"nodeName"`
+
+	// Name of the interface.
+	Interface string `json:"interface"`
+
+	// Local address of the interface in CIDR notation.
+	Address string `json:"address"`
+
+	// Address of the link peer.
+	Peer string `json:"peer"`
+
+	// Time the interface was last reported for a policy using the pool.
+	// Set when the interface is no longer reported, the subnet is released
+	// after a grace period so that restarted agents keep their addresses.
+	UnusedSince *metav1.Time `json:"unusedSince,omitempty"`
+}
+
+// ScaleOutAddressPoolStatus defines the observed state of ScaleOutAddressPool
+type ScaleOutAddressPoolStatus struct {
+	// Point-to-point subnets allocated to node interfaces.
+	// +listType=map
+	// +listMapKey=nodeName
+	// +listMapKey=interface
+	Allocations []AddressAllocation `json:"allocations,omitempty"`
+
+	// Number of subnets allocated.
+	Allocated int `json:"allocated"`
+
+	// Number of subnets still available.
+	Available int64 `json:"available"`
+
+	// Reason the latest allocation failed, e.g. the pool is exhausted.
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=scaleoutaddresspools,scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="CIDR",type=string,JSONPath=`.spec.cidr`
+//+kubebuilder:printcolumn:name="Allocated",type=integer,JSONPath=`.status.allocated`
+//+kubebuilder:printcolumn:name="Available",type=integer,JSONPath=`.status.available`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ScaleOutAddressPool is the Schema for the scaleoutaddresspools API. The
+// operator allocates point-to-point subnets from the pool to the scale-out
+// interfaces of the nodes configured by policies referencing the pool.
+type ScaleOutAddressPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ScaleOutAddressPoolSpec   `json:"spec,omitempty"`
+	Status ScaleOutAddressPoolStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ScaleOutAddressPoolList contains a list of ScaleOutAddressPool
+type ScaleOutAddressPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ScaleOutAddressPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ScaleOutAddressPool{}, &ScaleOutAddressPoolList{})
+}
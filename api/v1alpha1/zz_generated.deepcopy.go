@@ -21,9 +21,66 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddressAllocation) DeepCopyInto(out *AddressAllocation) {
+	*out = *in
+	if in.UnusedSince != nil {
+		in, out := &in.UnusedSince, &out.UnusedSince
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressAllocation.
+func (in *AddressAllocation) DeepCopy() *AddressAllocation {
+	if in == nil {
+		return nil
+	}
+	out := new(AddressAllocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddressPlanSpec) DeepCopyInto(out *AddressPlanSpec) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeAddressPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressPlanSpec.
+func (in *AddressPlanSpec) DeepCopy() *AddressPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AddressPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DSCPPriority) DeepCopyInto(out *DSCPPriority) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DSCPPriority.
+func (in *DSCPPriority) DeepCopy() *DSCPPriority {
+	if in == nil {
+		return nil
+	}
+	out := new(DSCPPriority)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DranetSpec) DeepCopyInto(out *DranetSpec) {
 	*out = *in
@@ -44,9 +101,74 @@ func (in *DranetSpec) DeepCopy() *DranetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ETSSpec) DeepCopyInto(out *ETSSpec) {
+	*out = *in
+	if in.PriorityTrafficClasses != nil {
+		in, out := &in.PriorityTrafficClasses, &out.PriorityTrafficClasses
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bandwidths != nil {
+		in, out := &in.Bandwidths, &out.Bandwidths
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ETSSpec.
+func (in *ETSSpec) DeepCopy() *ETSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ETSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedNode) DeepCopyInto(out *FailedNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedNode.
+func (in *FailedNode) DeepCopy() *FailedNode {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GaudiScaleOutSpec) DeepCopyInto(out *GaudiScaleOutSpec) {
 	*out = *in
+	if in.ETS != nil {
+		in, out := &in.ETS, &out.ETS
+		*out = new(ETSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DSCPPriorities != nil {
+		in, out := &in.DSCPPriorities, &out.DSCPPriorities
+		*out = make([]DSCPPriority, len(*in))
+		copy(*out, *in)
+	}
+	if in.RouteDestinations != nil {
+		in, out := &in.RouteDestinations, &out.RouteDestinations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LLDPAddress != nil {
+		in, out := &in.LLDPAddress, &out.LLDPAddress
+		*out = new(LLDPAddressSpec)
+		**out = **in
+	}
+	if in.AddressPlan != nil {
+		in, out := &in.AddressPlan, &out.AddressPlan
+		*out = new(AddressPlanSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaudiScaleOutSpec.
@@ -75,6 +197,56 @@ func (in *HostNicScaleOutSpec) DeepCopy() *HostNicScaleOutSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceAddress) DeepCopyInto(out *InterfaceAddress) {
+	*out = *in
+	if in.RouteDestinations != nil {
+		in, out := &in.RouteDestinations, &out.RouteDestinations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceAddress.
+func (in *InterfaceAddress) DeepCopy() *InterfaceAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceState) DeepCopyInto(out *InterfaceState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceState.
+func (in *InterfaceState) DeepCopy() *InterfaceState {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LLDPAddressSpec) DeepCopyInto(out *LLDPAddressSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLDPAddressSpec.
+func (in *LLDPAddressSpec) DeepCopy() *LLDPAddressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LLDPAddressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkClusterPolicy) DeepCopyInto(out *NetworkClusterPolicy) {
 	*out = *in
@@ -144,7 +316,7 @@ func (in *NetworkClusterPolicySpec) DeepCopyInto(out *NetworkClusterPolicySpec)
 			(*out)[key] = val
 		}
 	}
-	out.GaudiScaleOut = in.GaudiScaleOut
+	in.GaudiScaleOut.DeepCopyInto(&out.GaudiScaleOut)
 	in.HostNicScaleOut.DeepCopyInto(&out.HostNicScaleOut)
 }
 
@@ -166,6 +338,18 @@ func (in *NetworkClusterPolicyStatus) DeepCopyInto(out *NetworkClusterPolicyStat
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.FailedNodes != nil {
+		in, out := &in.FailedNodes, &out.FailedNodes
+		*out = make([]FailedNode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkClusterPolicyStatus.
@@ -178,6 +362,123 @@ func (in *NetworkClusterPolicyStatus) DeepCopy() *NetworkClusterPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkNodeState) DeepCopyInto(out *NetworkNodeState) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkNodeState.
+func (in *NetworkNodeState) DeepCopy() *NetworkNodeState {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkNodeState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkNodeState) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkNodeStateList) DeepCopyInto(out *NetworkNodeStateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NetworkNodeState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkNodeStateList.
+func (in *NetworkNodeStateList) DeepCopy() *NetworkNodeStateList {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkNodeStateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkNodeStateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkNodeStateSpec) DeepCopyInto(out *NetworkNodeStateSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkNodeStateSpec.
+func (in *NetworkNodeStateSpec) DeepCopy() *NetworkNodeStateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkNodeStateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkNodeStateStatus) DeepCopyInto(out *NetworkNodeStateStatus) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]InterfaceState, len(*in))
+		copy(*out, *in)
+	}
+	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkNodeStateStatus.
+func (in *NetworkNodeStateStatus) DeepCopy() *NetworkNodeStateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkNodeStateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAddressPlan) DeepCopyInto(out *NodeAddressPlan) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]InterfaceAddress, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAddressPlan.
+func (in *NodeAddressPlan) DeepCopy() *NodeAddressPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAddressPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RDMADeviceClassSpec) DeepCopyInto(out *RDMADeviceClassSpec) {
 	*out = *in
@@ -192,3 +493,99 @@ func (in *RDMADeviceClassSpec) DeepCopy() *RDMADeviceClassSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleOutAddressPool) DeepCopyInto(out *ScaleOutAddressPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleOutAddressPool.
+func (in *ScaleOutAddressPool) DeepCopy() *ScaleOutAddressPool {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleOutAddressPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScaleOutAddressPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleOutAddressPoolList) DeepCopyInto(out *ScaleOutAddressPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScaleOutAddressPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleOutAddressPoolList.
+func (in *ScaleOutAddressPoolList) DeepCopy() *ScaleOutAddressPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleOutAddressPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScaleOutAddressPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleOutAddressPoolSpec) DeepCopyInto(out *ScaleOutAddressPoolSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleOutAddressPoolSpec.
+func (in *ScaleOutAddressPoolSpec) DeepCopy() *ScaleOutAddressPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleOutAddressPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleOutAddressPoolStatus) DeepCopyInto(out *ScaleOutAddressPoolStatus) {
+	*out = *in
+	if in.Allocations != nil {
+		in, out := &in.Allocations, &out.Allocations
+		*out = make([]AddressAllocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleOutAddressPoolStatus.
+func (in *ScaleOutAddressPoolStatus) DeepCopy() *ScaleOutAddressPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleOutAddressPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+)
+
+// readAddressPlan reads the interface addresses of the node from the
+// address plan directory, which has one YAML file per node named after
+// the node.
+func readAddressPlan(dir, nodeName string) ([]networkv1alpha1.InterfaceAddress, error) {
+	data, err := os.ReadFile(filepath.Join(dir, nodeName))
+	if err != nil {
+		return nil, fmt.Errorf("no address plan for node '%s': %v", nodeName, err)
+	}
+
+	plan := []networkv1alpha1.InterfaceAddress{}
+	if err := yaml.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("cannot parse address plan for node '%s': %v", nodeName, err)
+	}
+
+	if err := networkv1alpha1.ValidateInterfaceAddresses(plan); err != nil {
+		return nil, fmt.Errorf("invalid address plan for node '%s': %v", nodeName, err)
+	}
+
+	return plan, nil
+}
+
+// planInterface returns the interface selected by the address plan entry
+// either by name or by module ID and port index.
+func planInterface(entry networkv1alpha1.InterfaceAddress, networkConfigs map[string]*networkConfiguration) (*networkConfiguration, error) {
+	if entry.Interface != "" {
+		nwconfig, exists := networkConfigs[entry.Interface]
+		if !exists {
+			return nil, fmt.Errorf("interface '%s' not found", entry.Interface)
+		}
+		return nwconfig, nil
+	}
+
+	ifnames := []string{}
+	for ifname, nwconfig := range networkConfigs {
+		if nwconfig.moduleId == entry.Module {
+			ifnames = append(ifnames, ifname)
+		}
+	}
+	sort.Strings(ifnames)
+
+	if entry.Port >= len(ifnames) {
+		return nil, fmt.Errorf("module '%s' has no port %d", entry.Module, entry.Port)
+	}
+
+	return networkConfigs[ifnames[entry.Port]], nil
+}
+
+// applyAddressPlan sets the local and peer addresses of the interfaces
+// from the address plan. Returns true if any interface got an address.
+func applyAddressPlan(plan []networkv1alpha1.InterfaceAddress, networkConfigs map[string]*networkConfiguration) bool {
+	foundpeers := false
+
+	for _, entry := range plan {
+		nwconfig, err := planInterface(entry, networkConfigs)
+		if err != nil {
+			klog.Warningf("Skipping address plan entry %s: %v", entry.Address, err)
+			continue
+		}
+		ifname := nwconfig.link.Attrs().Name
+
+		localAddr, localNetwork, err := net.ParseCIDR(entry.Address)
+		if err != nil {
+			klog.Warningf("Interface '%s' address plan: %v", ifname, err)
+			continue
+		}
+
+		peer := net.ParseIP(entry.Peer)
+		if peer == nil {
+			if peer, err = otherPointToPointAddress(localAddr, localNetwork); err != nil {
+				klog.Warningf("Interface '%s' has no peer address in the address plan and %v", ifname, err)
+				continue
+			}
+		}
+
+		if entry.PeerMAC != "" {
+			hwaddr, err := net.ParseMAC(entry.PeerMAC)
+			if err != nil {
+				klog.Warningf("Interface '%s' address plan: %v", ifname, err)
+				continue
+			}
+			nwconfig.peerHWAddr = &hwaddr
+		}
+
+		routeDsts := []*net.IPNet{}
+		for _, dst := range entry.RouteDestinations {
+			_, dstNet, err := net.ParseCIDR(dst)
+			if err != nil {
+				klog.Warningf("Interface '%s' address plan: %v", ifname, err)
+				continue
+			}
+			routeDsts = append(routeDsts, dstNet)
+		}
+
+		nwconfig.localAddr = &localAddr
+		nwconfig.localPrefixLen, _ = localNetwork.Mask.Size()
+		nwconfig.planRouteDsts = routeDsts
+		nwconfig.lldpPeer = &peer
+		if entry.VLAN != 0 {
+			nwconfig.vlanID = entry.VLAN
+		}
+		foundpeers = true
+
+		klog.V(3).Infof("Interface '%s' planned address %s peer %s", ifname, entry.Address, peer)
+	}
+
+	return foundpeers
+}
+
+// verifyAddressPlan compares the planned peer addresses with the ones
+// advertised in LLDP. Returns the number of mismatching interfaces.
+func verifyAddressPlan(networkConfigs map[string]*networkConfiguration) int {
+	mismatches := 0
+
+	for ifname, nwconfig := range networkConfigs {
+		if nwconfig.lldpPeer == nil {
+			continue
+		}
+
+		lldpPeer, _, _, err := selectPointToPointL3Address(nwconfig)
+		if err != nil {
+			klog.Warningf("Interface '%s' cannot verify planned address: %v", ifname, err)
+			mismatches++
+			continue
+		}
+
+		if !lldpPeer.Equal(*nwconfig.lldpPeer) {
+			klog.Warningf("Interface '%s' planned peer %s does not match LLDP peer %s",
+				ifname, nwconfig.lldpPeer, lldpPeer)
+			mismatches++
+		}
+	}
+
+	return mismatches
+}
+
+// addressPlanResults configures the interface addresses from the address
+// plan, optionally verifying them with LLDP.
+func addressPlanResults(config *cmdConfig, networkConfigs map[string]*networkConfiguration) (bool, error) {
+	plan, err := readAddressPlan(config.addressPlan, config.nodeName)
+	if err != nil {
+		return false, err
+	}
+
+	return applyVerifiedAddressPlan(config, plan, networkConfigs), nil
+}
+
+// applyVerifiedAddressPlan applies the address plan to the interfaces,
+// optionally verifying the planned addresses with LLDP.
+func applyVerifiedAddressPlan(config *cmdConfig, plan []networkv1alpha1.InterfaceAddress, networkConfigs map[string]*networkConfiguration) bool {
+	if config.verifyLLDP {
+		detectLLDP(config, networkConfigs)
+	}
+
+	foundpeers := applyAddressPlan(plan, networkConfigs)
+
+	if config.verifyLLDP {
+		if mismatches := verifyAddressPlan(networkConfigs); mismatches > 0 {
+			klog.Warningf("%d interfaces do not match the address plan in LLDP", mismatches)
+		} else {
+			klog.Infof("Address plan verified with LLDP")
+		}
+	}
+
+	return foundpeers
+}
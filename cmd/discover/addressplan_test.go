@@ -0,0 +1,145 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testAddressPlan = `
+- interface: eth_a
+  address: 10.210.8.121/30
+- module: "1"
+  port: 1
+  address: "2001:db8::1/127"
+  peer: "2001:db8::"
+  peerMAC: "01:01:02:02:03:04"
+  routeDestinations: ["2001:db8:100::/48"]
+`
+
+func writeTestAddressPlan(t *testing.T, contents string) string {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, testNodeName), []byte(contents), 0644); err != nil {
+		t.Fatalf("cannot write address plan: %v", err)
+	}
+
+	return dir
+}
+
+func TestReadAddressPlan(t *testing.T) {
+	dir := writeTestAddressPlan(t, testAddressPlan)
+
+	plan, err := readAddressPlan(dir, testNodeName)
+	if err != nil || len(plan) != 2 {
+		t.Fatalf("unexpected address plan %v: %v", plan, err)
+	}
+	if plan[1].Module != "1" || plan[1].Port != 1 || plan[1].Peer != "2001:db8::" {
+		t.Errorf("unexpected address plan entry %+v", plan[1])
+	}
+
+	if _, err := readAddressPlan(dir, "node-b"); err == nil {
+		t.Error("expected an error for a node without address plan")
+	}
+
+	for _, contents := range []string{
+		"foo",
+		"- address: 10.210.8.121/30",
+		"- interface: eth_a\n  address: 10.210.8.121",
+	} {
+		if _, err := readAddressPlan(writeTestAddressPlan(t, contents), testNodeName); err == nil {
+			t.Errorf("expected an error for address plan '%s'", contents)
+		}
+	}
+}
+
+func TestApplyAddressPlan(t *testing.T) {
+	dir := writeTestAddressPlan(t, testAddressPlan)
+	plan, err := readAddressPlan(dir, testNodeName)
+	if err != nil {
+		t.Fatalf("cannot read address plan: %v", err)
+	}
+
+	nwconfigs := getFakeNetworkDataConfigs()
+	nwconfigs["eth_b"].moduleId = "1"
+	nwconfigs["eth_c"].moduleId = "1"
+
+	if !applyAddressPlan(plan, nwconfigs) {
+		t.Fatal("no addresses applied from the address plan")
+	}
+
+	ethA := nwconfigs["eth_a"]
+	if ethA.localAddr.String() != "10.210.8.121" || ethA.lldpPeer.String() != "10.210.8.122" || ethA.localPrefixLen != 30 {
+		t.Errorf("unexpected eth_a addresses %s peer %s /%d", ethA.localAddr, ethA.lldpPeer, ethA.localPrefixLen)
+	}
+
+	if nwconfigs["eth_b"].localAddr != nil {
+		t.Errorf("eth_b should not have an address: %s", nwconfigs["eth_b"].localAddr)
+	}
+
+	ethC := nwconfigs["eth_c"]
+	if ethC.localAddr.String() != "2001:db8::1" || ethC.lldpPeer.String() != "2001:db8::" ||
+		ethC.localPrefixLen != 127 || ethC.peerHWAddr.String() != "01:01:02:02:03:04" {
+		t.Errorf("unexpected eth_c addresses %s peer %s /%d MAC %s",
+			ethC.localAddr, ethC.lldpPeer, ethC.localPrefixLen, ethC.peerHWAddr)
+	}
+	if len(ethC.planRouteDsts) != 1 || ethC.planRouteDsts[0].String() != "2001:db8:100::/48" || len(ethA.planRouteDsts) != 0 {
+		t.Errorf("unexpected route destinations eth_a %v eth_c %v", ethA.planRouteDsts, ethC.planRouteDsts)
+	}
+
+	// eth_a matches the LLDP port description, eth_c does not
+	if mismatches := verifyAddressPlan(nwconfigs); mismatches != 1 {
+		t.Errorf("expected 1 mismatch, got %d", mismatches)
+	}
+}
+
+func TestAddressPlanInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		config cmdConfig
+		errMsg string
+	}{
+		{"plan in L2 mode", cmdConfig{mode: L2, addressPlan: "/address-plan", nodeName: testNodeName}, "Address plan requires mode L3"},
+		{"plan with DHCP L2 addresses", cmdConfig{mode: L2, addressPlan: "/address-plan", l2AddressSource: l2AddressDHCP, nodeName: testNodeName}, "Address plan requires the static L2 address source, not dhcp"},
+		{"plan without node name", cmdConfig{mode: L3, addressPlan: "/address-plan"}, "Address plan requires the node name"},
+		{"pool in L2 mode", cmdConfig{mode: L2, addressPool: "pool", nodeName: testNodeName}, "Address pool requires mode L3"},
+		{"pool with static L2 addresses", cmdConfig{mode: L2, addressPool: "pool", l2AddressSource: l2AddressStatic, nodeName: testNodeName}, "Static L2 addresses require an address plan"},
+		{"pool without node name", cmdConfig{mode: L3, addressPool: "pool"}, "Address pool requires the node name"},
+		{"plan and pool", cmdConfig{mode: L3, addressPlan: "/address-plan", addressPool: "pool", nodeName: testNodeName}, "mutually exclusive"},
+	}
+
+	for _, tt := range tests {
+		config := tt.config
+		config.ctx = context.Background()
+		config.planFormat = planFormatJSON
+		if err := sanitizeInput(&config); err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+			t.Errorf("%s: expected error '%s', got: %v", tt.name, tt.errMsg, err)
+		}
+	}
+
+	config := &cmdConfig{ctx: context.Background(), mode: L3}
+
+	config.addressPlan = t.TempDir()
+	config.nodeName = testNodeName
+	if _, err := addressPlanResults(config, getFakeNetworkDataConfigs()); err == nil {
+		t.Error("expected an error with a missing address plan file")
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+)
+
+var addressPoolPollInterval = 2 * time.Second
+
+// poolAllocations returns the addresses allocated to the node interfaces
+// in the address pool.
+func (r *nodeStateReporter) poolAllocations(ctx context.Context, poolName string) ([]networkv1alpha1.InterfaceAddress, error) {
+	pool := &networkv1alpha1.ScaleOutAddressPool{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: poolName}, pool); err != nil {
+		return nil, err
+	}
+
+	plan := []networkv1alpha1.InterfaceAddress{}
+	for _, allocation := range pool.Status.Allocations {
+		if allocation.NodeName != r.nodeName {
+			continue
+		}
+
+		plan = append(plan, networkv1alpha1.InterfaceAddress{
+			Interface: allocation.Interface,
+			Address:   allocation.Address,
+			Peer:      allocation.Peer,
+		})
+	}
+
+	return plan, nil
+}
+
+// waitPoolAllocations waits until the operator has allocated addresses
+// from the pool for all interfaces or the timeout expires.
+func (r *nodeStateReporter) waitPoolAllocations(ctx context.Context, poolName string, networkConfigs map[string]*networkConfiguration) ([]networkv1alpha1.InterfaceAddress, error) {
+	ticker := time.NewTicker(addressPoolPollInterval)
+	defer ticker.Stop()
+
+	for {
+		plan, err := r.poolAllocations(ctx, poolName)
+		if err != nil {
+			klog.Warningf("Cannot get ScaleOutAddressPool '%s': %v", poolName, err)
+		} else if len(plan) >= len(networkConfigs) {
+			return plan, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if len(plan) == 0 {
+				return nil, fmt.Errorf("no addresses allocated from pool '%s'", poolName)
+			}
+			klog.Warningf("Addresses allocated for %d of %d interfaces from pool '%s'", len(plan), len(networkConfigs), poolName)
+			return plan, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// addressPoolResults reports the interfaces of the node so that the
+// operator can allocate addresses for them from the address pool and
+// configures the interfaces with the allocated addresses.
+func addressPoolResults(config *cmdConfig, reporter *nodeStateReporter, networkConfigs map[string]*networkConfiguration) (bool, error) {
+	if reporter == nil {
+		return false, fmt.Errorf("address pool '%s' requires reporting the node state", config.addressPool)
+	}
+
+	reporter.report(config, networkConfigs, networkv1alpha1.NodeStateDiscovered, nil)
+
+	ctx, cancel := context.WithTimeout(config.ctx, config.timeout)
+	defer cancel()
+
+	klog.Infof("Waiting for addresses from pool '%s'...", config.addressPool)
+
+	plan, err := reporter.waitPoolAllocations(ctx, config.addressPool, networkConfigs)
+	if err != nil {
+		return false, err
+	}
+
+	return applyVerifiedAddressPlan(config, plan, networkConfigs), nil
+}
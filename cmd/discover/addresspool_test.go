@@ -0,0 +1,79 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+)
+
+func TestAddressPoolResults(t *testing.T) {
+	networkLink.LinkByName = fakeLinkByName
+	addressPoolPollInterval = 10 * time.Millisecond
+
+	pool := &networkv1alpha1.ScaleOutAddressPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+		Spec:       networkv1alpha1.ScaleOutAddressPoolSpec{CIDR: "10.220.0.0/24"},
+		Status: networkv1alpha1.ScaleOutAddressPoolStatus{
+			Allocations: []networkv1alpha1.AddressAllocation{
+				{NodeName: testNodeName, Interface: "eth_a", Address: "10.220.0.1/30", Peer: "10.220.0.2"},
+				{NodeName: testNodeName, Interface: "eth_c", Address: "10.220.0.5/30", Peer: "10.220.0.6"},
+				{NodeName: "node-b", Interface: "eth_b", Address: "10.220.0.9/30", Peer: "10.220.0.10"},
+			},
+		},
+	}
+
+	config := &cmdConfig{ctx: context.Background(), timeout: 50 * time.Millisecond, addressPool: pool.Name}
+	nwconfigs := getFakeNetworkDataConfigs()
+
+	if _, err := addressPoolResults(config, nil, nwconfigs); err == nil {
+		t.Error("expected an error without node state reporter")
+	}
+
+	r := fakeNodeStateReporter(t)
+	if _, err := addressPoolResults(config, r, nwconfigs); err == nil {
+		t.Error("expected an error without address pool")
+	}
+
+	r = fakeNodeStateReporter(t, testPolicy(), pool)
+	foundpeers, err := addressPoolResults(config, r, nwconfigs)
+	if err != nil || !foundpeers {
+		t.Fatalf("no addresses from pool: %v", err)
+	}
+
+	if nwconfigs["eth_a"].localAddr.String() != "10.220.0.1" || nwconfigs["eth_a"].lldpPeer.String() != "10.220.0.2" {
+		t.Errorf("unexpected eth_a addresses %s peer %s", nwconfigs["eth_a"].localAddr, nwconfigs["eth_a"].lldpPeer)
+	}
+	if nwconfigs["eth_b"].localAddr != nil {
+		t.Errorf("eth_b got another node's address %s", nwconfigs["eth_b"].localAddr)
+	}
+
+	// the interfaces were reported for the allocation
+	nodeState := &networkv1alpha1.NetworkNodeState{}
+	if err := r.client.Get(context.Background(), client.ObjectKey{Name: testNodeName}, nodeState); err != nil {
+		t.Fatalf("node state was not reported: %v", err)
+	}
+	if nodeState.Status.State != networkv1alpha1.NodeStateDiscovered || len(nodeState.Status.Interfaces) != len(nwconfigs) {
+		t.Errorf("unexpected node state status %+v", nodeState.Status)
+	}
+}
@@ -0,0 +1,263 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+
+	"github.com/vishvananda/netlink"
+	"k8s.io/klog/v2"
+)
+
+// interfaceState is the original state of an interface before the agent
+// changed it.
+type interfaceState struct {
+	Name         string `json:"name"`
+	HardwareAddr string `json:"hardwareAddr"`
+	Up           bool   `json:"up"`
+	MTU          int    `json:"mtu,omitempty"`
+	// addresses in CIDR notation
+	Addresses []string     `json:"addresses,omitempty"`
+	Routes    []routeState `json:"routes,omitempty"`
+	// VLAN sub-interface created by the agent
+	VLAN string `json:"vlan,omitempty"`
+}
+
+// routeState is an original route of an interface. A missing destination
+// is the default route.
+type routeState struct {
+	Dst      string `json:"dst,omitempty"`
+	Gateway  string `json:"gateway,omitempty"`
+	Source   string `json:"source,omitempty"`
+	Priority int    `json:"priority,omitempty"`
+	Table    int    `json:"table,omitempty"`
+	Scope    int    `json:"scope,omitempty"`
+	Protocol int    `json:"protocol,omitempty"`
+}
+
+func newRouteState(route netlink.Route) routeState {
+	state := routeState{
+		Priority: route.Priority,
+		Table:    route.Table,
+		Scope:    int(route.Scope),
+		Protocol: int(route.Protocol),
+	}
+	if route.Dst != nil {
+		state.Dst = route.Dst.String()
+	}
+	if route.Gw != nil {
+		state.Gateway = route.Gw.String()
+	}
+	if route.Src != nil {
+		state.Source = route.Src.String()
+	}
+
+	return state
+}
+
+func (r routeState) route() (netlink.Route, error) {
+	route := netlink.Route{
+		Gw:       net.ParseIP(r.Gateway),
+		Src:      net.ParseIP(r.Source),
+		Priority: r.Priority,
+		Table:    r.Table,
+		Scope:    netlink.Scope(r.Scope),
+		Protocol: netlink.RouteProtocol(r.Protocol),
+	}
+
+	if r.Dst != "" {
+		_, dst, err := net.ParseCIDR(r.Dst)
+		if err != nil {
+			return route, err
+		}
+		route.Dst = dst
+	}
+
+	return route, nil
+}
+
+// interfaceCheckpoint is the state file content, written to a host path
+// so that the interfaces can be restored after the agent was killed.
+type interfaceCheckpoint struct {
+	Interfaces []interfaceState `json:"interfaces"`
+}
+
+// writeCheckpoint writes the original state of the interfaces to the state
+// file. The file is replaced atomically so that a killed agent never leaves
+// a partial file behind.
+func writeCheckpoint(path string, networkConfigs map[string]*networkConfiguration) error {
+	checkpoint := interfaceCheckpoint{Interfaces: []interfaceState{}}
+
+	for ifname, nwconfig := range networkConfigs {
+		state := interfaceState{
+			Name:         ifname,
+			HardwareAddr: nwconfig.link.Attrs().HardwareAddr.String(),
+			Up:           nwconfig.origState&net.FlagUp != 0,
+			MTU:          nwconfig.origMTU,
+		}
+		for _, addr := range nwconfig.origAddrs {
+			state.Addresses = append(state.Addresses, addr.IPNet.String())
+		}
+		for _, route := range nwconfig.origRoutes {
+			state.Routes = append(state.Routes, newRouteState(route))
+		}
+		if nwconfig.vlanLink != nil && nwconfig.vlanCreated {
+			state.VLAN = nwconfig.vlanLink.Attrs().Name
+		}
+
+		checkpoint.Interfaces = append(checkpoint.Interfaces, state)
+	}
+
+	sort.Slice(checkpoint.Interfaces, func(i, j int) bool {
+		return checkpoint.Interfaces[i].Name < checkpoint.Interfaces[j].Name
+	})
+
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal state file: %v", err)
+	}
+
+	if err := writeFileAtomic(path, append(data, '\n')); err != nil {
+		return fmt.Errorf("cannot write state file: %v", err)
+	}
+
+	klog.V(3).Infof("Saved original interface state to %s", path)
+
+	return nil
+}
+
+// readCheckpoint reads the state file. Returns nil if there is none.
+func readCheckpoint(path string) (*interfaceCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read state file: %v", err)
+	}
+
+	checkpoint := &interfaceCheckpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("cannot parse state file '%s': %v", path, err)
+	}
+
+	return checkpoint, nil
+}
+
+// checkpointConfigs returns the network configurations of the interfaces
+// in the checkpoint with their original state. Interfaces that are gone or
+// were renamed to another device are skipped.
+func checkpointConfigs(checkpoint *interfaceCheckpoint) map[string]*networkConfiguration {
+	networkConfigs := map[string]*networkConfiguration{}
+
+	for _, state := range checkpoint.Interfaces {
+		link, err := networkLink.LinkByName(state.Name)
+		if err != nil {
+			klog.Warningf("Cannot restore interface '%s': %v", state.Name, err)
+			continue
+		}
+
+		if hwaddr := link.Attrs().HardwareAddr.String(); hwaddr != state.HardwareAddr {
+			klog.Warningf("Cannot restore interface '%s': MAC address %s does not match %s",
+				state.Name, hwaddr, state.HardwareAddr)
+			continue
+		}
+
+		nwconfig := &networkConfiguration{
+			link:        link,
+			origMTU:     state.MTU,
+			localHwAddr: &link.Attrs().HardwareAddr,
+		}
+		if state.Up {
+			nwconfig.origState = net.FlagUp
+		}
+
+		for _, address := range state.Addresses {
+			addr, err := netlink.ParseAddr(address)
+			if err != nil {
+				klog.Warningf("Cannot restore interface '%s' address: %v", state.Name, err)
+				continue
+			}
+			nwconfig.origAddrs = append(nwconfig.origAddrs, *addr)
+		}
+		for _, r := range state.Routes {
+			route, err := r.route()
+			if err != nil {
+				klog.Warningf("Cannot restore interface '%s' route: %v", state.Name, err)
+				continue
+			}
+			nwconfig.origRoutes = append(nwconfig.origRoutes, route)
+		}
+
+		if state.VLAN != "" {
+			if vlanLink, err := networkLink.LinkByName(state.VLAN); err == nil {
+				nwconfig.vlanLink = vlanLink
+				nwconfig.vlanCreated = true
+			}
+		}
+
+		networkConfigs[state.Name] = nwconfig
+	}
+
+	return networkConfigs
+}
+
+// restoreInterfaces puts the interfaces back to their original state.
+func restoreInterfaces(networkConfigs map[string]*networkConfiguration) {
+	klog.Infof("Restoring interfaces to original state...")
+
+	deleteVLANs(networkConfigs)
+
+	if err := removeExistingIPs(networkConfigs); err != nil {
+		klog.Warningf("Failed to remove any existing IPs from interfaces: %+v\n", err)
+	}
+
+	interfacesRestoreMTU(networkConfigs)
+	restoreOriginalAddresses(networkConfigs)
+
+	if err := interfacesRestoreDown(networkConfigs); err != nil {
+		klog.Warningf("Failed to restore interfaces to original state: %+v\n", err)
+	}
+}
+
+// restoreCheckpoint restores the interfaces saved in the state file and
+// removes the file. Does nothing if there is no state file.
+func restoreCheckpoint(path string) error {
+	checkpoint, err := readCheckpoint(path)
+	if err != nil || checkpoint == nil {
+		return err
+	}
+
+	klog.Infof("Restoring interfaces from state file %s...", path)
+
+	restoreInterfaces(checkpointConfigs(checkpoint))
+	removeCheckpoint(path)
+
+	return nil
+}
+
+// removeCheckpoint removes the state file once the interfaces have been
+// restored.
+func removeCheckpoint(path string) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		klog.Warningf("Failed to remove state file '%s': %v", path, err)
+	}
+}
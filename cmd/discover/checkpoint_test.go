@@ -0,0 +1,195 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/vishvananda/netlink"
+)
+
+func TestCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "discover.json")
+
+	if checkpoint, err := readCheckpoint(path); err != nil || checkpoint != nil {
+		t.Fatalf("unexpected checkpoint %v without a state file: %v", checkpoint, err)
+	}
+
+	networkConfigs := map[string]*networkConfiguration{
+		"eth1": fakeL2Config("eth1", 2, "00:00:00:00:00:11"),
+		"eth0": fakeL2Config("eth0", 1, "00:00:00:00:00:10"),
+	}
+	networkConfigs["eth0"].origState = net.FlagUp
+	networkConfigs["eth0"].origMTU = 1500
+	addr, _ := netlink.ParseAddr("192.168.10.11/24")
+	_, dst, _ := net.ParseCIDR("10.0.0.0/8")
+	networkConfigs["eth0"].origAddrs = []netlink.Addr{*addr}
+	networkConfigs["eth0"].origRoutes = []netlink.Route{
+		{Dst: dst, Gw: net.ParseIP("192.168.10.1"), Priority: 100},
+		{Gw: net.ParseIP("192.168.10.1")},
+	}
+	networkConfigs["eth1"].vlanLink = &fakeLink{fakeAttrs: netlink.LinkAttrs{Name: "eth1.100"}}
+	networkConfigs["eth1"].vlanCreated = true
+	// reused sub-interfaces are not removed on restore
+	networkConfigs["eth0"].vlanLink = &fakeLink{fakeAttrs: netlink.LinkAttrs{Name: "eth0.100"}}
+	// the current state is not saved
+	networkConfigs["eth1"].link.Attrs().Flags = net.FlagUp
+
+	if err := writeCheckpoint(path, networkConfigs); err != nil {
+		t.Fatalf("cannot write checkpoint: %v", err)
+	}
+
+	checkpoint, err := readCheckpoint(path)
+	if err != nil || checkpoint == nil {
+		t.Fatalf("cannot read checkpoint: %v", err)
+	}
+
+	expected := []interfaceState{
+		{
+			Name: "eth0", HardwareAddr: "00:00:00:00:00:10", Up: true, MTU: 1500,
+			Addresses: []string{"192.168.10.11/24"},
+			Routes: []routeState{
+				{Dst: "10.0.0.0/8", Gateway: "192.168.10.1", Priority: 100},
+				{Gateway: "192.168.10.1"},
+			},
+		},
+		{Name: "eth1", HardwareAddr: "00:00:00:00:00:11", VLAN: "eth1.100"},
+	}
+	if len(checkpoint.Interfaces) != len(expected) {
+		t.Fatalf("unexpected checkpoint %+v", checkpoint.Interfaces)
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(checkpoint.Interfaces[i], expected[i]) {
+			t.Errorf("unexpected interface state %+v, expected %+v", checkpoint.Interfaces[i], expected[i])
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("foo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readCheckpoint(path); err == nil {
+		t.Error("expected an error for an invalid state file")
+	}
+}
+
+func TestRestoreCheckpoint(t *testing.T) {
+	orig := networkLink
+	t.Cleanup(func() { networkLink = orig })
+
+	links := map[string]*fakeLink{}
+	for i, name := range []string{"eth0", "eth1", "eth2", "eth1.100"} {
+		mac, _ := net.ParseMAC(fmt.Sprintf("00:00:00:00:00:1%d", i))
+		links[name] = &fakeLink{fakeAttrs: netlink.LinkAttrs{Name: name, Index: i + 1, HardwareAddr: mac, Flags: net.FlagUp}}
+	}
+
+	deleted := []string{}
+	down := map[string]bool{}
+	removed := map[string][]string{}
+	mtus := map[string]int{}
+	added := map[string][]string{}
+	routes := map[int][]string{}
+
+	networkLink.LinkByName = func(name string) (netlink.Link, error) {
+		if link, exists := links[name]; exists {
+			return link, nil
+		}
+		return nil, fmt.Errorf("link '%s' not found", name)
+	}
+	networkLink.LinkDel = func(link netlink.Link) error {
+		deleted = append(deleted, link.Attrs().Name)
+		return nil
+	}
+	networkLink.LinkSubscribe = func(ch chan<- netlink.LinkUpdate, done <-chan struct{}) error {
+		return nil
+	}
+	networkLink.LinkSetDown = func(link netlink.Link) error {
+		down[link.Attrs().Name] = true
+		return nil
+	}
+	networkLink.AddrList = func(link netlink.Link, family int) ([]netlink.Addr, error) {
+		_, global, _ := net.ParseCIDR("10.210.8.121/30")
+		_, linkLocal, _ := net.ParseCIDR("fe80::10/64")
+		return []netlink.Addr{{IPNet: global}, {IPNet: linkLocal}}, nil
+	}
+	networkLink.AddrDel = func(link netlink.Link, addr *netlink.Addr) error {
+		removed[link.Attrs().Name] = append(removed[link.Attrs().Name], addr.IPNet.String())
+		return nil
+	}
+	networkLink.LinkSetMTU = func(link netlink.Link, mtu int) error {
+		mtus[link.Attrs().Name] = mtu
+		return nil
+	}
+	networkLink.AddrAdd = func(link netlink.Link, addr *netlink.Addr) error {
+		added[link.Attrs().Name] = append(added[link.Attrs().Name], addr.IPNet.String())
+		return nil
+	}
+	networkLink.RouteAppend = func(route *netlink.Route) error {
+		routes[route.LinkIndex] = append(routes[route.LinkIndex], fmt.Sprintf("%v via %s", route.Dst, route.Gw))
+		return nil
+	}
+
+	path := filepath.Join(t.TempDir(), "discover.json")
+	contents := `{"interfaces": [
+		{"name": "eth0", "hardwareAddr": "00:00:00:00:00:10", "up": true, "mtu": 1500,
+		 "addresses": ["192.168.10.11/24", "2001:db8::11/64"],
+		 "routes": [{"dst": "10.0.0.0/8", "gateway": "192.168.10.1"}, {"gateway": "192.168.10.1"}]},
+		{"name": "eth1", "hardwareAddr": "00:00:00:00:00:11", "vlan": "eth1.100"},
+		{"name": "eth2", "hardwareAddr": "00:00:00:00:00:99"},
+		{"name": "eth3", "hardwareAddr": "00:00:00:00:00:13"}
+	]}`
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := restoreCheckpoint(path); err != nil {
+		t.Fatalf("cannot restore checkpoint: %v", err)
+	}
+
+	if len(deleted) != 1 || deleted[0] != "eth1.100" {
+		t.Errorf("unexpected deleted links %v", deleted)
+	}
+	if !down["eth1"] || down["eth0"] || down["eth2"] {
+		t.Errorf("unexpected links set down %v", down)
+	}
+	if len(removed["eth0"]) != 1 || len(removed["eth1"]) != 1 || len(removed["eth2"]) != 0 {
+		t.Errorf("unexpected removed addresses %v", removed)
+	}
+
+	if mtus["eth0"] != 1500 || len(mtus) != 1 {
+		t.Errorf("unexpected restored MTUs %v", mtus)
+	}
+	if !reflect.DeepEqual(added, map[string][]string{"eth0": {"192.168.10.11/24", "2001:db8::11/64"}}) {
+		t.Errorf("unexpected restored addresses %v", added)
+	}
+	if !reflect.DeepEqual(routes, map[int][]string{1: {"10.0.0.0/8 via 192.168.10.1", "<nil> via 192.168.10.1"}}) {
+		t.Errorf("unexpected restored routes %v", routes)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("state file not removed: %v", err)
+	}
+
+	// nothing to restore without a state file
+	if err := restoreCheckpoint(path); err != nil {
+		t.Errorf("unexpected error without a state file: %v", err)
+	}
+}
@@ -0,0 +1,442 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+)
+
+// Kernel DCB netlink interface, see include/uapi/linux/dcbnl.h
+const (
+	dcbCmdIEEESet = 20
+	dcbCmdIEEEGet = 21
+	dcbCmdSetDCBX = 23
+	dcbCmdIEEEDel = 27
+
+	dcbAttrIfname = 1
+	dcbAttrIEEE   = 13
+	dcbAttrDCBX   = 14
+
+	dcbAttrIEEEETS           = 1
+	dcbAttrIEEEPFC           = 2
+	dcbAttrIEEEAppTable      = 3
+	dcbAttrIEEEAppTrustTable = 11
+
+	dcbAttrIEEEApp = 1
+	dcbAttrDCBApp  = 2
+
+	ieeeAppSelDSCP = 5
+	dcbAppSelPCP   = 255
+
+	dcbCapDCBXHost    = 0x01
+	dcbCapDCBXVerIEEE = 0x08
+
+	ieeeMaxTCs = 8
+
+	ieeeTSAETS = 2
+)
+
+// ieeeETS is struct ieee_ets of the kernel.
+type ieeeETS struct {
+	Willing    uint8
+	ETSCap     uint8
+	CBS        uint8
+	TCTxBW     [ieeeMaxTCs]uint8
+	TCRxBW     [ieeeMaxTCs]uint8
+	TCTSA      [ieeeMaxTCs]uint8
+	PrioTC     [ieeeMaxTCs]uint8
+	TCRecoBW   [ieeeMaxTCs]uint8
+	TCRecoTSA  [ieeeMaxTCs]uint8
+	RecoPrioTC [ieeeMaxTCs]uint8
+}
+
+// ieeePFC is struct ieee_pfc of the kernel.
+type ieeePFC struct {
+	PFCCap      uint8
+	PFCEn       uint8
+	MBC         uint8
+	_           uint8
+	Delay       uint16
+	_           [2]uint8
+	Requests    [ieeeMaxTCs]uint64
+	Indications [ieeeMaxTCs]uint64
+}
+
+// dcbApp is struct dcb_app of the kernel.
+type dcbApp struct {
+	Selector uint8
+	Priority uint8
+	Protocol uint16
+}
+
+// dcbLinkFn holds the DCB netlink operations so that they can be replaced
+// in tests.
+type dcbLinkFn struct {
+	IEEEGet     func(ifname string) (*ieeeETS, *ieeePFC, error)
+	IEEESet     func(ifname string, ets *ieeeETS, pfc *ieeePFC) error
+	SetDCBX     func(ifname string, mode uint8) error
+	AppAdd      func(ifname string, apps []dcbApp) error
+	AppDel      func(ifname string, apps []dcbApp) error
+	GetAppTrust func(ifname string) ([]uint8, error)
+	SetAppTrust func(ifname string, selectors []uint8) error
+}
+
+var dcbLink = dcbLinkFn{
+	IEEEGet:     dcbIEEEGet,
+	IEEESet:     dcbIEEESet,
+	SetDCBX:     dcbSetDCBX,
+	AppAdd:      dcbAppAdd,
+	AppDel:      dcbAppDel,
+	GetAppTrust: dcbGetAppTrust,
+	SetAppTrust: dcbSetAppTrust,
+}
+
+// dcbMsg is struct dcbmsg of the kernel.
+type dcbMsg struct {
+	cmd uint8
+}
+
+func (m *dcbMsg) Len() int {
+	return 4
+}
+
+func (m *dcbMsg) Serialize() []byte {
+	return []byte{unix.AF_UNSPEC, m.cmd, 0, 0}
+}
+
+func serializeDCBStruct(data any) []byte {
+	buf := &bytes.Buffer{}
+	_ = binary.Write(buf, nl.NativeEndian(), data)
+
+	return buf.Bytes()
+}
+
+func deserializeDCBStruct(b []byte, data any) error {
+	if len(b) < binary.Size(data) {
+		return fmt.Errorf("DCB attribute too short: %d bytes", len(b))
+	}
+
+	return binary.Read(bytes.NewReader(b), nl.NativeEndian(), data)
+}
+
+func dcbRequest(proto int, cmd uint8, ifname string) *nl.NetlinkRequest {
+	req := nl.NewNetlinkRequest(proto, 0)
+	req.AddData(&dcbMsg{cmd: cmd})
+	req.AddData(nl.NewRtAttr(dcbAttrIfname, nl.ZeroTerminated(ifname)))
+
+	return req
+}
+
+// dcbExecute sends the request and returns the attributes of the reply.
+func dcbExecute(req *nl.NetlinkRequest, resType uint16) ([]syscall.NetlinkRouteAttr, error) {
+	msgs, err := req.Execute(unix.NETLINK_ROUTE, resType)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(msgs) == 0 || len(msgs[0]) < 4 {
+		return nil, fmt.Errorf("no DCB reply")
+	}
+
+	return nl.ParseRouteAttr(msgs[0][4:])
+}
+
+// dcbStatus returns an error if the status attribute of a set reply
+// indicates a failure.
+func dcbStatus(attrs []syscall.NetlinkRouteAttr, attrType uint16) error {
+	for _, attr := range attrs {
+		if attr.Attr.Type == attrType && len(attr.Value) > 0 && attr.Value[0] != 0 {
+			return fmt.Errorf("DCB request failed with status %d", attr.Value[0])
+		}
+	}
+
+	return nil
+}
+
+// parseIEEEAttrs returns the ETS and PFC configuration of an IEEE get
+// reply. Either is nil if the driver does not report it.
+func parseIEEEAttrs(attrs []syscall.NetlinkRouteAttr) (*ieeeETS, *ieeePFC, error) {
+	var ets *ieeeETS
+	var pfc *ieeePFC
+
+	for _, attr := range attrs {
+		if attr.Attr.Type != dcbAttrIEEE {
+			continue
+		}
+
+		nested, err := nl.ParseRouteAttr(attr.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, a := range nested {
+			switch a.Attr.Type {
+			case dcbAttrIEEEETS:
+				ets = &ieeeETS{}
+				if err := deserializeDCBStruct(a.Value, ets); err != nil {
+					return nil, nil, err
+				}
+			case dcbAttrIEEEPFC:
+				pfc = &ieeePFC{}
+				if err := deserializeDCBStruct(a.Value, pfc); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+
+	return ets, pfc, nil
+}
+
+func dcbIEEEGetAttrs(ifname string) ([]syscall.NetlinkRouteAttr, error) {
+	return dcbExecute(dcbRequest(unix.RTM_GETDCB, dcbCmdIEEEGet, ifname), unix.RTM_GETDCB)
+}
+
+func dcbIEEEGet(ifname string) (*ieeeETS, *ieeePFC, error) {
+	attrs, err := dcbIEEEGetAttrs(ifname)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return parseIEEEAttrs(attrs)
+}
+
+// parseAppTrust returns the trusted APP selectors of an IEEE get reply in
+// order of precedence, or an error if the driver does not report them.
+func parseAppTrust(attrs []syscall.NetlinkRouteAttr) ([]uint8, error) {
+	for _, attr := range attrs {
+		if attr.Attr.Type != dcbAttrIEEE {
+			continue
+		}
+
+		nested, err := nl.ParseRouteAttr(attr.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range nested {
+			if a.Attr.Type != dcbAttrIEEEAppTrustTable {
+				continue
+			}
+
+			selectors, err := nl.ParseRouteAttr(a.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			trust := []uint8{}
+			for _, selector := range selectors {
+				if len(selector.Value) > 0 {
+					trust = append(trust, selector.Value[0])
+				}
+			}
+
+			return trust, nil
+		}
+	}
+
+	return nil, fmt.Errorf("APP trust not supported")
+}
+
+func dcbGetAppTrust(ifname string) ([]uint8, error) {
+	attrs, err := dcbIEEEGetAttrs(ifname)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAppTrust(attrs)
+}
+
+// ieeeAttr returns the nested IEEE attribute with the given ETS and PFC
+// configuration. Nil configurations are left out and not changed.
+func ieeeAttr(ets *ieeeETS, pfc *ieeePFC) *nl.RtAttr {
+	attr := nl.NewRtAttr(dcbAttrIEEE, nil)
+	if ets != nil {
+		attr.AddRtAttr(dcbAttrIEEEETS, serializeDCBStruct(ets))
+	}
+	if pfc != nil {
+		attr.AddRtAttr(dcbAttrIEEEPFC, serializeDCBStruct(pfc))
+	}
+
+	return attr
+}
+
+// appTableAttr returns the nested IEEE attribute with the APP table
+// entries.
+func appTableAttr(apps []dcbApp) *nl.RtAttr {
+	attr := nl.NewRtAttr(dcbAttrIEEE, nil)
+	table := attr.AddRtAttr(dcbAttrIEEEAppTable, nil)
+	for _, app := range apps {
+		table.AddRtAttr(dcbAttrIEEEApp, serializeDCBStruct(&app))
+	}
+
+	return attr
+}
+
+// appTrustAttr returns the nested IEEE attribute with the trusted APP
+// selectors in order of precedence.
+func appTrustAttr(selectors []uint8) *nl.RtAttr {
+	attr := nl.NewRtAttr(dcbAttrIEEE, nil)
+	table := attr.AddRtAttr(dcbAttrIEEEAppTrustTable, nil)
+	for _, selector := range selectors {
+		// the PCP selector is not defined by IEEE
+		attrType := dcbAttrIEEEApp
+		if selector == dcbAppSelPCP {
+			attrType = dcbAttrDCBApp
+		}
+		table.AddRtAttr(attrType, nl.Uint8Attr(selector))
+	}
+
+	return attr
+}
+
+func dcbIEEERequest(ifname string, cmd uint8, attr *nl.RtAttr) error {
+	req := dcbRequest(unix.RTM_SETDCB, cmd, ifname)
+	req.AddData(attr)
+
+	attrs, err := dcbExecute(req, unix.RTM_SETDCB)
+	if err != nil {
+		return err
+	}
+
+	return dcbStatus(attrs, dcbAttrIEEE)
+}
+
+func dcbIEEESet(ifname string, ets *ieeeETS, pfc *ieeePFC) error {
+	return dcbIEEERequest(ifname, dcbCmdIEEESet, ieeeAttr(ets, pfc))
+}
+
+func dcbAppAdd(ifname string, apps []dcbApp) error {
+	return dcbIEEERequest(ifname, dcbCmdIEEESet, appTableAttr(apps))
+}
+
+func dcbAppDel(ifname string, apps []dcbApp) error {
+	return dcbIEEERequest(ifname, dcbCmdIEEEDel, appTableAttr(apps))
+}
+
+func dcbSetAppTrust(ifname string, selectors []uint8) error {
+	return dcbIEEERequest(ifname, dcbCmdIEEESet, appTrustAttr(selectors))
+}
+
+func dcbSetDCBX(ifname string, mode uint8) error {
+	req := dcbRequest(unix.RTM_SETDCB, dcbCmdSetDCBX, ifname)
+	req.AddData(nl.NewRtAttr(dcbAttrDCBX, nl.Uint8Attr(mode)))
+
+	attrs, err := dcbExecute(req, unix.RTM_SETDCB)
+	if err != nil {
+		return err
+	}
+
+	return dcbStatus(attrs, dcbAttrDCBX)
+}
+
+// pfcMask converts a verified list of PFC priorities to the bitmask of
+// enabled priorities.
+func pfcMask(pfc string) (uint8, error) {
+	if pfc == "" || pfc == pfcDisable {
+		return 0, nil
+	}
+
+	mask := uint8(0)
+	for _, s := range strings.Split(pfc, ",") {
+		prio, err := strconv.Atoi(s)
+		if err != nil || prio < 0 || prio >= ieeeMaxTCs {
+			return 0, fmt.Errorf("invalid PFC priority '%s'", s)
+		}
+		mask |= 1 << prio
+	}
+
+	return mask, nil
+}
+
+// setHostDCBX sets the DCBX mode of the interface to host managed IEEE as
+// there is no LLDP agent negotiating the configuration. Drivers without
+// DCBX support work in host managed mode already.
+func setHostDCBX(ifname string) {
+	if err := dcbLink.SetDCBX(ifname, dcbCapDCBXHost|dcbCapDCBXVerIEEE); err != nil {
+		klog.V(3).Infof("Cannot set DCBX mode of interface %s: %v", ifname, err)
+	}
+}
+
+// setDCBPFC enables the given PFC priorities on the interface through the
+// kernel DCB netlink interface.
+func setDCBPFC(ifname string, pfc string) error {
+	mask, err := pfcMask(pfc)
+	if err != nil {
+		return err
+	}
+
+	setHostDCBX(ifname)
+
+	_, current, err := dcbLink.IEEEGet(ifname)
+	if err != nil {
+		return fmt.Errorf("cannot get the DCB configuration of interface %s: %v", ifname, err)
+	}
+	if current == nil {
+		current = &ieeePFC{}
+	}
+
+	current.PFCEn = mask
+
+	if err := dcbLink.IEEESet(ifname, nil, current); err != nil {
+		return fmt.Errorf("cannot set PFC of interface %s: %v", ifname, err)
+	}
+
+	klog.Infof("Set PFC of interface %s to 0x%02x", ifname, mask)
+
+	return nil
+}
+
+// setDCBETS sets the traffic class of each priority and the bandwidth of
+// each traffic class of the interface through the kernel DCB netlink
+// interface. All traffic classes use the ETS transmission selection
+// algorithm.
+func setDCBETS(ifname string, ets *etsConfig) error {
+	setHostDCBX(ifname)
+
+	current, _, err := dcbLink.IEEEGet(ifname)
+	if err != nil {
+		return fmt.Errorf("cannot get the DCB configuration of interface %s: %v", ifname, err)
+	}
+	if current == nil {
+		current = &ieeeETS{}
+	}
+
+	current.Willing = 0
+	current.PrioTC = ets.prioTC
+	current.TCTxBW = ets.tcBW
+	for i := range current.TCTSA {
+		current.TCTSA[i] = ieeeTSAETS
+	}
+
+	if err := dcbLink.IEEESet(ifname, current, nil); err != nil {
+		return fmt.Errorf("cannot set ETS of interface %s: %v", ifname, err)
+	}
+
+	klog.Infof("Set ETS of interface %s to %s", ifname, ets)
+
+	return nil
+}
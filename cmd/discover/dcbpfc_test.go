@@ -0,0 +1,293 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"syscall"
+	"testing"
+
+	"github.com/vishvananda/netlink/nl"
+)
+
+// fakeDCB records the DCB configuration of interfaces in place of the
+// kernel.
+type fakeDCB struct {
+	ets     map[string]*ieeeETS
+	pfc     map[string]*ieeePFC
+	dcbx    map[string]uint8
+	apps    map[string]map[dcbApp]bool
+	trust   map[string][]uint8
+	failSet bool
+}
+
+func newFakeDCB() *fakeDCB {
+	return &fakeDCB{
+		ets:   map[string]*ieeeETS{},
+		pfc:   map[string]*ieeePFC{},
+		dcbx:  map[string]uint8{},
+		apps:  map[string]map[dcbApp]bool{},
+		trust: map[string][]uint8{},
+	}
+}
+
+func (f *fakeDCB) install(t *testing.T) {
+	orig := dcbLink
+	t.Cleanup(func() { dcbLink = orig })
+
+	dcbLink = dcbLinkFn{
+		IEEEGet: func(ifname string) (*ieeeETS, *ieeePFC, error) {
+			var ets *ieeeETS
+			var pfc *ieeePFC
+			if e, ok := f.ets[ifname]; ok {
+				c := *e
+				ets = &c
+			}
+			if p, ok := f.pfc[ifname]; ok {
+				c := *p
+				pfc = &c
+			}
+			return ets, pfc, nil
+		},
+		IEEESet: func(ifname string, ets *ieeeETS, pfc *ieeePFC) error {
+			if f.failSet {
+				return fmt.Errorf("operation not supported")
+			}
+			if ets != nil {
+				c := *ets
+				f.ets[ifname] = &c
+			}
+			if pfc != nil {
+				c := *pfc
+				f.pfc[ifname] = &c
+			}
+			return nil
+		},
+		SetDCBX: func(ifname string, mode uint8) error {
+			f.dcbx[ifname] = mode
+			return nil
+		},
+		AppAdd: func(ifname string, apps []dcbApp) error {
+			if f.failSet {
+				return fmt.Errorf("operation not supported")
+			}
+			if f.apps[ifname] == nil {
+				f.apps[ifname] = map[dcbApp]bool{}
+			}
+			for _, app := range apps {
+				f.apps[ifname][app] = true
+			}
+			return nil
+		},
+		AppDel: func(ifname string, apps []dcbApp) error {
+			for _, app := range apps {
+				delete(f.apps[ifname], app)
+			}
+			return nil
+		},
+		GetAppTrust: func(ifname string) ([]uint8, error) {
+			trust, ok := f.trust[ifname]
+			if !ok {
+				return nil, fmt.Errorf("APP trust not supported")
+			}
+			return trust, nil
+		},
+		SetAppTrust: func(ifname string, selectors []uint8) error {
+			f.trust[ifname] = selectors
+			return nil
+		},
+	}
+}
+
+func TestPFCMask(t *testing.T) {
+	tests := []struct {
+		pfc     string
+		mask    uint8
+		success bool
+	}{
+		{"", 0, true},
+		{pfcDisable, 0, true},
+		{"3", 0x08, true},
+		{"0,1,2,3", 0x0f, true},
+		{"7", 0x80, true},
+		{"8", 0, false},
+		{"foo", 0, false},
+	}
+
+	for _, tt := range tests {
+		mask, err := pfcMask(tt.pfc)
+		if (err == nil) != tt.success {
+			t.Errorf("pfc '%s': unexpected error %v", tt.pfc, err)
+		}
+		if mask != tt.mask {
+			t.Errorf("pfc '%s': expected mask 0x%02x, got 0x%02x", tt.pfc, tt.mask, mask)
+		}
+	}
+}
+
+func TestIEEEAttrs(t *testing.T) {
+	ets := &ieeeETS{ETSCap: 8, PrioTC: [ieeeMaxTCs]uint8{0, 1, 2, 3, 4, 5, 6, 7}}
+	pfc := &ieeePFC{PFCCap: 8, PFCEn: 0x0f, Delay: 0x1234, Requests: [ieeeMaxTCs]uint64{1: 42}}
+
+	if size := len(serializeDCBStruct(ets)); size != 59 {
+		t.Errorf("expected struct ieee_ets size 59, got %d", size)
+	}
+	if size := len(serializeDCBStruct(pfc)); size != 136 {
+		t.Errorf("expected struct ieee_pfc size 136, got %d", size)
+	}
+
+	// a get reply carries the same nested attribute as a set request
+	attrs, err := nl.ParseRouteAttr(ieeeAttr(ets, pfc).Serialize())
+	if err != nil {
+		t.Fatalf("cannot parse attributes: %v", err)
+	}
+
+	gotETS, gotPFC, err := parseIEEEAttrs(attrs)
+	if err != nil {
+		t.Fatalf("cannot parse IEEE attributes: %v", err)
+	}
+	if gotETS == nil || *gotETS != *ets {
+		t.Errorf("expected ETS %+v, got %+v", ets, gotETS)
+	}
+	if gotPFC == nil || *gotPFC != *pfc {
+		t.Errorf("expected PFC %+v, got %+v", pfc, gotPFC)
+	}
+
+	// nil configurations are left out
+	attrs, _ = nl.ParseRouteAttr(ieeeAttr(nil, pfc).Serialize())
+	if gotETS, gotPFC, _ = parseIEEEAttrs(attrs); gotETS != nil || gotPFC == nil {
+		t.Errorf("expected only PFC, got %+v %+v", gotETS, gotPFC)
+	}
+
+	// truncated structures are rejected
+	short := nl.NewRtAttr(dcbAttrIEEE, nil)
+	short.AddRtAttr(dcbAttrIEEEPFC, []byte{1, 2, 3})
+	attrs, _ = nl.ParseRouteAttr(short.Serialize())
+	if _, _, err = parseIEEEAttrs(attrs); err == nil {
+		t.Errorf("expected an error for a truncated PFC attribute")
+	}
+}
+
+func TestDCBStatus(t *testing.T) {
+	attr := func(value byte) syscall.NetlinkRouteAttr {
+		return syscall.NetlinkRouteAttr{
+			Attr:  syscall.RtAttr{Type: dcbAttrIEEE},
+			Value: []byte{value},
+		}
+	}
+
+	if err := dcbStatus([]syscall.NetlinkRouteAttr{attr(0)}, dcbAttrIEEE); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := dcbStatus([]syscall.NetlinkRouteAttr{attr(1)}, dcbAttrIEEE); err == nil {
+		t.Errorf("expected an error for a failure status")
+	}
+}
+
+func TestDCBPFC(t *testing.T) {
+	fake := newFakeDCB()
+	fake.install(t)
+
+	fake.pfc["foo0"] = &ieeePFC{PFCCap: 8, Delay: 32}
+
+	config := &cmdConfig{pfc: "0,1,2,3", pfcBackend: pfcBackendNetlink}
+	networkConfigs := map[string]*networkConfiguration{
+		"foo0": {},
+		"foo1": {},
+	}
+
+	if err := EnableAllPFC(config, networkConfigs); err != nil {
+		t.Fatalf("cannot enable PFC: %v", err)
+	}
+
+	for ifname := range networkConfigs {
+		if fake.pfc[ifname].PFCEn != 0x0f {
+			t.Errorf("%s: expected PFC 0x0f, got 0x%02x", ifname, fake.pfc[ifname].PFCEn)
+		}
+		if fake.dcbx[ifname] != dcbCapDCBXHost|dcbCapDCBXVerIEEE {
+			t.Errorf("%s: expected host managed IEEE DCBX, got 0x%02x", ifname, fake.dcbx[ifname])
+		}
+		if _, ok := fake.ets[ifname]; ok {
+			t.Errorf("%s: ETS should not be changed", ifname)
+		}
+	}
+
+	// other settings are kept
+	if fake.pfc["foo0"].PFCCap != 8 || fake.pfc["foo0"].Delay != 32 {
+		t.Errorf("PFC settings not kept: %+v", fake.pfc["foo0"])
+	}
+
+	if err := DisableAllPFC(config, networkConfigs); err != nil {
+		t.Fatalf("cannot disable PFC: %v", err)
+	}
+
+	for ifname := range networkConfigs {
+		if fake.pfc[ifname].PFCEn != 0 {
+			t.Errorf("%s: expected PFC disabled, got 0x%02x", ifname, fake.pfc[ifname].PFCEn)
+		}
+	}
+
+	fake.failSet = true
+
+	if err := EnableAllPFC(config, networkConfigs); err == nil {
+		t.Errorf("expected an error when the driver rejects the configuration")
+	}
+}
+
+func TestDCBETS(t *testing.T) {
+	fake := newFakeDCB()
+	fake.install(t)
+
+	fake.ets["foo0"] = &ieeeETS{ETSCap: 8, Willing: 1}
+
+	config := &cmdConfig{
+		pfcBackend: pfcBackendNetlink,
+		ets:        &etsConfig{prioTC: [ieeeMaxTCs]uint8{0, 0, 0, 1}, tcBW: [ieeeMaxTCs]uint8{40, 60}},
+	}
+	networkConfigs := map[string]*networkConfiguration{
+		"foo0": {},
+	}
+
+	if err := EnableAllETS(config, networkConfigs); err != nil {
+		t.Fatalf("cannot enable ETS: %v", err)
+	}
+
+	ets := fake.ets["foo0"]
+	if ets.PrioTC != config.ets.prioTC || ets.TCTxBW != config.ets.tcBW {
+		t.Errorf("unexpected ETS %+v", ets)
+	}
+	if ets.ETSCap != 8 || ets.Willing != 0 {
+		t.Errorf("expected ETS capability kept and not willing, got %+v", ets)
+	}
+	for tc, tsa := range ets.TCTSA {
+		if tsa != ieeeTSAETS {
+			t.Errorf("traffic class %d: expected ETS TSA, got %d", tc, tsa)
+		}
+	}
+	if _, ok := fake.pfc["foo0"]; ok {
+		t.Errorf("PFC should not be changed")
+	}
+
+	if err := RestoreAllETS(config, networkConfigs); err != nil {
+		t.Fatalf("cannot restore ETS: %v", err)
+	}
+
+	ets = fake.ets["foo0"]
+	if ets.PrioTC != ([ieeeMaxTCs]uint8{}) || ets.TCTxBW != ([ieeeMaxTCs]uint8{100}) {
+		t.Errorf("expected default ETS, got %+v", ets)
+	}
+}
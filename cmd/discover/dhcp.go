@@ -0,0 +1,519 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+)
+
+const (
+	dhcpServerPort = 67
+	dhcpClientPort = 68
+
+	// ask the server to broadcast its replies as the interface has no
+	// address to receive unicast ones on
+	dhcpFlagBroadcast = 0x8000
+
+	dhcpReplyTimeout     = 4 * time.Second
+	dhcpAttempts         = 3
+	dhcpDefaultLeaseTime = time.Hour
+	dhcpRenewRetry       = time.Minute
+	dhcpMaxMessageLen    = 1500
+)
+
+var (
+	// where the DHCP messages are sent to
+	dhcpServerAddr net.Addr = &net.UDPAddr{IP: net.IPv4bcast, Port: dhcpServerPort}
+
+	dhcpListen = listenDHCP
+
+	// shortest time between lease renewals, also with a zero lease time
+	dhcpMinRenewWait = 10 * time.Second
+)
+
+// dhcpLease is the address leased to an interface by a DHCP server.
+type dhcpLease struct {
+	addr      net.IP
+	mask      net.IPMask
+	router    net.IP
+	server    net.IP
+	leaseTime time.Duration
+}
+
+// listenDHCP opens the DHCP client socket of the interface. The socket is
+// bound to the interface so that clients on all interfaces can run at the
+// same time.
+func listenDHCP(ifname string) (net.PacketConn, error) {
+	lc := net.ListenConfig{
+		Control: func(network, address string, c syscall.RawConn) error {
+			var sockErr error
+
+			err := c.Control(func(fd uintptr) {
+				if sockErr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEADDR, 1); sockErr != nil {
+					return
+				}
+				if sockErr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_BROADCAST, 1); sockErr != nil {
+					return
+				}
+				sockErr = unix.BindToDevice(int(fd), ifname)
+			})
+			if err != nil {
+				return err
+			}
+
+			return sockErr
+		},
+	}
+
+	return lc.ListenPacket(context.Background(), "udp4", fmt.Sprintf(":%d", dhcpClientPort))
+}
+
+// dhcpMessage serializes a DHCP client message of the given type.
+func dhcpMessage(msgType layers.DHCPMsgType, hwaddr net.HardwareAddr, xid uint32, clientIP net.IP, options ...layers.DHCPOption) ([]byte, error) {
+	msg := &layers.DHCPv4{
+		Operation:    layers.DHCPOpRequest,
+		HardwareType: layers.LinkTypeEthernet,
+		Xid:          xid,
+		Flags:        dhcpFlagBroadcast,
+		ClientIP:     clientIP,
+		ClientHWAddr: hwaddr,
+		Options: append([]layers.DHCPOption{
+			layers.NewDHCPOption(layers.DHCPOptMessageType, []byte{byte(msgType)}),
+		}, options...),
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	if err := msg.SerializeTo(buf, gopacket.SerializeOptions{FixLengths: true}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// dhcpOption returns the data of the option, or nil if it is missing.
+func dhcpOption(msg *layers.DHCPv4, opt layers.DHCPOpt) []byte {
+	for _, o := range msg.Options {
+		if o.Type == opt {
+			return o.Data
+		}
+	}
+
+	return nil
+}
+
+// dhcpMessageType returns the DHCP message type of the reply.
+func dhcpMessageType(msg *layers.DHCPv4) layers.DHCPMsgType {
+	if data := dhcpOption(msg, layers.DHCPOptMessageType); len(data) == 1 {
+		return layers.DHCPMsgType(data[0])
+	}
+
+	return layers.DHCPMsgTypeUnspecified
+}
+
+// parseDHCPReply decodes a DHCP server reply.
+func parseDHCPReply(data []byte) (*layers.DHCPv4, error) {
+	// the decoder does not check the hardware address length
+	if len(data) < 240 || data[2] > 16 {
+		return nil, fmt.Errorf("invalid DHCP message")
+	}
+
+	msg := &layers.DHCPv4{}
+	if err := msg.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
+		return nil, err
+	}
+
+	if msg.Operation != layers.DHCPOpReply {
+		return nil, fmt.Errorf("not a DHCP reply")
+	}
+
+	return msg, nil
+}
+
+// dhcpReceive waits for a reply to the transaction with one of the given
+// message types, ignoring other traffic.
+func dhcpReceive(conn net.PacketConn, xid uint32, msgTypes ...layers.DHCPMsgType) (*layers.DHCPv4, error) {
+	if err := conn.SetReadDeadline(time.Now().Add(dhcpReplyTimeout)); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, dhcpMaxMessageLen)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		// the decoded message refers to the data
+		msg, err := parseDHCPReply(append([]byte{}, buf[:n]...))
+		if err != nil || msg.Xid != xid {
+			continue
+		}
+
+		for _, msgType := range msgTypes {
+			if dhcpMessageType(msg) == msgType {
+				return msg, nil
+			}
+		}
+	}
+}
+
+// dhcpTransaction sends the message and waits for the reply, retrying
+// when no reply is received.
+func dhcpTransaction(conn net.PacketConn, xid uint32, msg []byte, msgTypes ...layers.DHCPMsgType) (*layers.DHCPv4, error) {
+	var err error
+
+	for range dhcpAttempts {
+		if _, err = conn.WriteTo(msg, dhcpServerAddr); err != nil {
+			return nil, err
+		}
+
+		var reply *layers.DHCPv4
+		if reply, err = dhcpReceive(conn, xid, msgTypes...); err == nil {
+			return reply, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no DHCP reply: %v", err)
+}
+
+// dhcpLeaseFromAck returns the lease granted in the DHCP ACK.
+func dhcpLeaseFromAck(ack *layers.DHCPv4) (*dhcpLease, error) {
+	lease := &dhcpLease{
+		addr:      ack.YourClientIP.To4(),
+		leaseTime: dhcpDefaultLeaseTime,
+	}
+
+	if lease.addr == nil || lease.addr.IsUnspecified() {
+		return nil, fmt.Errorf("no address in DHCP ACK")
+	}
+
+	mask := dhcpOption(ack, layers.DHCPOptSubnetMask)
+	if len(mask) != net.IPv4len {
+		return nil, fmt.Errorf("no subnet mask in DHCP ACK")
+	}
+	lease.mask = net.IPMask(mask)
+
+	if router := dhcpOption(ack, layers.DHCPOptRouter); len(router) >= net.IPv4len {
+		lease.router = net.IP(router[:net.IPv4len])
+	}
+	if server := dhcpOption(ack, layers.DHCPOptServerID); len(server) == net.IPv4len {
+		lease.server = net.IP(server)
+	}
+	if leaseTime := dhcpOption(ack, layers.DHCPOptLeaseTime); len(leaseTime) == 4 {
+		lease.leaseTime = time.Duration(binary.BigEndian.Uint32(leaseTime)) * time.Second
+	}
+
+	return lease, nil
+}
+
+// dhcpParams lists the options requested from the server.
+func dhcpParams() layers.DHCPOption {
+	return layers.NewDHCPOption(layers.DHCPOptParamsRequest, []byte{
+		byte(layers.DHCPOptSubnetMask),
+		byte(layers.DHCPOptRouter),
+		byte(layers.DHCPOptLeaseTime),
+	})
+}
+
+// requestDHCPLease gets an address for the interface from a DHCP server.
+func requestDHCPLease(ifname string, hwaddr net.HardwareAddr) (*dhcpLease, error) {
+	conn, err := dhcpListen(ifname)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open DHCP socket: %v", err)
+	}
+	defer conn.Close()
+
+	xid := rand.Uint32()
+
+	discover, err := dhcpMessage(layers.DHCPMsgTypeDiscover, hwaddr, xid, nil, dhcpParams())
+	if err != nil {
+		return nil, err
+	}
+
+	offer, err := dhcpTransaction(conn, xid, discover, layers.DHCPMsgTypeOffer)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := dhcpMessage(layers.DHCPMsgTypeRequest, hwaddr, xid, nil,
+		layers.NewDHCPOption(layers.DHCPOptRequestIP, offer.YourClientIP.To4()),
+		layers.NewDHCPOption(layers.DHCPOptServerID, dhcpOption(offer, layers.DHCPOptServerID)),
+		dhcpParams())
+	if err != nil {
+		return nil, err
+	}
+
+	ack, err := dhcpTransaction(conn, xid, request, layers.DHCPMsgTypeAck, layers.DHCPMsgTypeNak)
+	if err != nil {
+		return nil, err
+	}
+	if dhcpMessageType(ack) == layers.DHCPMsgTypeNak {
+		return nil, fmt.Errorf("DHCP request for %s declined", offer.YourClientIP)
+	}
+
+	return dhcpLeaseFromAck(ack)
+}
+
+// renewDHCPLease extends the lease of the interface address.
+func renewDHCPLease(ifname string, hwaddr net.HardwareAddr, lease *dhcpLease) (*dhcpLease, error) {
+	conn, err := dhcpListen(ifname)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open DHCP socket: %v", err)
+	}
+	defer conn.Close()
+
+	xid := rand.Uint32()
+
+	request, err := dhcpMessage(layers.DHCPMsgTypeRequest, hwaddr, xid, lease.addr, dhcpParams())
+	if err != nil {
+		return nil, err
+	}
+
+	ack, err := dhcpTransaction(conn, xid, request, layers.DHCPMsgTypeAck, layers.DHCPMsgTypeNak)
+	if err != nil {
+		return nil, err
+	}
+	if dhcpMessageType(ack) == layers.DHCPMsgTypeNak {
+		return nil, fmt.Errorf("DHCP lease of %s not renewed", lease.addr)
+	}
+
+	return dhcpLeaseFromAck(ack)
+}
+
+// releaseDHCPLease gives the interface address back to the server.
+func releaseDHCPLease(ifname string, hwaddr net.HardwareAddr, lease *dhcpLease) error {
+	conn, err := dhcpListen(ifname)
+	if err != nil {
+		return fmt.Errorf("cannot open DHCP socket: %v", err)
+	}
+	defer conn.Close()
+
+	options := []layers.DHCPOption{}
+	if lease.server != nil {
+		options = append(options, layers.NewDHCPOption(layers.DHCPOptServerID, lease.server.To4()))
+	}
+
+	release, err := dhcpMessage(layers.DHCPMsgTypeRelease, hwaddr, rand.Uint32(), lease.addr, options...)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.WriteTo(release, dhcpServerAddr)
+
+	return err
+}
+
+// dhcpResults gets the interface addresses from DHCP. Returns true if any
+// interface got an address.
+func dhcpResults(networkConfigs map[string]*networkConfiguration) bool {
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	leases := map[string]*dhcpLease{}
+
+	for ifname, nwconfig := range networkConfigs {
+		if nwconfig.link.Attrs().Flags&net.FlagUp == 0 {
+			klog.Infof("Link '%s' is down, cannot start DHCP", ifname)
+			continue
+		}
+
+		hwaddr := *nwconfig.localHwAddr
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			lease, err := requestDHCPLease(ifname, hwaddr)
+			if err != nil {
+				klog.Warningf("Interface '%s' DHCP failed: %v", ifname, err)
+				return
+			}
+
+			mu.Lock()
+			leases[ifname] = lease
+			mu.Unlock()
+		}()
+
+		klog.Infof("Started DHCP for '%s'...", ifname)
+	}
+
+	wg.Wait()
+
+	for ifname, lease := range leases {
+		nwconfig := networkConfigs[ifname]
+
+		nwconfig.localAddr = &lease.addr
+		nwconfig.localPrefixLen, _ = lease.mask.Size()
+		nwconfig.dhcpLease = lease
+		if lease.router != nil {
+			nwconfig.gateway = &lease.router
+		}
+
+		klog.Infof("Interface '%s' leased address %s/%d for %s",
+			ifname, lease.addr, nwconfig.localPrefixLen, lease.leaseTime)
+	}
+
+	return len(leases) > 0
+}
+
+// removeDHCPAddress removes the address of an expired lease from the
+// interface, which is then no longer configured.
+func removeDHCPAddress(ifname string, nwconfig *networkConfiguration) error {
+	addr := &netlink.Addr{
+		IPNet: &net.IPNet{
+			IP:   *nwconfig.localAddr,
+			Mask: networkMask(nwconfig, RouteMaskPointToPoint),
+		},
+	}
+
+	nwconfig.localAddr = nil
+	nwconfig.dhcpLease = nil
+	nwconfig.configured = false
+
+	if err := networkLink.AddrDel(l3Link(nwconfig), addr); err != nil {
+		return fmt.Errorf("cannot remove address %s of interface '%s': %v", addr.IPNet, ifname, err)
+	}
+
+	klog.Infof("Removed address %s of interface '%s'", addr.IPNet, ifname)
+
+	return nil
+}
+
+// dhcpRenewer keeps renewing the DHCP leases of the interfaces and
+// releases them when stopped. The interfaces whose lease expires are sent
+// to the expired channel.
+type dhcpRenewer struct {
+	done    chan struct{}
+	expired chan string
+	wg      sync.WaitGroup
+}
+
+// startDHCPRenewer starts renewing the leases of the interfaces. Returns
+// nil if the addresses do not come from DHCP.
+func startDHCPRenewer(networkConfigs map[string]*networkConfiguration) *dhcpRenewer {
+	var r *dhcpRenewer
+
+	for ifname, nwconfig := range networkConfigs {
+		if nwconfig.dhcpLease == nil {
+			continue
+		}
+
+		if r == nil {
+			// each interface expires at most once, the renewers never block
+			r = &dhcpRenewer{
+				done:    make(chan struct{}),
+				expired: make(chan string, len(networkConfigs)),
+			}
+		}
+
+		lease := *nwconfig.dhcpLease
+		hwaddr := *nwconfig.localHwAddr
+
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+
+			r.renew(ifname, hwaddr, &lease)
+		}()
+	}
+
+	return r
+}
+
+// dhcpRenewWait returns the time to wait before renewing a lease, half of
+// its lease time.
+func dhcpRenewWait(leaseTime time.Duration) time.Duration {
+	return max(leaseTime/2, dhcpMinRenewWait)
+}
+
+// renew renews the lease at half of its lease time until stopped. Failed
+// renewals are retried until the lease expires.
+func (r *dhcpRenewer) renew(ifname string, hwaddr net.HardwareAddr, lease *dhcpLease) {
+	expires := time.Now().Add(lease.leaseTime)
+	wait := dhcpRenewWait(lease.leaseTime)
+
+	for {
+		select {
+		case <-r.done:
+			if err := releaseDHCPLease(ifname, hwaddr, lease); err != nil {
+				klog.Warningf("Cannot release the DHCP lease of interface '%s': %v", ifname, err)
+			}
+			return
+		case <-time.After(wait):
+		}
+
+		renewed, err := renewDHCPLease(ifname, hwaddr, lease)
+		if err != nil {
+			remaining := time.Until(expires)
+			if remaining <= 0 {
+				klog.Warningf("Interface '%s' %v, lease expired", ifname, err)
+				r.expired <- ifname
+				return
+			}
+
+			klog.Warningf("Interface '%s' %v, retrying", ifname, err)
+			wait = max(min(dhcpRenewRetry, remaining), dhcpMinRenewWait)
+			continue
+		}
+
+		if !renewed.addr.Equal(lease.addr) {
+			klog.Warningf("Interface '%s' DHCP server changed the address to %s, keeping %s",
+				ifname, renewed.addr, lease.addr)
+		}
+
+		klog.V(3).Infof("Interface '%s' renewed DHCP lease of %s for %s", ifname, lease.addr, renewed.leaseTime)
+
+		lease.leaseTime = renewed.leaseTime
+		expires = time.Now().Add(lease.leaseTime)
+		wait = dhcpRenewWait(lease.leaseTime)
+	}
+}
+
+// expiredLeases returns the channel of the interfaces whose lease expired.
+// A nil renewer returns a nil channel which blocks forever.
+func (r *dhcpRenewer) expiredLeases() <-chan string {
+	if r == nil {
+		return nil
+	}
+
+	return r.expired
+}
+
+// stop stops renewing and releases the leases.
+func (r *dhcpRenewer) stop() {
+	if r == nil {
+		return
+	}
+
+	close(r.done)
+	r.wg.Wait()
+}
@@ -0,0 +1,293 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/vishvananda/netlink"
+)
+
+// fakeDHCPServer answers the DHCP client messages on the loopback
+// interface, leasing 192.168.10.11/24.
+type fakeDHCPServer struct {
+	conn net.PacketConn
+
+	mu       sync.Mutex
+	received []layers.DHCPMsgType
+}
+
+func startFakeDHCPServer(t *testing.T) *fakeDHCPServer {
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("cannot start DHCP server: %v", err)
+	}
+
+	s := &fakeDHCPServer{conn: conn}
+
+	origAddr, origListen := dhcpServerAddr, dhcpListen
+	t.Cleanup(func() {
+		dhcpServerAddr, dhcpListen = origAddr, origListen
+		conn.Close()
+	})
+
+	dhcpServerAddr = conn.LocalAddr()
+	dhcpListen = func(ifname string) (net.PacketConn, error) {
+		return net.ListenPacket("udp4", "127.0.0.1:0")
+	}
+
+	go s.serve(t)
+
+	return s
+}
+
+func (s *fakeDHCPServer) messages() []layers.DHCPMsgType {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]layers.DHCPMsgType{}, s.received...)
+}
+
+func (s *fakeDHCPServer) serve(t *testing.T) {
+	buf := make([]byte, dhcpMaxMessageLen)
+
+	for {
+		n, client, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		request := &layers.DHCPv4{}
+		if err := request.DecodeFromBytes(buf[:n], gopacket.NilDecodeFeedback); err != nil {
+			t.Errorf("cannot decode DHCP message: %v", err)
+			return
+		}
+
+		msgType := dhcpMessageType(request)
+
+		s.mu.Lock()
+		s.received = append(s.received, msgType)
+		s.mu.Unlock()
+
+		var replyType layers.DHCPMsgType
+		switch msgType {
+		case layers.DHCPMsgTypeDiscover:
+			replyType = layers.DHCPMsgTypeOffer
+		case layers.DHCPMsgTypeRequest:
+			replyType = layers.DHCPMsgTypeAck
+		default:
+			continue
+		}
+
+		reply := &layers.DHCPv4{
+			Operation:    layers.DHCPOpReply,
+			HardwareType: layers.LinkTypeEthernet,
+			Xid:          request.Xid,
+			YourClientIP: net.ParseIP("192.168.10.11").To4(),
+			ClientHWAddr: request.ClientHWAddr,
+			Options: []layers.DHCPOption{
+				layers.NewDHCPOption(layers.DHCPOptMessageType, []byte{byte(replyType)}),
+				layers.NewDHCPOption(layers.DHCPOptServerID, []byte{192, 168, 10, 2}),
+				layers.NewDHCPOption(layers.DHCPOptSubnetMask, []byte{255, 255, 255, 0}),
+				layers.NewDHCPOption(layers.DHCPOptRouter, []byte{192, 168, 10, 1}),
+				layers.NewDHCPOption(layers.DHCPOptLeaseTime, []byte{0, 0, 0x0e, 0x10}),
+			},
+		}
+
+		out := gopacket.NewSerializeBuffer()
+		if err := reply.SerializeTo(out, gopacket.SerializeOptions{FixLengths: true}); err != nil {
+			t.Errorf("cannot serialize DHCP reply: %v", err)
+			return
+		}
+
+		if _, err := s.conn.WriteTo(out.Bytes(), client); err != nil {
+			return
+		}
+	}
+}
+
+func TestDHCPResults(t *testing.T) {
+	server := startFakeDHCPServer(t)
+
+	networkConfigs := map[string]*networkConfiguration{
+		"eth0": fakeL2Config("eth0", 1, "00:00:00:00:00:10"),
+		"eth1": fakeL2Config("eth1", 2, "00:00:00:00:00:11"),
+	}
+	for _, nwconfig := range networkConfigs {
+		nwconfig.localHwAddr = &nwconfig.link.Attrs().HardwareAddr
+	}
+	networkConfigs["eth0"].link.Attrs().Flags = net.FlagUp
+
+	if !dhcpResults(networkConfigs) {
+		t.Fatal("no addresses from DHCP")
+	}
+
+	nwconfig := networkConfigs["eth0"]
+	if nwconfig.localAddr == nil || nwconfig.localAddr.String() != "192.168.10.11" || nwconfig.localPrefixLen != 24 {
+		t.Errorf("unexpected DHCP address %v/%d", nwconfig.localAddr, nwconfig.localPrefixLen)
+	}
+	if nwconfig.gateway == nil || nwconfig.gateway.String() != "192.168.10.1" {
+		t.Errorf("unexpected DHCP gateway %v", nwconfig.gateway)
+	}
+	if nwconfig.dhcpLease == nil || nwconfig.dhcpLease.leaseTime != time.Hour || nwconfig.dhcpLease.server.String() != "192.168.10.2" {
+		t.Errorf("unexpected DHCP lease %+v", nwconfig.dhcpLease)
+	}
+
+	if networkConfigs["eth1"].localAddr != nil {
+		t.Errorf("unexpected DHCP address for a link that is down")
+	}
+
+	renewer := startDHCPRenewer(networkConfigs)
+	if renewer == nil {
+		t.Fatal("DHCP leases not renewed")
+	}
+	renewer.stop()
+
+	expected := []layers.DHCPMsgType{layers.DHCPMsgTypeDiscover, layers.DHCPMsgTypeRequest, layers.DHCPMsgTypeRelease}
+	deadline := time.Now().Add(time.Second)
+	for len(server.messages()) < len(expected) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	messages := server.messages()
+	if len(messages) != len(expected) {
+		t.Fatalf("unexpected DHCP messages %v", messages)
+	}
+	for i := range expected {
+		if messages[i] != expected[i] {
+			t.Errorf("unexpected DHCP messages %v", messages)
+		}
+	}
+}
+
+func TestDHCPLeaseFromAck(t *testing.T) {
+	ack := &layers.DHCPv4{
+		YourClientIP: net.ParseIP("192.168.10.11"),
+		Options: []layers.DHCPOption{
+			layers.NewDHCPOption(layers.DHCPOptSubnetMask, []byte{255, 255, 0, 0}),
+		},
+	}
+
+	lease, err := dhcpLeaseFromAck(ack)
+	if err != nil {
+		t.Fatalf("cannot get lease: %v", err)
+	}
+	if ones, _ := lease.mask.Size(); ones != 16 || lease.router != nil || lease.leaseTime != dhcpDefaultLeaseTime {
+		t.Errorf("unexpected lease %+v", lease)
+	}
+
+	ack.Options = nil
+	if _, err := dhcpLeaseFromAck(ack); err == nil {
+		t.Error("expected an error without a subnet mask")
+	}
+
+	ack.YourClientIP = net.IPv4zero
+	if _, err := dhcpLeaseFromAck(ack); err == nil {
+		t.Error("expected an error without an address")
+	}
+}
+
+func TestDHCPNoStartWithoutLeases(t *testing.T) {
+	networkConfigs := map[string]*networkConfiguration{
+		"eth0": {link: &fakeLink{fakeAttrs: netlink.LinkAttrs{Name: "eth0"}}},
+	}
+
+	renewer := startDHCPRenewer(networkConfigs)
+	if renewer != nil {
+		t.Error("unexpected DHCP renewer without leases")
+	}
+	renewer.stop()
+}
+
+func TestDHCPLeaseExpiry(t *testing.T) {
+	origNetworkLink, origListen, origWait := networkLink, dhcpListen, dhcpMinRenewWait
+	defer func(dir, file string) {
+		networkLink, dhcpListen, dhcpMinRenewWait = origNetworkLink, origListen, origWait
+		nfdFeatureDir, nfdLabelFile = dir, file
+	}(nfdFeatureDir, nfdLabelFile)
+
+	nfdFeatureDir = t.TempDir()
+	nfdLabelFile = filepath.Join(nfdFeatureDir, "scale-out-readiness.txt")
+
+	removed := []string{}
+	networkLink.AddrDel = func(link netlink.Link, addr *netlink.Addr) error {
+		removed = append(removed, link.Attrs().Name+" "+addr.IPNet.String())
+		return nil
+	}
+
+	// the server never answers, a zero lease time does not renew in a busy loop
+	dhcpMinRenewWait = 10 * time.Millisecond
+	attempts := 0
+	dhcpListen = func(ifname string) (net.PacketConn, error) {
+		attempts++
+		return nil, fmt.Errorf("no server")
+	}
+
+	nwconfig := fakeL2Config("eth0", 1, "00:00:00:00:00:10")
+	nwconfig.localHwAddr = &nwconfig.link.Attrs().HardwareAddr
+	nwconfig.dhcpLease = &dhcpLease{addr: net.ParseIP("192.168.10.11").To4(), mask: net.CIDRMask(24, 32)}
+	nwconfig.localAddr = &nwconfig.dhcpLease.addr
+	nwconfig.localPrefixLen = 24
+	nwconfig.configured = true
+	networkConfigs := map[string]*networkConfiguration{"eth0": nwconfig}
+
+	renewer := startDHCPRenewer(networkConfigs)
+	defer renewer.stop()
+
+	select {
+	case ifname := <-renewer.expiredLeases():
+		if ifname != "eth0" {
+			t.Errorf("unexpected expired lease of interface '%s'", ifname)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("lease did not expire")
+	}
+	if attempts != 1 {
+		t.Errorf("expected a single renewal attempt, got %d", attempts)
+	}
+
+	config := &cmdConfig{ctx: context.Background(), mode: L2, l2AddressSource: l2AddressDHCP}
+
+	var watcher *linkWatcher
+	watcher.handleLeaseExpiry(config, nil, nil, networkConfigs, "eth0")
+
+	if len(removed) != 1 || removed[0] != "eth0 192.168.10.11/24" {
+		t.Errorf("unexpected removed addresses %v", removed)
+	}
+	if nwconfig.localAddr != nil || nwconfig.dhcpLease != nil || nwconfig.configured {
+		t.Errorf("expired address still configured %+v", nwconfig)
+	}
+
+	data, err := os.ReadFile(nfdLabelFile)
+	if err != nil {
+		t.Fatalf("cannot read labels: %v", err)
+	}
+	if labels := string(data); strings.Contains(labels, nfdScaleOutLabel+"=true") ||
+		!strings.Contains(labels, nfdPortLabelPrefix+"eth0=false") {
+		t.Errorf("unexpected labels %s", labels)
+	}
+}
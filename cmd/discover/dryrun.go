@@ -0,0 +1,365 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/vishvananda/netlink"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	planFormatJSON = "json"
+	planFormatYAML = "yaml"
+
+	// interface indexes of the VLAN sub-interfaces that are not created
+	dryRunVLANIndex = 1 << 20
+)
+
+var (
+	errDryRun = fmt.Errorf("not applied in dry run")
+
+	// where the plan is written to
+	planOutput io.Writer = os.Stdout
+)
+
+// plannedChange is a change the agent would make to the node.
+type plannedChange struct {
+	Interface string `json:"interface,omitempty"`
+	Action    string `json:"action"`
+	Value     string `json:"value,omitempty"`
+}
+
+// plannedFile is a file the agent would write.
+type plannedFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// dryRunPlan records the changes the agent would make in place of applying
+// them. The netlink, DCB, lldptool, DHCP and file functions are replaced
+// with recording ones while the plan is active, the read-only ones are
+// left as is.
+type dryRunPlan struct {
+	Changes []plannedChange `json:"changes"`
+	Files   []plannedFile   `json:"files,omitempty"`
+
+	mutex sync.Mutex
+	names map[int]string
+	vlans map[string]netlink.Link
+
+	linkUpdates chan<- netlink.LinkUpdate
+	linkDone    <-chan struct{}
+
+	origNetworkLink networkLinkFn
+	origDCBLink     dcbLinkFn
+	origLLDPTool    func(args ...string) error
+	origWriteFile   func(name string, data []byte, perm os.FileMode) error
+	origDHCPListen  func(ifname string) (net.PacketConn, error)
+}
+
+func (p *dryRunPlan) record(ifname string, action string, value string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.Changes = append(p.Changes, plannedChange{Interface: ifname, Action: action, Value: value})
+}
+
+// linkName returns the name of the interface with the index.
+func (p *dryRunPlan) linkName(index int) string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.names[index]
+}
+
+// linkSetUp records setting the link up and sends the link update the
+// kernel would send for it.
+func (p *dryRunPlan) linkSetUp(link netlink.Link) error {
+	p.record(link.Attrs().Name, "link-up", "")
+
+	attrs := *link.Attrs()
+	attrs.Flags |= net.FlagUp
+	update := netlink.LinkUpdate{Link: &netlink.Device{LinkAttrs: attrs}}
+
+	p.mutex.Lock()
+	ch, done := p.linkUpdates, p.linkDone
+	p.mutex.Unlock()
+
+	if ch != nil {
+		go func() {
+			select {
+			case ch <- update:
+			case <-done:
+			}
+		}()
+	}
+
+	return nil
+}
+
+func (p *dryRunPlan) linkSubscribe(ch chan<- netlink.LinkUpdate, done <-chan struct{}) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.linkUpdates, p.linkDone = ch, done
+
+	return nil
+}
+
+// linkAdd records adding the VLAN sub-interface, which is then found by
+// name for the rest of the plan.
+func (p *dryRunPlan) linkAdd(link netlink.Link) error {
+	vlan, ok := link.(*netlink.Vlan)
+	if !ok {
+		return fmt.Errorf("unexpected link type %s", link.Type())
+	}
+
+	p.mutex.Lock()
+	vlan.Index = dryRunVLANIndex + len(p.vlans)
+	p.vlans[vlan.Name] = vlan
+	p.names[vlan.Index] = vlan.Name
+	parent := p.names[vlan.ParentIndex]
+	p.mutex.Unlock()
+
+	p.record(parent, "link-add", fmt.Sprintf("%s vlan %d", vlan.Name, vlan.VlanId))
+
+	return nil
+}
+
+func (p *dryRunPlan) linkByName(name string) (netlink.Link, error) {
+	p.mutex.Lock()
+	vlan, exists := p.vlans[name]
+	p.mutex.Unlock()
+
+	if exists {
+		return vlan, nil
+	}
+
+	return p.origNetworkLink.LinkByName(name)
+}
+
+func (p *dryRunPlan) addrList(link netlink.Link, family int) ([]netlink.Addr, error) {
+	if link.Attrs().Index >= dryRunVLANIndex {
+		return nil, nil
+	}
+
+	return p.origNetworkLink.AddrList(link, family)
+}
+
+func (p *dryRunPlan) routeAppend(route *netlink.Route) error {
+	value := "default"
+	if route.Dst != nil {
+		value = route.Dst.String()
+	}
+	if route.Gw != nil {
+		value += " via " + route.Gw.String()
+	}
+	if route.Src != nil {
+		value += " src " + route.Src.String()
+	}
+
+	p.record(p.linkName(route.LinkIndex), "route-append", value)
+
+	return nil
+}
+
+func (p *dryRunPlan) writeFile(name string, data []byte, perm os.FileMode) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.Files = append(p.Files, plannedFile{Path: name, Content: string(data)})
+
+	return nil
+}
+
+// install replaces the functions changing the node with recording ones.
+func (p *dryRunPlan) install() {
+	p.origNetworkLink = networkLink
+	p.origDCBLink = dcbLink
+	p.origLLDPTool = runLLDPTool
+	p.origWriteFile = WriteFile
+	p.origDHCPListen = dhcpListen
+
+	networkLink.LinkByName = p.linkByName
+	networkLink.AddrList = p.addrList
+	networkLink.LinkSubscribe = p.linkSubscribe
+	networkLink.LinkSetUp = p.linkSetUp
+	networkLink.LinkAdd = p.linkAdd
+	networkLink.RouteAppend = p.routeAppend
+	networkLink.LinkSetDown = func(link netlink.Link) error {
+		p.record(link.Attrs().Name, "link-down", "")
+		return nil
+	}
+	networkLink.LinkSetMTU = func(link netlink.Link, mtu int) error {
+		p.record(link.Attrs().Name, "set-mtu", strconv.Itoa(mtu))
+		return nil
+	}
+	networkLink.LinkDel = func(link netlink.Link) error {
+		p.record(link.Attrs().Name, "link-del", "")
+		return nil
+	}
+	networkLink.AddrAdd = func(link netlink.Link, addr *netlink.Addr) error {
+		p.record(link.Attrs().Name, "addr-add", addr.IPNet.String())
+		return nil
+	}
+	networkLink.AddrDel = func(link netlink.Link, addr *netlink.Addr) error {
+		p.record(link.Attrs().Name, "addr-del", addr.IPNet.String())
+		return nil
+	}
+
+	dcbLink.IEEESet = func(ifname string, ets *ieeeETS, pfc *ieeePFC) error {
+		if ets != nil {
+			p.record(ifname, "dcb-ets", fmt.Sprintf("prio-tc=%v tc-bw=%v", ets.PrioTC, ets.TCTxBW))
+		}
+		if pfc != nil {
+			p.record(ifname, "dcb-pfc", fmt.Sprintf("0x%02x", pfc.PFCEn))
+		}
+		return nil
+	}
+	dcbLink.SetDCBX = func(ifname string, mode uint8) error {
+		p.record(ifname, "dcb-dcbx", fmt.Sprintf("0x%02x", mode))
+		return nil
+	}
+	dcbLink.AppAdd = func(ifname string, apps []dcbApp) error {
+		p.record(ifname, "dcb-app-add", dcbAppsString(apps))
+		return nil
+	}
+	dcbLink.AppDel = func(ifname string, apps []dcbApp) error {
+		p.record(ifname, "dcb-app-del", dcbAppsString(apps))
+		return nil
+	}
+	dcbLink.SetAppTrust = func(ifname string, selectors []uint8) error {
+		p.record(ifname, "dcb-app-trust", fmt.Sprint(selectors))
+		return nil
+	}
+
+	runLLDPTool = func(args ...string) error {
+		ifname := ""
+		for i, arg := range args {
+			if arg == "-i" && i+1 < len(args) {
+				ifname = args[i+1]
+			}
+		}
+		p.record(ifname, "lldptool", strings.Join(args, " "))
+		return nil
+	}
+
+	WriteFile = p.writeFile
+
+	dhcpListen = func(ifname string) (net.PacketConn, error) {
+		p.record(ifname, "dhcp-request", "")
+		return nil, errDryRun
+	}
+}
+
+// uninstall puts the functions changing the node back.
+func (p *dryRunPlan) uninstall() {
+	networkLink = p.origNetworkLink
+	dcbLink = p.origDCBLink
+	runLLDPTool = p.origLLDPTool
+	WriteFile = p.origWriteFile
+	dhcpListen = p.origDHCPListen
+}
+
+func dcbAppsString(apps []dcbApp) string {
+	entries := []string{}
+	for _, app := range apps {
+		entries = append(entries, fmt.Sprintf("%d,%d,%d", app.Priority, app.Selector, app.Protocol))
+	}
+
+	return strings.Join(entries, " ")
+}
+
+// startDryRun starts recording the changes to the interfaces.
+func startDryRun(networkConfigs map[string]*networkConfiguration) *dryRunPlan {
+	p := &dryRunPlan{
+		Changes: []plannedChange{},
+		names:   map[int]string{},
+		vlans:   map[string]netlink.Link{},
+	}
+
+	for ifname, nwconfig := range networkConfigs {
+		p.names[nwconfig.link.Attrs().Index] = ifname
+	}
+
+	p.install()
+
+	klog.Infof("Dry run, recording the changes without applying them")
+
+	return p
+}
+
+// write writes the plan in the given format.
+func (p *dryRunPlan) write(w io.Writer, format string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if format == planFormatYAML {
+		data, err = yaml.Marshal(p)
+	} else {
+		data, err = json.MarshalIndent(p, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return fmt.Errorf("cannot marshal the dry run plan: %v", err)
+	}
+
+	_, err = w.Write(data)
+
+	return err
+}
+
+// finish stops recording and writes the plan. With --keep-running it waits
+// to be terminated so that the agent is not restarted.
+func (p *dryRunPlan) finish(config *cmdConfig, runErr error) error {
+	p.uninstall()
+
+	if err := p.write(planOutput, config.planFormat); err != nil {
+		return err
+	}
+
+	if runErr != nil || !config.keepRunning {
+		return runErr
+	}
+
+	klog.Infof("Dry run done. Idling...")
+
+	term := make(chan os.Signal, 1)
+	signal.Notify(term, os.Interrupt, syscall.SIGTERM)
+	<-term
+
+	klog.Infof("Exited")
+
+	return nil
+}
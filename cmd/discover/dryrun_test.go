@@ -0,0 +1,157 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/vishvananda/netlink"
+	"sigs.k8s.io/yaml"
+)
+
+func TestDryRun(t *testing.T) {
+	origLink, origDCB := networkLink, dcbLink
+	t.Cleanup(func() { networkLink, dcbLink = origLink, origDCB })
+
+	applied := []string{}
+	fail := func(name string) func(netlink.Link) error {
+		return func(link netlink.Link) error {
+			applied = append(applied, name)
+			return fmt.Errorf("%s applied in dry run", name)
+		}
+	}
+
+	networkLink.LinkSetUp = fail("LinkSetUp")
+	networkLink.LinkSetDown = fail("LinkSetDown")
+	networkLink.LinkAdd = fail("LinkAdd")
+	networkLink.LinkDel = fail("LinkDel")
+	networkLink.LinkByName = func(name string) (netlink.Link, error) {
+		return nil, fmt.Errorf("link '%s' not found", name)
+	}
+	networkLink.AddrList = func(link netlink.Link, family int) ([]netlink.Addr, error) {
+		addr, _ := netlink.ParseAddr("192.168.1.5/24")
+		return []netlink.Addr{*addr}, nil
+	}
+	dcbLink.IEEEGet = func(ifname string) (*ieeeETS, *ieeePFC, error) {
+		return nil, nil, nil
+	}
+	dcbLink.IEEESet = func(ifname string, ets *ieeeETS, pfc *ieeePFC) error {
+		applied = append(applied, "IEEESet")
+		return nil
+	}
+	dcbLink.SetDCBX = func(ifname string, mode uint8) error {
+		applied = append(applied, "SetDCBX")
+		return nil
+	}
+
+	nwconfig := fakeVLANConfig("eth0", 1, "10.210.8.121/30")
+	nwconfig.link.Attrs().HardwareAddr, _ = net.ParseMAC("00:00:00:00:00:10")
+	peerMAC, _ := net.ParseMAC("02:00:00:00:00:01")
+	nwconfig.peerHWAddr = &peerMAC
+	networkConfigs := map[string]*networkConfiguration{"eth0": nwconfig}
+
+	config := &cmdConfig{mtu: 9000, vlan: 100, pfc: "3", pfcBackend: pfcBackendNetlink, planFormat: planFormatJSON}
+	setVLANs(config, networkConfigs)
+
+	plan := startDryRun(networkConfigs)
+
+	if err := initializeInterfaces(config, networkConfigs); err != nil {
+		t.Fatalf("cannot initialize interfaces: %v", err)
+	}
+	if nwconfig.link.Attrs().Flags&net.FlagUp == 0 {
+		t.Errorf("link not up for the rest of the dry run")
+	}
+	if err := createVLANs(config, networkConfigs); err != nil {
+		t.Fatalf("cannot create VLANs: %v", err)
+	}
+	if configured, total := configureInterfaces(networkConfigs); configured != total {
+		t.Errorf("configured %d of %d interfaces", configured, total)
+	}
+	JsonMarshal = json.Marshal
+	if err := WriteGaudiNet("/etc/habanalabs/gaudinet.json", networkConfigs); err != nil {
+		t.Errorf("cannot write gaudinet: %v", err)
+	}
+	if err := EnableAllPFC(config, networkConfigs); err != nil {
+		t.Errorf("cannot enable PFC: %v", err)
+	}
+	if err := execPFC("eth0", "3"); err != nil {
+		t.Errorf("cannot run lldptool: %v", err)
+	}
+
+	plan.uninstall()
+
+	if len(applied) != 0 {
+		t.Errorf("changes applied in dry run: %v", applied)
+	}
+
+	expected := []plannedChange{
+		{Interface: "eth0", Action: "link-up"},
+		{Interface: "eth0", Action: "set-mtu", Value: "9000"},
+		{Interface: "eth0", Action: "addr-del", Value: "192.168.1.5/24"},
+		{Interface: "eth0", Action: "link-add", Value: "eth0.100 vlan 100"},
+		{Interface: "eth0.100", Action: "link-up"},
+		{Interface: "eth0.100", Action: "addr-add", Value: "10.210.8.121/30"},
+		{Interface: "eth0.100", Action: "route-append", Value: "10.210.0.0/16 via 10.210.8.122"},
+		{Interface: "eth0", Action: "dcb-dcbx", Value: fmt.Sprintf("0x%02x", dcbCapDCBXHost|dcbCapDCBXVerIEEE)},
+		{Interface: "eth0", Action: "dcb-pfc", Value: "0x08"},
+		{Interface: "eth0", Action: "lldptool", Value: "-L -i eth0 adminStatus=rxtx"},
+		{Interface: "eth0", Action: "lldptool", Value: "-T -i eth0 -V PFC enableTx=yes enabled=3"},
+	}
+	if len(plan.Changes) != len(expected) {
+		t.Fatalf("unexpected planned changes %+v", plan.Changes)
+	}
+	for i := range expected {
+		if plan.Changes[i] != expected[i] {
+			t.Errorf("unexpected planned change %+v, expected %+v", plan.Changes[i], expected[i])
+		}
+	}
+
+	if len(plan.Files) != 1 || plan.Files[0].Path != "/etc/habanalabs/gaudinet.json" ||
+		!strings.Contains(plan.Files[0].Content, "10.210.8.121") {
+		t.Errorf("unexpected planned files %+v", plan.Files)
+	}
+
+	var out bytes.Buffer
+	decoded := &dryRunPlan{}
+
+	if err := plan.write(&out, planFormatJSON); err != nil {
+		t.Fatalf("cannot write JSON plan: %v", err)
+	}
+	if err := json.Unmarshal(out.Bytes(), decoded); err != nil || len(decoded.Changes) != len(expected) {
+		t.Errorf("unexpected JSON plan '%s': %v", out.String(), err)
+	}
+
+	out.Reset()
+	decoded = &dryRunPlan{}
+
+	if err := plan.write(&out, planFormatYAML); err != nil {
+		t.Fatalf("cannot write YAML plan: %v", err)
+	}
+	if err := yaml.Unmarshal(out.Bytes(), decoded); err != nil || len(decoded.Files) != 1 {
+		t.Errorf("unexpected YAML plan '%s': %v", out.String(), err)
+	}
+
+	// the original functions are back
+	if err := networkLink.LinkSetUp(nwconfig.link); err == nil || len(applied) != 1 {
+		t.Errorf("original functions not restored")
+	}
+}
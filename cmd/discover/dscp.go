@@ -0,0 +1,177 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+const maxDSCP = 63
+
+// VerifyDSCPArgument parses a comma separated list of 'dscp:priority'
+// mappings into DSCP APP table entries ordered by DSCP.
+func VerifyDSCPArgument(arg string) ([]dcbApp, error) {
+	if strings.TrimSpace(arg) == "" {
+		return nil, nil
+	}
+
+	apps := []dcbApp{}
+	seen := map[int]bool{}
+
+	for _, s := range strings.Split(arg, ",") {
+		dscpStr, prioStr, found := strings.Cut(strings.TrimSpace(s), ":")
+		if !found {
+			return nil, fmt.Errorf("mapping '%s' is not 'dscp:priority'", s)
+		}
+
+		dscp, err := strconv.Atoi(strings.TrimSpace(dscpStr))
+		if err != nil || dscp < 0 || dscp > maxDSCP {
+			return nil, fmt.Errorf("DSCP '%s' not in range 0-%d", dscpStr, maxDSCP)
+		}
+
+		prio, err := strconv.Atoi(strings.TrimSpace(prioStr))
+		if err != nil || prio < 0 || prio >= ieeeMaxTCs {
+			return nil, fmt.Errorf("priority '%s' not in range 0-%d", prioStr, ieeeMaxTCs-1)
+		}
+
+		if seen[dscp] {
+			return nil, fmt.Errorf("DSCP %d mapped more than once", dscp)
+		}
+		seen[dscp] = true
+
+		apps = append(apps, dcbApp{Selector: ieeeAppSelDSCP, Priority: uint8(prio), Protocol: uint16(dscp)})
+	}
+
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].Protocol < apps[j].Protocol
+	})
+
+	return apps, nil
+}
+
+// execDSCP adds or removes the DSCP APP table entries with lldptool. The
+// trust mode cannot be set with lldptool, drivers switch to DSCP trust
+// when DSCP entries are added.
+func execDSCP(ifname string, apps []dcbApp, remove bool) error {
+	if err := runLLDPTool("-L", "-i", ifname, "adminStatus=rxtx"); err != nil {
+		return err
+	}
+
+	for _, app := range apps {
+		args := []string{"-T", "-i", ifname, "-V", "APP"}
+		if remove {
+			args = append(args, "-d")
+		}
+		args = append(args, fmt.Sprintf("app=%d,%d,%d", app.Priority, app.Selector, app.Protocol))
+
+		if err := runLLDPTool(args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setDCBDSCP sets the interface to trust DSCP before PCP and adds the DSCP
+// APP table entries through the kernel DCB netlink interface. The original
+// trust is saved for reverting. Drivers without trust configuration are
+// expected to switch to DSCP trust when DSCP entries are added.
+func setDCBDSCP(ifname string, nwconfig *networkConfiguration, apps []dcbApp) error {
+	setHostDCBX(ifname)
+
+	if trust, err := dcbLink.GetAppTrust(ifname); err != nil {
+		klog.V(3).Infof("Cannot get the APP trust of interface %s: %v", ifname, err)
+	} else if err := dcbLink.SetAppTrust(ifname, []uint8{ieeeAppSelDSCP, dcbAppSelPCP}); err != nil {
+		klog.Warningf("Cannot set interface %s to trust DSCP: %v", ifname, err)
+	} else {
+		nwconfig.origAppTrust = trust
+		nwconfig.appTrustSet = true
+	}
+
+	if err := dcbLink.AppAdd(ifname, apps); err != nil {
+		return fmt.Errorf("cannot add DSCP APP entries of interface %s: %v", ifname, err)
+	}
+
+	klog.Infof("Set DSCP priorities of interface %s", ifname)
+
+	return nil
+}
+
+// revertDCBDSCP removes the DSCP APP table entries and restores the
+// original trust of the interface.
+func revertDCBDSCP(ifname string, nwconfig *networkConfiguration, apps []dcbApp) error {
+	if err := dcbLink.AppDel(ifname, apps); err != nil {
+		return fmt.Errorf("cannot remove DSCP APP entries of interface %s: %v", ifname, err)
+	}
+
+	if nwconfig.appTrustSet {
+		if err := dcbLink.SetAppTrust(ifname, nwconfig.origAppTrust); err != nil {
+			return fmt.Errorf("cannot restore the APP trust of interface %s: %v", ifname, err)
+		}
+		nwconfig.appTrustSet = false
+	}
+
+	return nil
+}
+
+func EnableAllDSCP(config *cmdConfig, networkConfigs map[string]*networkConfiguration) error {
+	for ifname, nwconfig := range networkConfigs {
+		var err error
+		if config.pfcBackend == pfcBackendNetlink {
+			err = setDCBDSCP(ifname, nwconfig, config.dscp)
+		} else {
+			err = execDSCP(ifname, config.dscp, false)
+		}
+		if err != nil {
+			return err
+		}
+
+		klog.V(3).Infof("Enabled DSCP priorities '%s' for interface %s", config.dscpPriorities, ifname)
+	}
+
+	return nil
+}
+
+// RevertAllDSCP removes the DSCP priorities from all interfaces. All
+// interfaces are tried, the first error is returned.
+func RevertAllDSCP(config *cmdConfig, networkConfigs map[string]*networkConfiguration) error {
+	var firstErr error
+
+	for ifname, nwconfig := range networkConfigs {
+		var err error
+		if config.pfcBackend == pfcBackendNetlink {
+			err = revertDCBDSCP(ifname, nwconfig, config.dscp)
+		} else {
+			err = execDSCP(ifname, config.dscp, true)
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		klog.V(3).Infof("Reverted DSCP priorities for interface %s", ifname)
+	}
+
+	return firstErr
+}
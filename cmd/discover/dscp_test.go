@@ -0,0 +1,151 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vishvananda/netlink/nl"
+)
+
+func TestVerifyDSCPArgument(t *testing.T) {
+	tests := []struct {
+		arg      string
+		success  bool
+		expected []dcbApp
+	}{
+		{"", true, nil},
+		{"26:3", true, []dcbApp{{Selector: ieeeAppSelDSCP, Priority: 3, Protocol: 26}}},
+		{" 48 : 6, 26:3 ", true, []dcbApp{
+			{Selector: ieeeAppSelDSCP, Priority: 3, Protocol: 26},
+			{Selector: ieeeAppSelDSCP, Priority: 6, Protocol: 48},
+		}},
+		{"26", false, nil},
+		{"64:3", false, nil},
+		{"26:8", false, nil},
+		{"foo:3", false, nil},
+		{"26:3,26:4", false, nil},
+	}
+
+	for _, tt := range tests {
+		apps, err := VerifyDSCPArgument(tt.arg)
+		if (err == nil) != tt.success {
+			t.Errorf("DSCP '%s': unexpected error %v", tt.arg, err)
+		}
+		if !reflect.DeepEqual(apps, tt.expected) {
+			t.Errorf("DSCP '%s': expected %v, got %v", tt.arg, tt.expected, apps)
+		}
+	}
+}
+
+func TestAppTrustAttr(t *testing.T) {
+	attrs, err := nl.ParseRouteAttr(appTrustAttr([]uint8{ieeeAppSelDSCP, dcbAppSelPCP}).Serialize())
+	if err != nil {
+		t.Fatalf("cannot parse attributes: %v", err)
+	}
+
+	trust, err := parseAppTrust(attrs)
+	if err != nil {
+		t.Fatalf("cannot parse APP trust: %v", err)
+	}
+	if !reflect.DeepEqual(trust, []uint8{ieeeAppSelDSCP, dcbAppSelPCP}) {
+		t.Errorf("unexpected APP trust %v", trust)
+	}
+
+	attrs, _ = nl.ParseRouteAttr(appTableAttr([]dcbApp{{Selector: ieeeAppSelDSCP, Priority: 3, Protocol: 26}}).Serialize())
+	if _, err := parseAppTrust(attrs); err == nil {
+		t.Errorf("expected an error without an APP trust table")
+	}
+}
+
+func TestDCBDSCP(t *testing.T) {
+	fake := newFakeDCB()
+	fake.install(t)
+
+	fake.trust["foo0"] = []uint8{dcbAppSelPCP}
+
+	apps := []dcbApp{{Selector: ieeeAppSelDSCP, Priority: 3, Protocol: 26}}
+	config := &cmdConfig{pfcBackend: pfcBackendNetlink, dscp: apps}
+	networkConfigs := map[string]*networkConfiguration{
+		"foo0": {},
+		"foo1": {},
+	}
+
+	if err := EnableAllDSCP(config, networkConfigs); err != nil {
+		t.Fatalf("cannot enable DSCP priorities: %v", err)
+	}
+
+	for ifname := range networkConfigs {
+		if !fake.apps[ifname][apps[0]] {
+			t.Errorf("%s: DSCP APP entry not added", ifname)
+		}
+	}
+
+	if !reflect.DeepEqual(fake.trust["foo0"], []uint8{ieeeAppSelDSCP, dcbAppSelPCP}) {
+		t.Errorf("expected DSCP trust, got %v", fake.trust["foo0"])
+	}
+	// interfaces without trust support are left alone
+	if _, ok := fake.trust["foo1"]; ok || networkConfigs["foo1"].appTrustSet {
+		t.Errorf("trust should not be set without support")
+	}
+
+	if err := RevertAllDSCP(config, networkConfigs); err != nil {
+		t.Fatalf("cannot revert DSCP priorities: %v", err)
+	}
+
+	for ifname := range networkConfigs {
+		if len(fake.apps[ifname]) > 0 {
+			t.Errorf("%s: DSCP APP entries not removed", ifname)
+		}
+	}
+
+	if !reflect.DeepEqual(fake.trust["foo0"], []uint8{dcbAppSelPCP}) {
+		t.Errorf("expected original trust, got %v", fake.trust["foo0"])
+	}
+
+	fake.failSet = true
+
+	if err := EnableAllDSCP(config, networkConfigs); err == nil {
+		t.Errorf("expected an error when the driver rejects the entries")
+	}
+}
+
+func TestExecDSCP(t *testing.T) {
+	apps := []dcbApp{{Selector: ieeeAppSelDSCP, Priority: 3, Protocol: 26}}
+
+	lldpBinary = lldpBinarySuccess
+	if err := LookupLLDPTool(); err != nil {
+		t.Fatalf("lldp binary '%s' not found: %v", lldpBinary, err)
+	}
+
+	if err := execDSCP("foo0", apps, false); err != nil {
+		t.Errorf("%s adding failed: %v", lldpBinary, err)
+	}
+	if err := execDSCP("foo0", apps, true); err != nil {
+		t.Errorf("%s removing failed: %v", lldpBinary, err)
+	}
+
+	lldpBinary = lldpBinaryFailure
+	if err := LookupLLDPTool(); err != nil {
+		t.Fatalf("lldp binary '%s' not found: %v", lldpBinary, err)
+	}
+
+	if err := execDSCP("foo0", apps, false); err == nil {
+		t.Errorf("%s adding returned success when it should not have", lldpBinary)
+	}
+}
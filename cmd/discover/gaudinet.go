@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 
 	"k8s.io/klog/v2"
@@ -39,6 +40,7 @@ type GaudiNetEntry struct {
 var (
 	JsonMarshal func(v any) ([]byte, error)                                      = json.Marshal
 	JsonIndent  func(dst *bytes.Buffer, src []byte, prefix, indent string) error = json.Indent
+	WriteFile   func(name string, data []byte, perm os.FileMode) error           = os.WriteFile
 )
 
 func GenerateGaudiNet(networkConfigs map[string]*networkConfiguration) ([]byte, error) {
@@ -55,14 +57,14 @@ func GenerateGaudiNet(networkConfigs map[string]*networkConfiguration) ([]byte,
 			continue
 		}
 
-		net := GaudiNetEntry{
+		entry := GaudiNetEntry{
 			Mac:        nwconfig.link.Attrs().HardwareAddr.String(),
 			IP:         nwconfig.localAddr.String(),
-			Mask:       "255.255.255.252",
+			Mask:       net.IP(networkMask(nwconfig, RouteMaskPointToPoint)).String(),
 			GatewayMac: nwconfig.peerHWAddr.String(),
 		}
 
-		gaudinet.Config = append(gaudinet.Config, net)
+		gaudinet.Config = append(gaudinet.Config, entry)
 	}
 
 	gaudinetJSON, err := JsonMarshal(gaudinet)
@@ -89,5 +91,5 @@ func WriteGaudiNet(filename string, networkConfigs map[string]*networkConfigurat
 		return err
 	}
 
-	return os.WriteFile(filename, gaudinetContents, 0644)
+	return WriteFile(filename, gaudinetContents, 0644)
 }
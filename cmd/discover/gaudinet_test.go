@@ -65,6 +65,30 @@ func TestGenerateGaudiNet(t *testing.T) {
 	}
 }
 
+func TestGenerateGaudiNetIPv6(t *testing.T) {
+	nwconfigs, _ := fakenetworkconfigs()
+
+	localAddr := net.ParseIP("2001:db8:0:1::")
+	nwconfigs["eth1234"].localAddr = &localAddr
+	nwconfigs["eth1234"].localPrefixLen = 127
+
+	expectedoutput := "{\n" +
+		"  \"NIC_NET_CONFIG\": [\n" +
+		"    {\n" +
+		"      \"NIC_MAC\": \"01:02:03:04:05:06\",\n" +
+		"      \"NIC_IP\": \"2001:db8:0:1::\",\n" +
+		"      \"SUBNET_MASK\": \"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe\",\n" +
+		"      \"GATEWAY_MAC\": \"06:05:04:03:02:01\"\n" +
+		"    }\n" +
+		"  ]\n" +
+		"}\n"
+
+	json, err := GenerateGaudiNet(nwconfigs)
+	if string(json) != expectedoutput {
+		t.Errorf("Expected result '%s', returned '%s': %v", expectedoutput, json, err)
+	}
+}
+
 func TestGenerateGaudiNetMissingLocalAddr(t *testing.T) {
 	nwconfigs, _ := fakenetworkconfigs()
 
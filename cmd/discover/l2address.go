@@ -0,0 +1,235 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+)
+
+const (
+	l2AddressStatic    = "static"
+	l2AddressDHCP      = "dhcp"
+	l2AddressLinkLocal = "link-local"
+
+	// discard service the neighbor probe is sent to
+	neighborProbePort     = 9
+	neighborProbeAttempts = 10
+)
+
+// neighbor states with a usable MAC address
+const neighborResolved = netlink.NUD_REACHABLE | netlink.NUD_STALE | netlink.NUD_DELAY |
+	netlink.NUD_PROBE | netlink.NUD_PERMANENT
+
+var (
+	neighborProbeInterval = 300 * time.Millisecond
+
+	probeNeighbor = sendNeighborProbe
+)
+
+// applyL2AddressPlan sets the local addresses and the optional gateways of
+// the interfaces from the address plan. Returns true if any interface got
+// an address.
+func applyL2AddressPlan(plan []networkv1alpha1.InterfaceAddress, networkConfigs map[string]*networkConfiguration) bool {
+	found := false
+
+	for _, entry := range plan {
+		nwconfig, err := planInterface(entry, networkConfigs)
+		if err != nil {
+			klog.Warningf("Skipping address plan entry %s: %v", entry.Address, err)
+			continue
+		}
+		ifname := nwconfig.link.Attrs().Name
+
+		localAddr, localNetwork, err := net.ParseCIDR(entry.Address)
+		if err != nil {
+			klog.Warningf("Interface '%s' address plan: %v", ifname, err)
+			continue
+		}
+
+		if entry.PeerMAC != "" {
+			hwaddr, err := net.ParseMAC(entry.PeerMAC)
+			if err != nil {
+				klog.Warningf("Interface '%s' address plan: %v", ifname, err)
+				continue
+			}
+			nwconfig.peerHWAddr = &hwaddr
+		}
+
+		if gateway := net.ParseIP(entry.Peer); gateway != nil {
+			nwconfig.gateway = &gateway
+		}
+
+		nwconfig.localAddr = &localAddr
+		nwconfig.localPrefixLen, _ = localNetwork.Mask.Size()
+		found = true
+
+		klog.V(3).Infof("Interface '%s' planned address %s", ifname, entry.Address)
+	}
+
+	return found
+}
+
+// linkLocalResults uses the IPv6 link-local addresses of the interfaces.
+// Returns true if any interface has one.
+func linkLocalResults(networkConfigs map[string]*networkConfiguration) bool {
+	found := false
+
+	for ifname, nwconfig := range networkConfigs {
+		addrs, err := networkLink.AddrList(nwconfig.link, netlink.FAMILY_V6)
+		if err != nil {
+			klog.Warningf("Could not get addresses for link '%s': %v", ifname, err)
+			continue
+		}
+
+		for _, addr := range addrs {
+			if !addr.IP.IsLinkLocalUnicast() {
+				continue
+			}
+
+			ip := addr.IP
+			nwconfig.localAddr = &ip
+			nwconfig.localPrefixLen, _ = addr.Mask.Size()
+			found = true
+
+			break
+		}
+
+		if nwconfig.localAddr == nil {
+			klog.Warningf("Interface '%s' has no IPv6 link-local address", ifname)
+		}
+	}
+
+	return found
+}
+
+// l2AddressResults gets the interface addresses from the L2 address
+// source. Returns true if any interface got an address.
+func l2AddressResults(config *cmdConfig, networkConfigs map[string]*networkConfiguration) (bool, error) {
+	switch config.l2AddressSource {
+	case l2AddressStatic:
+		plan, err := readAddressPlan(config.addressPlan, config.nodeName)
+		if err != nil {
+			return false, err
+		}
+
+		return applyL2AddressPlan(plan, networkConfigs), nil
+	case l2AddressDHCP:
+		return dhcpResults(networkConfigs), nil
+	case l2AddressLinkLocal:
+		return linkLocalResults(networkConfigs), nil
+	}
+
+	return false, fmt.Errorf("unknown L2 address source '%s'", config.l2AddressSource)
+}
+
+// sendNeighborProbe sends a packet to the address out of the interface
+// for the kernel to resolve its MAC address.
+func sendNeighborProbe(ifname string, addr net.IP) error {
+	dialer := net.Dialer{
+		Control: func(network, address string, c syscall.RawConn) error {
+			var sockErr error
+
+			if err := c.Control(func(fd uintptr) {
+				sockErr = unix.BindToDevice(int(fd), ifname)
+			}); err != nil {
+				return err
+			}
+
+			return sockErr
+		},
+	}
+
+	conn, err := dialer.Dial("udp", net.JoinHostPort(addr.String(), fmt.Sprint(neighborProbePort)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte{0})
+
+	return err
+}
+
+// resolveGatewayMAC returns the MAC address of the gateway of the
+// interface from the neighbor table.
+func resolveGatewayMAC(ifname string, nwconfig *networkConfiguration) (net.HardwareAddr, error) {
+	gateway := *nwconfig.gateway
+
+	family := netlink.FAMILY_V4
+	if isIPv6(gateway) {
+		family = netlink.FAMILY_V6
+	}
+
+	if err := probeNeighbor(ifname, gateway); err != nil {
+		return nil, fmt.Errorf("cannot reach gateway %s: %v", gateway, err)
+	}
+
+	for range neighborProbeAttempts {
+		neighbors, err := networkLink.NeighList(nwconfig.link.Attrs().Index, family)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, neighbor := range neighbors {
+			if neighbor.IP.Equal(gateway) && neighbor.State&neighborResolved != 0 && len(neighbor.HardwareAddr) > 0 {
+				return neighbor.HardwareAddr, nil
+			}
+		}
+
+		time.Sleep(neighborProbeInterval)
+	}
+
+	return nil, fmt.Errorf("gateway %s not resolved", gateway)
+}
+
+// setGatewayMACs sets the peer MAC addresses for gaudinet.json in L2 mode.
+// The gateway addresses are resolved, and the switch port MAC address
+// from LLDP is used for interfaces without a gateway.
+func setGatewayMACs(config *cmdConfig, networkConfigs map[string]*networkConfiguration) {
+	missing := map[string]*networkConfiguration{}
+
+	for ifname, nwconfig := range networkConfigs {
+		if nwconfig.localAddr == nil || nwconfig.peerHWAddr != nil {
+			continue
+		}
+
+		if nwconfig.gateway != nil {
+			hwaddr, err := resolveGatewayMAC(ifname, nwconfig)
+			if err == nil {
+				nwconfig.peerHWAddr = &hwaddr
+				continue
+			}
+
+			klog.Warningf("Interface '%s' %v, using the switch port MAC address", ifname, err)
+		}
+
+		missing[ifname] = nwconfig
+	}
+
+	if len(missing) > 0 {
+		detectLLDP(config, missing)
+	}
+}
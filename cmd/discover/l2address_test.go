@@ -0,0 +1,179 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/vishvananda/netlink"
+)
+
+const testL2AddressPlan = `
+- interface: eth0
+  address: 192.168.10.11/24
+  peer: 192.168.10.1
+- interface: eth1
+  address: 192.168.11.11/24
+  peerMAC: "02:00:00:00:00:02"
+`
+
+func fakeL2Config(name string, index int, hwaddr string) *networkConfiguration {
+	mac, _ := net.ParseMAC(hwaddr)
+
+	return &networkConfiguration{
+		link: &fakeLink{fakeAttrs: netlink.LinkAttrs{Name: name, Index: index, HardwareAddr: mac}},
+	}
+}
+
+func TestL2AddressPlan(t *testing.T) {
+	gatewayMAC, _ := net.ParseMAC("02:00:00:00:00:01")
+
+	orig, origProbe := networkLink, probeNeighbor
+	t.Cleanup(func() { networkLink, probeNeighbor = orig, origProbe })
+
+	probed := map[string]string{}
+	probeNeighbor = func(ifname string, addr net.IP) error {
+		probed[ifname] = addr.String()
+		return nil
+	}
+	networkLink.NeighList = func(linkIndex, family int) ([]netlink.Neigh, error) {
+		if family != netlink.FAMILY_V4 {
+			t.Errorf("unexpected neighbor family %d", family)
+		}
+		return []netlink.Neigh{
+			{LinkIndex: linkIndex, IP: net.ParseIP("192.168.10.2"), HardwareAddr: gatewayMAC, State: netlink.NUD_REACHABLE},
+			{LinkIndex: linkIndex, IP: net.ParseIP("192.168.10.1"), HardwareAddr: gatewayMAC, State: netlink.NUD_STALE},
+		}, nil
+	}
+
+	config := &cmdConfig{
+		mode:            L2,
+		l2AddressSource: l2AddressStatic,
+		addressPlan:     writeTestAddressPlan(t, testL2AddressPlan),
+		nodeName:        testNodeName,
+	}
+	networkConfigs := map[string]*networkConfiguration{
+		"eth0": fakeL2Config("eth0", 1, "00:00:00:00:00:10"),
+		"eth1": fakeL2Config("eth1", 2, "00:00:00:00:00:11"),
+	}
+
+	found, err := l2AddressResults(config, networkConfigs)
+	if err != nil || !found {
+		t.Fatalf("no addresses from the address plan: %v", err)
+	}
+
+	for ifname, nwconfig := range networkConfigs {
+		if nwconfig.localAddr == nil || nwconfig.localPrefixLen != 24 {
+			t.Errorf("%s: unexpected address %v/%d", ifname, nwconfig.localAddr, nwconfig.localPrefixLen)
+		}
+		if nwconfig.lldpPeer != nil {
+			t.Errorf("%s: unexpected routed network peer %v", ifname, nwconfig.lldpPeer)
+		}
+	}
+
+	setGatewayMACs(config, networkConfigs)
+
+	if probed["eth0"] != "192.168.10.1" || len(probed) != 1 {
+		t.Errorf("unexpected gateway probes %v", probed)
+	}
+	if hwaddr := networkConfigs["eth0"].peerHWAddr; hwaddr == nil || hwaddr.String() != gatewayMAC.String() {
+		t.Errorf("unexpected gateway MAC address %v", hwaddr)
+	}
+	if hwaddr := networkConfigs["eth1"].peerHWAddr; hwaddr == nil || hwaddr.String() != "02:00:00:00:00:02" {
+		t.Errorf("unexpected planned gateway MAC address %v", hwaddr)
+	}
+
+	JsonMarshal = json.Marshal
+
+	contents, err := GenerateGaudiNet(networkConfigs)
+	if err != nil {
+		t.Fatalf("cannot generate gaudinet: %v", err)
+	}
+
+	gaudinet := GaudiNet{}
+	if err := json.Unmarshal(contents, &gaudinet); err != nil {
+		t.Fatalf("cannot parse gaudinet: %v", err)
+	}
+	if len(gaudinet.Config) != 2 {
+		t.Fatalf("unexpected gaudinet entries %+v", gaudinet.Config)
+	}
+	for _, entry := range gaudinet.Config {
+		if entry.Mask != "255.255.255.0" {
+			t.Errorf("unexpected gaudinet subnet mask %+v", entry)
+		}
+		if entry.Mac == "00:00:00:00:00:10" && (entry.IP != "192.168.10.11" || entry.GatewayMac != gatewayMAC.String()) {
+			t.Errorf("unexpected gaudinet entry %+v", entry)
+		}
+	}
+}
+
+func TestL2LinkLocal(t *testing.T) {
+	orig := networkLink
+	t.Cleanup(func() { networkLink = orig })
+
+	networkLink.AddrList = func(link netlink.Link, family int) ([]netlink.Addr, error) {
+		if link.Attrs().Name != "eth0" {
+			return nil, nil
+		}
+		_, global, _ := net.ParseCIDR("2001:db8::1/64")
+		_, linkLocal, _ := net.ParseCIDR("fe80::10/64")
+		global.IP = net.ParseIP("2001:db8::1")
+		linkLocal.IP = net.ParseIP("fe80::10")
+		return []netlink.Addr{{IPNet: global}, {IPNet: linkLocal}}, nil
+	}
+
+	config := &cmdConfig{mode: L2, l2AddressSource: l2AddressLinkLocal}
+	networkConfigs := map[string]*networkConfiguration{
+		"eth0": fakeL2Config("eth0", 1, "00:00:00:00:00:10"),
+		"eth1": fakeL2Config("eth1", 2, "00:00:00:00:00:11"),
+	}
+
+	found, err := l2AddressResults(config, networkConfigs)
+	if err != nil || !found {
+		t.Fatalf("no link-local addresses: %v", err)
+	}
+
+	if addr := networkConfigs["eth0"].localAddr; addr == nil || addr.String() != "fe80::10" || networkConfigs["eth0"].localPrefixLen != 64 {
+		t.Errorf("unexpected link-local address %v/%d", addr, networkConfigs["eth0"].localPrefixLen)
+	}
+	if addr := networkConfigs["eth1"].localAddr; addr != nil {
+		t.Errorf("unexpected address %v without a link-local address", addr)
+	}
+}
+
+func TestResolveGatewayMACFailure(t *testing.T) {
+	orig, origProbe, origInterval := networkLink, probeNeighbor, neighborProbeInterval
+	t.Cleanup(func() { networkLink, probeNeighbor, neighborProbeInterval = orig, origProbe, origInterval })
+
+	neighborProbeInterval = 0
+	probeNeighbor = func(ifname string, addr net.IP) error { return nil }
+	networkLink.NeighList = func(linkIndex, family int) ([]netlink.Neigh, error) {
+		return []netlink.Neigh{
+			{LinkIndex: linkIndex, IP: net.ParseIP("2001:db8::1"), State: netlink.NUD_FAILED},
+		}, nil
+	}
+
+	gateway := net.ParseIP("2001:db8::1")
+	nwconfig := fakeL2Config("eth0", 1, "00:00:00:00:00:10")
+	nwconfig.gateway = &gateway
+
+	if hwaddr, err := resolveGatewayMAC("eth0", nwconfig); err == nil {
+		t.Errorf("unexpected gateway MAC address %v for an unresolved neighbor", hwaddr)
+	}
+}
@@ -0,0 +1,269 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/vishvananda/netlink"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+)
+
+const (
+	eventReasonLinkDown     = "LinkDown"
+	eventReasonLinkRestored = "LinkRestored"
+	eventReasonLeaseExpired = "LeaseExpired"
+	linkWatchBufferSize     = 64
+)
+
+// linkWatcher follows the link and address updates of the interfaces
+// after they are configured. The configuration is restored when a link
+// comes back after a port flap or a driver reset, and the readiness labels
+// are updated while any of the interfaces is not ready.
+type linkWatcher struct {
+	links   chan netlink.LinkUpdate
+	addrs   chan netlink.AddrUpdate
+	done    chan struct{}
+	unready map[string]bool
+	node    *nodeReadiness
+}
+
+// startLinkWatcher subscribes to link and address updates. The readiness
+// is published on the given Node, if any. Returns nil if link updates
+// cannot be subscribed to.
+func startLinkWatcher(node *nodeReadiness) *linkWatcher {
+	w := &linkWatcher{
+		links:   make(chan netlink.LinkUpdate, linkWatchBufferSize),
+		addrs:   make(chan netlink.AddrUpdate, linkWatchBufferSize),
+		done:    make(chan struct{}),
+		unready: map[string]bool{},
+		node:    node,
+	}
+
+	if err := networkLink.LinkSubscribe(w.links, w.done); err != nil {
+		klog.Warningf("Cannot subscribe to link updates: %v", err)
+		close(w.done)
+		return nil
+	}
+
+	if err := networkLink.AddrSubscribe(w.addrs, w.done); err != nil {
+		klog.Warningf("Cannot subscribe to address updates: %v", err)
+		w.addrs = nil
+	}
+
+	klog.Infof("Watching link updates")
+
+	return w
+}
+
+// linkUpdates returns the channel of link updates. A nil watcher returns
+// a nil channel which blocks forever.
+func (w *linkWatcher) linkUpdates() <-chan netlink.LinkUpdate {
+	if w == nil {
+		return nil
+	}
+
+	return w.links
+}
+
+// addrUpdates returns the channel of address updates. A nil watcher
+// returns a nil channel which blocks forever.
+func (w *linkWatcher) addrUpdates() <-chan netlink.AddrUpdate {
+	if w == nil {
+		return nil
+	}
+
+	return w.addrs
+}
+
+// stop ends the link and address update subscriptions.
+func (w *linkWatcher) stop() {
+	if w == nil {
+		return
+	}
+
+	close(w.done)
+}
+
+// linkOperational returns true if the link is up and has carrier. Drivers
+// not reporting the operational state are considered up.
+func linkOperational(link netlink.Link) bool {
+	attrs := link.Attrs()
+	if attrs.Flags&net.FlagUp == 0 {
+		return false
+	}
+
+	return attrs.OperState == netlink.OperUp || attrs.OperState == netlink.OperUnknown
+}
+
+// restoreInterface recreates the VLAN sub-interface, if any, and sets the
+// MTU and, with an address, the address and routes of the interface again.
+func restoreInterface(config *cmdConfig, ifname string, nwconfig *networkConfiguration) error {
+	single := map[string]*networkConfiguration{ifname: nwconfig}
+
+	if err := restoreVLAN(ifname, nwconfig); err != nil {
+		return fmt.Errorf("could not restore VLAN of interface '%s': %v", ifname, err)
+	}
+
+	interfacesSetMTU(single, config.mtu)
+
+	if nwconfig.localAddr == nil {
+		return nil
+	}
+
+	if numConfigured, _ := configureInterfaces(single); numConfigured == 0 {
+		return fmt.Errorf("could not restore address %s of interface '%s'", localCIDR(nwconfig), ifname)
+	}
+
+	return nil
+}
+
+func (w *linkWatcher) unreadyError() error {
+	ifnames := []string{}
+	for ifname := range w.unready {
+		ifnames = append(ifnames, ifname)
+	}
+	sort.Strings(ifnames)
+
+	return fmt.Errorf("Interfaces not ready: %s", strings.Join(ifnames, ", "))
+}
+
+// updateReadiness updates the readiness labels and reports the node
+// state depending on whether there are enough working interfaces.
+func (w *linkWatcher) updateReadiness(config *cmdConfig, reporter *nodeStateReporter, networkConfigs map[string]*networkConfiguration) {
+	ready, err := publishReadiness(config, w.node, networkConfigs, w.unready)
+	if err != nil {
+		klog.Warning(err.Error())
+	}
+
+	switch {
+	case !ready:
+		reporter.report(config, networkConfigs, networkv1alpha1.NodeStateFailed, w.unreadyError())
+	case len(w.unready) > 0:
+		reporter.report(config, networkConfigs, networkv1alpha1.NodeStateConfigured, w.unreadyError())
+	default:
+		reporter.report(config, networkConfigs, networkv1alpha1.NodeStateConfigured, nil)
+	}
+}
+
+// restore restores the interface configuration and marks the interface
+// ready again on success.
+func (w *linkWatcher) restore(config *cmdConfig, reporter *nodeStateReporter, ifname string, nwconfig *networkConfiguration) {
+	if err := restoreInterface(config, ifname, nwconfig); err != nil {
+		klog.Warningf("Restoring failed: %v", err)
+		reporter.event(config, corev1.EventTypeWarning, eventReasonReconfigureFailed, err.Error())
+		w.unready[ifname] = true
+		return
+	}
+
+	delete(w.unready, ifname)
+
+	klog.Infof("Restored the configuration of interface '%s'", ifname)
+	reporter.event(config, corev1.EventTypeNormal, eventReasonLinkRestored,
+		fmt.Sprintf("Restored the configuration of interface '%s'", ifname))
+}
+
+// handleLinkUpdate withdraws the readiness when an interface goes down and
+// restores its configuration when it comes back.
+func (w *linkWatcher) handleLinkUpdate(config *cmdConfig, reporter *nodeStateReporter,
+	networkConfigs map[string]*networkConfiguration, update netlink.LinkUpdate) {
+	ifname := update.Link.Attrs().Name
+
+	nwconfig, exists := networkConfigs[ifname]
+	if !exists {
+		return
+	}
+
+	nwconfig.link = update.Link
+
+	operational := linkOperational(update.Link)
+
+	switch {
+	case !operational && !w.unready[ifname]:
+		w.unready[ifname] = true
+
+		klog.Warningf("Link '%s' is down", ifname)
+		reporter.event(config, corev1.EventTypeWarning, eventReasonLinkDown, fmt.Sprintf("Link '%s' is down", ifname))
+
+	case operational && w.unready[ifname]:
+		klog.Infof("Link '%s' is up again", ifname)
+		w.restore(config, reporter, ifname, nwconfig)
+
+	default:
+		return
+	}
+
+	w.updateReadiness(config, reporter, networkConfigs)
+}
+
+// handleAddrUpdate restores the configured address of an interface that
+// is up when the address is removed.
+func (w *linkWatcher) handleAddrUpdate(config *cmdConfig, reporter *nodeStateReporter,
+	networkConfigs map[string]*networkConfiguration, update netlink.AddrUpdate) {
+	if update.NewAddr {
+		return
+	}
+
+	for ifname, nwconfig := range networkConfigs {
+		if l3Link(nwconfig).Attrs().Index != update.LinkIndex || nwconfig.localAddr == nil ||
+			!nwconfig.localAddr.Equal(update.LinkAddress.IP) {
+			continue
+		}
+
+		// interfaces that are down are restored when they come back
+		if w.unready[ifname] && !linkOperational(nwconfig.link) {
+			return
+		}
+
+		klog.Warningf("Address %s removed from interface '%s'", update.LinkAddress.String(), ifname)
+		w.restore(config, reporter, ifname, nwconfig)
+		w.updateReadiness(config, reporter, networkConfigs)
+
+		return
+	}
+}
+
+// handleLeaseExpiry removes the address of an interface whose DHCP lease
+// expired and withdraws the readiness of the interface.
+func (w *linkWatcher) handleLeaseExpiry(config *cmdConfig, reporter *nodeStateReporter, node *nodeReadiness,
+	networkConfigs map[string]*networkConfiguration, ifname string) {
+	nwconfig, exists := networkConfigs[ifname]
+	if !exists || nwconfig.localAddr == nil {
+		return
+	}
+
+	message := fmt.Sprintf("DHCP lease of address %s of interface '%s' expired", nwconfig.localAddr, ifname)
+	klog.Warning(message)
+	reporter.event(config, corev1.EventTypeWarning, eventReasonLeaseExpired, message)
+
+	if err := removeDHCPAddress(ifname, nwconfig); err != nil {
+		klog.Warning(err.Error())
+	}
+
+	// without link updates no interface is known to be down
+	if w == nil {
+		w = &linkWatcher{unready: map[string]bool{}, node: node}
+	}
+
+	w.updateReadiness(config, reporter, networkConfigs)
+}
@@ -0,0 +1,203 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vishvananda/netlink"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+)
+
+func fakeLinkUpdate(name string, flags net.Flags, state netlink.LinkOperState) netlink.LinkUpdate {
+	return netlink.LinkUpdate{
+		Link: &fakeLink{
+			fakeAttrs: netlink.LinkAttrs{
+				Name:      name,
+				Flags:     flags,
+				OperState: state,
+			},
+		},
+	}
+}
+
+func TestLinkOperational(t *testing.T) {
+	for _, tc := range []struct {
+		flags       net.Flags
+		state       netlink.LinkOperState
+		operational bool
+	}{
+		{net.FlagUp, netlink.OperUp, true},
+		{net.FlagUp, netlink.OperUnknown, true},
+		{net.FlagUp, netlink.OperDown, false},
+		{net.FlagUp, netlink.OperLowerLayerDown, false},
+		{0, netlink.OperUp, false},
+	} {
+		if linkOperational(fakeLinkUpdate("eth_a", tc.flags, tc.state).Link) != tc.operational {
+			t.Errorf("link with flags %v and state %s, expected operational %v", tc.flags, tc.state, tc.operational)
+		}
+	}
+}
+
+func TestStartLinkWatcher(t *testing.T) {
+	defer func() {
+		networkLink.LinkSubscribe = netlink.LinkSubscribe
+		networkLink.AddrSubscribe = netlink.AddrSubscribe
+	}()
+
+	networkLink.LinkSubscribe = func(ch chan<- netlink.LinkUpdate, done <-chan struct{}) error {
+		return fmt.Errorf("cannot subscribe")
+	}
+
+	if w := startLinkWatcher(nil); w != nil {
+		t.Error("watcher started without link updates")
+	}
+
+	networkLink.LinkSubscribe = func(ch chan<- netlink.LinkUpdate, done <-chan struct{}) error {
+		return nil
+	}
+	networkLink.AddrSubscribe = func(ch chan<- netlink.AddrUpdate, done <-chan struct{}) error {
+		return fmt.Errorf("cannot subscribe")
+	}
+
+	w := startLinkWatcher(nil)
+	if w == nil || w.linkUpdates() == nil || w.addrUpdates() != nil {
+		t.Errorf("unexpected watcher %+v", w)
+	}
+	w.stop()
+
+	// nil watcher does nothing
+	var nilWatcher *linkWatcher
+	if nilWatcher.linkUpdates() != nil || nilWatcher.addrUpdates() != nil {
+		t.Error("nil watcher has updates")
+	}
+	nilWatcher.stop()
+}
+
+func TestLinkWatcherRecovery(t *testing.T) {
+	defer func(dir, file string) {
+		nfdFeatureDir, nfdLabelFile = dir, file
+	}(nfdFeatureDir, nfdLabelFile)
+
+	nfdFeatureDir = t.TempDir()
+	nfdLabelFile = filepath.Join(nfdFeatureDir, "scale-out-readiness.txt")
+
+	networkLink.LinkByName = fakeLinkByName
+	networkLink.AddrList = fakeLinkAddrList
+	networkLink.AddrAdd = fakeLinkAddrAdd
+	networkLink.RouteAppend = fakeRouteAppend
+
+	r := fakeNodeStateReporter(t, testPolicy())
+	// eth_b has no address
+	config := &cmdConfig{ctx: context.Background(), mode: L3, minReadyPorts: 2}
+
+	nwconfigs := getFakeNetworkDataConfigs()
+	_ = lldpResults(nwconfigs)
+	nwconfigs["eth_a"].configured = true
+	nwconfigs["eth_c"].configured = true
+
+	if ready, err := publishReadiness(config, nil, nwconfigs, nil); !ready || err != nil {
+		t.Fatalf("cannot write ready labels: %v", err)
+	}
+
+	nodeReady := func() bool {
+		data, err := os.ReadFile(nfdLabelFile)
+		if err != nil {
+			t.Fatalf("cannot read labels: %v", err)
+		}
+		return strings.Contains(string(data), nfdScaleOutLabel+"=true")
+	}
+
+	w := &linkWatcher{unready: map[string]bool{}}
+
+	nodeState := func() *networkv1alpha1.NetworkNodeState {
+		nodeState := &networkv1alpha1.NetworkNodeState{}
+		if err := r.client.Get(context.Background(), client.ObjectKey{Name: testNodeName}, nodeState); err != nil {
+			t.Fatalf("cannot get node state: %v", err)
+		}
+		return nodeState
+	}
+
+	// updates of other links and without state change are ignored
+	w.handleLinkUpdate(config, r, nwconfigs, fakeLinkUpdate("eth_other", net.FlagUp, netlink.OperDown))
+	w.handleLinkUpdate(config, r, nwconfigs, fakeLinkUpdate("eth_a", net.FlagUp, netlink.OperUp))
+	if len(w.unready) != 0 {
+		t.Errorf("unexpected unready interfaces %v", w.unready)
+	}
+
+	w.handleLinkUpdate(config, r, nwconfigs, fakeLinkUpdate("eth_a", net.FlagUp, netlink.OperDown))
+	w.handleLinkUpdate(config, r, nwconfigs, fakeLinkUpdate("eth_c", 0, netlink.OperDown))
+
+	if nodeReady() {
+		t.Error("readiness label not withdrawn")
+	}
+	if state := nodeState().Status; state.State != networkv1alpha1.NodeStateFailed ||
+		state.Message != "Interfaces not ready: eth_a, eth_c" {
+		t.Errorf("unexpected node state %+v", state)
+	}
+
+	fakeAddrsAdded = nil
+
+	w.handleLinkUpdate(config, r, nwconfigs, fakeLinkUpdate("eth_a", net.FlagUp, netlink.OperUp))
+
+	if len(fakeAddrsAdded) != 1 || fakeAddrsAdded[0].IPNet.String() != "10.210.8.121/30" {
+		t.Errorf("address not restored: %v", fakeAddrsAdded)
+	}
+	if nodeReady() {
+		t.Error("readiness label written with too few working ports")
+	}
+
+	w.handleLinkUpdate(config, r, nwconfigs, fakeLinkUpdate("eth_c", net.FlagUp, netlink.OperUp))
+
+	if !nodeReady() {
+		t.Error("readiness label not written")
+	}
+	if state := nodeState().Status; state.State != networkv1alpha1.NodeStateConfigured {
+		t.Errorf("unexpected node state %+v", state)
+	}
+
+	// removed address is restored
+	fakeAddrsAdded = nil
+
+	w.handleAddrUpdate(config, r, nwconfigs, netlink.AddrUpdate{
+		LinkAddress: net.IPNet{IP: net.IPv4(10, 210, 8, 121), Mask: net.CIDRMask(30, 32)},
+	})
+	if len(fakeAddrsAdded) != 1 || fakeAddrsAdded[0].IPNet.String() != "10.210.8.121/30" {
+		t.Errorf("address not restored: %v", fakeAddrsAdded)
+	}
+
+	// failing restore keeps the interface not ready
+	networkLink.AddrAdd = fakeLinkAddrAddErr
+
+	w.handleAddrUpdate(config, r, nwconfigs, netlink.AddrUpdate{
+		LinkAddress: net.IPNet{IP: net.IPv4(10, 210, 8, 121), Mask: net.CIDRMask(30, 32)},
+	})
+	if !w.unready["eth_a"] {
+		t.Error("interface ready after failing to restore it")
+	}
+	if nodeReady() {
+		t.Error("readiness label not withdrawn")
+	}
+}
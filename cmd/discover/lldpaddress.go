@@ -0,0 +1,152 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/intel/network-operator/pkg/lldp"
+)
+
+const (
+	// "<word> <CIDR>" in the Port Description, e.g. "no-alert 10.200.10.2/30"
+	addressFormatNoAlert = "no-alert"
+	// "ip=<CIDR>" in the Port Description or System Description
+	addressFormatIPEquals = "ip-equals"
+	// first CIDR in the System Description
+	addressFormatSysDescription = "sys-description"
+	// CIDR as text in an organizationally specific TLV
+	addressFormatOrgTLV = "org-tlv"
+	// first capture group of a regular expression matching the Port
+	// Description or System Description
+	addressFormatRegex = "regex"
+)
+
+// addressParser returns the peer address and point-to-point network
+// advertised by the switch in the LLDP information.
+type addressParser func(info lldp.DiscoveryResult) (net.IP, *net.IPNet, error)
+
+// lldpAddressParser is the parser used for all interfaces.
+var lldpAddressParser addressParser = parseNoAlert
+
+var (
+	ipEqualsRegex = regexp.MustCompile(`(?:^|\s)ip=(\S+)`)
+	cidrRegex     = regexp.MustCompile(`[0-9A-Fa-f:.]+/[0-9]{1,3}`)
+)
+
+func parseNoAlert(info lldp.DiscoveryResult) (net.IP, *net.IPNet, error) {
+	return parseIPFromString(info.PortDescription)
+}
+
+func parseIPEquals(info lldp.DiscoveryResult) (net.IP, *net.IPNet, error) {
+	for _, field := range []string{info.PortDescription, info.SysDescription} {
+		if match := ipEqualsRegex.FindStringSubmatch(field); match != nil {
+			return net.ParseCIDR(match[1])
+		}
+	}
+
+	return nil, nil, fmt.Errorf("no ip=<CIDR> in port or system description")
+}
+
+func parseSysDescription(info lldp.DiscoveryResult) (net.IP, *net.IPNet, error) {
+	for _, candidate := range cidrRegex.FindAllString(info.SysDescription, -1) {
+		if ip, ipnet, err := net.ParseCIDR(candidate); err == nil {
+			return ip, ipnet, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("no CIDR in system description '%s'", info.SysDescription)
+}
+
+// parseOrgTLVArgument parses an OUI and subtype given as "aabbcc:N".
+func parseOrgTLVArgument(orgTLV string) (uint32, uint8, error) {
+	parts := strings.Split(orgTLV, ":")
+	if len(parts) != 2 || len(parts[0]) != 6 {
+		return 0, 0, fmt.Errorf("organizationally specific TLV '%s' is not in format 'aabbcc:N'", orgTLV)
+	}
+
+	oui, err := strconv.ParseUint(parts[0], 16, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid OUI '%s': %v", parts[0], err)
+	}
+
+	subtype, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid subtype '%s': %v", parts[1], err)
+	}
+
+	return uint32(oui), uint8(subtype), nil
+}
+
+func orgTLVParser(oui uint32, subtype uint8) addressParser {
+	return func(info lldp.DiscoveryResult) (net.IP, *net.IPNet, error) {
+		for _, tlv := range info.OrgTLVs {
+			if tlv.OUI == oui && tlv.SubType == subtype {
+				return net.ParseCIDR(strings.TrimSpace(string(tlv.Info)))
+			}
+		}
+
+		return nil, nil, fmt.Errorf("no organizationally specific TLV %06x:%d", oui, subtype)
+	}
+}
+
+func regexParser(re *regexp.Regexp) addressParser {
+	return func(info lldp.DiscoveryResult) (net.IP, *net.IPNet, error) {
+		for _, field := range []string{info.PortDescription, info.SysDescription} {
+			if match := re.FindStringSubmatch(field); match != nil {
+				return net.ParseCIDR(match[1])
+			}
+		}
+
+		return nil, nil, fmt.Errorf("regular expression '%s' does not match port or system description", re.String())
+	}
+}
+
+// newAddressParser returns the parser for the given address format. The
+// regex and org-tlv formats require the regular expression and the
+// organizationally specific TLV, respectively.
+func newAddressParser(format, expr, orgTLV string) (addressParser, error) {
+	switch format {
+	case "", addressFormatNoAlert:
+		return parseNoAlert, nil
+	case addressFormatIPEquals:
+		return parseIPEquals, nil
+	case addressFormatSysDescription:
+		return parseSysDescription, nil
+	case addressFormatOrgTLV:
+		oui, subtype, err := parseOrgTLVArgument(orgTLV)
+		if err != nil {
+			return nil, err
+		}
+		return orgTLVParser(oui, subtype), nil
+	case addressFormatRegex:
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression '%s': %v", expr, err)
+		}
+		if re.NumSubexp() != 1 {
+			return nil, fmt.Errorf("regular expression '%s' must have exactly one capture group", expr)
+		}
+		return regexParser(re), nil
+	}
+
+	return nil, fmt.Errorf("unknown LLDP address format '%s'", format)
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"testing"
+
+	"github.com/intel/network-operator/pkg/lldp"
+)
+
+func TestAddressParsers(t *testing.T) {
+	orgTLV := lldp.OrgSpecificTLV{OUI: 0x0012bb, SubType: 7, Info: []byte("10.210.8.130/30\n")}
+
+	tests := []struct {
+		name     string
+		format   string
+		regex    string
+		orgTLV   string
+		info     lldp.DiscoveryResult
+		expected string
+	}{
+		{"no-alert", "", "", "", lldp.DiscoveryResult{PortDescription: "no-alert 10.210.8.122/30"}, "10.210.8.122"},
+		{"no-alert missing", addressFormatNoAlert, "", "", lldp.DiscoveryResult{PortDescription: "10.210.8.122/30"}, ""},
+		{"ip= port description", addressFormatIPEquals, "", "", lldp.DiscoveryResult{PortDescription: "Eth1/1 ip=10.210.8.126/30 rail=2"}, "10.210.8.126"},
+		{"ip= system description", addressFormatIPEquals, "", "", lldp.DiscoveryResult{PortDescription: "Eth1/1", SysDescription: "ip=2001:db8::1/127"}, "2001:db8::1"},
+		{"ip= missing", addressFormatIPEquals, "", "", lldp.DiscoveryResult{PortDescription: "noip=10.210.8.126/30"}, ""},
+		{"system description", addressFormatSysDescription, "", "", lldp.DiscoveryResult{SysDescription: "Switch OS 4.2, port 10.210.8.126/30"}, "10.210.8.126"},
+		{"system description missing", addressFormatSysDescription, "", "", lldp.DiscoveryResult{PortDescription: "no-alert 10.210.8.122/30"}, ""},
+		{"org tlv", addressFormatOrgTLV, "", "0012bb:7", lldp.DiscoveryResult{OrgTLVs: []lldp.OrgSpecificTLV{orgTLV}}, "10.210.8.130"},
+		{"org tlv other subtype", addressFormatOrgTLV, "", "0012bb:8", lldp.DiscoveryResult{OrgTLVs: []lldp.OrgSpecificTLV{orgTLV}}, ""},
+		{"regex", addressFormatRegex, `addr:(\S+)`, "", lldp.DiscoveryResult{PortDescription: "rail 3 addr:10.210.8.134/30"}, "10.210.8.134"},
+		{"regex no match", addressFormatRegex, `addr:(\S+)`, "", lldp.DiscoveryResult{PortDescription: "rail 3"}, ""},
+	}
+
+	for _, tc := range tests {
+		parser, err := newAddressParser(tc.format, tc.regex, tc.orgTLV)
+		if err != nil {
+			t.Errorf("%s: cannot create parser: %v", tc.name, err)
+			continue
+		}
+
+		ip, _, err := parser(tc.info)
+		if tc.expected == "" {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %s", tc.name, ip)
+			}
+			continue
+		}
+
+		if err != nil || ip.String() != tc.expected {
+			t.Errorf("%s: expected %s, got %s: %v", tc.name, tc.expected, ip, err)
+		}
+	}
+}
+
+func TestNewAddressParserErrors(t *testing.T) {
+	tests := []struct {
+		format string
+		regex  string
+		orgTLV string
+	}{
+		{"foo", "", ""},
+		{addressFormatRegex, "", ""},
+		{addressFormatRegex, "(", ""},
+		{addressFormatRegex, "(a)(b)", ""},
+		{addressFormatOrgTLV, "", ""},
+		{addressFormatOrgTLV, "", "0012bb"},
+		{addressFormatOrgTLV, "", "0012bx:1"},
+		{addressFormatOrgTLV, "", "0012bb:256"},
+	}
+
+	for _, tc := range tests {
+		if _, err := newAddressParser(tc.format, tc.regex, tc.orgTLV); err == nil {
+			t.Errorf("format '%s' regex '%s' org TLV '%s' did not fail", tc.format, tc.regex, tc.orgTLV)
+		}
+	}
+}
+
+func TestLLDPResultsAddressFormat(t *testing.T) {
+	defer func() { lldpAddressParser = parseNoAlert }()
+
+	nwconfigs := getFakeNetworkDataConfigs()
+	for _, nwconfig := range nwconfigs {
+		nwconfig.sysDescription = "ip=" + nwconfig.portDescription
+		nwconfig.portDescription = ""
+	}
+
+	if lldpResults(nwconfigs) {
+		t.Error("peers found with no-alert format")
+	}
+
+	var err error
+	lldpAddressParser, err = newAddressParser(addressFormatSysDescription, "", "")
+	if err != nil {
+		t.Fatalf("cannot create parser: %v", err)
+	}
+
+	if !lldpResults(nwconfigs) {
+		t.Error("no peers found with sys-description format")
+	}
+}
@@ -0,0 +1,277 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sync"
+
+	"github.com/vishvananda/netlink"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+	"github.com/intel/network-operator/pkg/lldp"
+)
+
+const (
+	eventReasonTopologyChanged     = "TopologyChanged"
+	eventReasonReconfigureFailed   = "ReconfigurationFailed"
+	lldpMonitorResultsPerInterface = 4
+)
+
+// lldpMonitor keeps listening for LLDP on the interfaces after they are
+// configured to follow topology changes such as moved cables or
+// reconfigured switch ports.
+type lldpMonitor struct {
+	ctx       context.Context
+	results   chan lldp.DiscoveryResult
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	mutex     sync.Mutex
+	listening map[string]bool
+}
+
+// startLLDPMonitor starts listening for LLDP on the interfaces that are up.
+// The other interfaces are monitored when their link comes up. Returns nil
+// if the addresses do not come from LLDP.
+func startLLDPMonitor(config *cmdConfig, networkConfigs map[string]*networkConfiguration) *lldpMonitor {
+	if config.mode != L3 || config.addressPlan != "" || config.addressPool != "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(config.ctx)
+	m := &lldpMonitor{
+		ctx:       ctx,
+		results:   make(chan lldp.DiscoveryResult, lldpMonitorResultsPerInterface*len(networkConfigs)),
+		cancel:    cancel,
+		listening: make(map[string]bool),
+	}
+
+	for ifname, nwconfig := range networkConfigs {
+		if !m.listen(ifname, nwconfig) {
+			klog.Infof("Link '%s' is down, monitoring LLDP when it comes up", ifname)
+		}
+	}
+
+	return m
+}
+
+// listen starts listening for LLDP on the interface unless its link is
+// down or it is already monitored. Returns false if the link is down.
+func (m *lldpMonitor) listen(ifname string, nwconfig *networkConfiguration) bool {
+	if nwconfig.link.Attrs().Flags&net.FlagUp == 0 {
+		return false
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.listening[ifname] {
+		return true
+	}
+	m.listening[ifname] = true
+
+	client := lldp.NewClient(m.ctx, ifname, *nwconfig.localHwAddr)
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		if err := client.Listen(m.results, lldpAddressFilter); err != nil {
+			klog.Warningf("Cannot monitor LLDP on '%s': %v", ifname, err)
+		}
+
+		// Let the listener be restarted when the link comes up again
+		m.mutex.Lock()
+		delete(m.listening, ifname)
+		m.mutex.Unlock()
+	}()
+
+	klog.Infof("Started LLDP monitoring for '%s'", ifname)
+
+	return true
+}
+
+// handleLinkUpdate starts monitoring an interface whose link comes up.
+func (m *lldpMonitor) handleLinkUpdate(networkConfigs map[string]*networkConfiguration, update netlink.LinkUpdate) {
+	if m == nil {
+		return
+	}
+
+	ifname := update.Link.Attrs().Name
+	if nwconfig, exists := networkConfigs[ifname]; exists {
+		m.listen(ifname, nwconfig)
+	}
+}
+
+// updates returns the channel of received LLDP information. A nil
+// monitor returns a nil channel which blocks forever.
+func (m *lldpMonitor) updates() <-chan lldp.DiscoveryResult {
+	if m == nil {
+		return nil
+	}
+
+	return m.results
+}
+
+// stop stops listening for LLDP.
+func (m *lldpMonitor) stop() {
+	if m == nil {
+		return
+	}
+
+	m.cancel()
+	m.wg.Wait()
+}
+
+// lldpInfoChanged returns true if the received LLDP information that the
+// address selection depends on differs from the information the interface
+// was configured with. The System Description changes e.g. with switch
+// firmware upgrades, so it only counts when the address parsed from it
+// changes.
+func lldpInfoChanged(nwconfig *networkConfiguration, result lldp.DiscoveryResult) bool {
+	if nwconfig.peerHWAddr == nil || !bytes.Equal(*nwconfig.peerHWAddr, result.PeerMAC) {
+		return true
+	}
+
+	if nwconfig.portDescription != result.PortDescription ||
+		!reflect.DeepEqual(nwconfig.orgTLVs, result.OrgTLVs) {
+		return true
+	}
+
+	oldAddr, oldNetwork, oldErr := lldpAddressParser(lldpInfo(nwconfig))
+	newAddr, newNetwork, newErr := lldpAddressParser(result)
+	if oldErr != nil || newErr != nil {
+		return (oldErr == nil) != (newErr == nil)
+	}
+
+	return !oldAddr.Equal(newAddr) || oldNetwork.String() != newNetwork.String()
+}
+
+// switchInfoChanged returns true if the interface is connected to another
+// switch or switch port. This alone does not affect the configuration.
+func switchInfoChanged(nwconfig *networkConfiguration, result lldp.DiscoveryResult) bool {
+	return nwconfig.sysName != result.SysName || nwconfig.portID != result.PortID
+}
+
+func peerMACString(nwconfig *networkConfiguration) string {
+	if nwconfig.peerHWAddr == nil {
+		return ""
+	}
+
+	return nwconfig.peerHWAddr.String()
+}
+
+// reconfigureOnLLDPChange updates the interface configuration from the
+// received LLDP information. If the address changes, the old address and
+// its routes are removed and the new ones configured. The gaudinet and
+// systemd-networkd files are rewritten for any change. Returns a
+// description of the change, or an empty string if nothing changed. A
+// changed switch or switch port is only recorded.
+func reconfigureOnLLDPChange(config *cmdConfig, networkConfigs map[string]*networkConfiguration, result lldp.DiscoveryResult) (string, error) {
+	nwconfig, exists := networkConfigs[result.InterfaceName]
+	if !exists {
+		return "", nil
+	}
+
+	// a failed reconfiguration is retried with the same LLDP information,
+	// which no longer differs from the recorded one
+	if !nwconfig.reconfigurePending && !lldpInfoChanged(nwconfig, result) {
+		nwconfig.sysDescription = result.SysDescription
+
+		if !switchInfoChanged(nwconfig, result) {
+			return "", nil
+		}
+
+		change := fmt.Sprintf("Interface '%s' switch '%s' port '%s', was switch '%s' port '%s'",
+			result.InterfaceName, result.SysName, result.PortID, nwconfig.sysName, nwconfig.portID)
+		nwconfig.sysName, nwconfig.portID = result.SysName, result.PortID
+
+		return change, nil
+	}
+
+	ifname := result.InterfaceName
+	oldPeerMAC, oldAddr := peerMACString(nwconfig), localCIDR(nwconfig)
+
+	setLLDPInfo(nwconfig, result)
+	nwconfig.reconfigurePending = true
+
+	lldpPeer, localAddr, prefixLen, err := selectPointToPointL3Address(nwconfig)
+	if err != nil {
+		return "", fmt.Errorf("interface '%s' LLDP information changed: %v", ifname, err)
+	}
+
+	nwconfig.lldpPeer = lldpPeer
+	nwconfig.localAddr = localAddr
+	nwconfig.localPrefixLen = prefixLen
+
+	single := map[string]*networkConfiguration{ifname: nwconfig}
+	if err := setRoutedNetworks(config, single); err != nil {
+		return "", err
+	}
+
+	change := fmt.Sprintf("Interface '%s' peer %s address %s, was peer %s address %s",
+		ifname, peerMACString(nwconfig), localCIDR(nwconfig), oldPeerMAC, oldAddr)
+
+	if localCIDR(nwconfig) != oldAddr || !nwconfig.configured {
+		if err := removeExistingIPs(single); err != nil {
+			return change, fmt.Errorf("cannot remove the addresses of interface '%s': %v", ifname, err)
+		}
+
+		if numConfigured, _ := configureInterfaces(single); numConfigured == 0 {
+			return change, fmt.Errorf("could not configure interface '%s' with address %s", ifname, localCIDR(nwconfig))
+		}
+	}
+
+	if err := writeL3Configuration(config, networkConfigs); err != nil {
+		return change, err
+	}
+
+	nwconfig.reconfigurePending = false
+
+	return change, nil
+}
+
+// handleLLDPUpdate reconfigures the interface on changed LLDP information
+// and records the outcome as an event and in the node state.
+func handleLLDPUpdate(config *cmdConfig, reporter *nodeStateReporter, transmitter *lldpTransmitter,
+	exporter *Exporter, networkConfigs map[string]*networkConfiguration, result lldp.DiscoveryResult) {
+	change, err := reconfigureOnLLDPChange(config, networkConfigs, result)
+	if err != nil {
+		klog.Warningf("Reconfiguration failed: %v", err)
+		reporter.event(config, corev1.EventTypeWarning, eventReasonReconfigureFailed, err.Error())
+		reporter.report(config, networkConfigs, networkv1alpha1.NodeStateFailed, err)
+		return
+	}
+
+	if change == "" {
+		return
+	}
+
+	klog.Infof("Topology changed: %s", change)
+
+	transmitter.update(config, networkConfigs)
+	exporter.update(networkConfigs)
+
+	reporter.event(config, corev1.EventTypeNormal, eventReasonTopologyChanged, change)
+	reporter.report(config, networkConfigs, networkv1alpha1.NodeStateConfigured, nil)
+}
@@ -0,0 +1,306 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vishvananda/netlink"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+	"github.com/intel/network-operator/pkg/lldp"
+)
+
+func TestLLDPInfoChanged(t *testing.T) {
+	nwconfig := getFakeNetworkDataConfigs()["eth_a"]
+
+	result := lldpInfo(nwconfig)
+	result.PeerMAC = *nwconfig.peerHWAddr
+
+	if lldpInfoChanged(nwconfig, result) {
+		t.Error("unchanged LLDP information detected as changed")
+	}
+
+	// the system name does not affect the configuration
+	result.SysName = "switch-b"
+	if lldpInfoChanged(nwconfig, result) {
+		t.Error("changed system name detected as changed LLDP information")
+	}
+
+	moved := result
+	moved.PeerMAC = net.HardwareAddr{0x01, 0x01, 0x02, 0x02, 0x03, 0x04}
+	if !lldpInfoChanged(nwconfig, moved) {
+		t.Error("changed peer MAC not detected")
+	}
+
+	reconfigured := result
+	reconfigured.PortDescription = "no-alert 10.210.8.130/30"
+	if !lldpInfoChanged(nwconfig, reconfigured) {
+		t.Error("changed port description not detected")
+	}
+
+	tlvs := result
+	tlvs.OrgTLVs = []lldp.OrgSpecificTLV{{OUI: 0x0012bb, SubType: 7, Info: []byte("10.210.8.130/30")}}
+	if !lldpInfoChanged(nwconfig, tlvs) {
+		t.Error("changed organizationally specific TLVs not detected")
+	}
+
+	// e.g. a switch firmware upgrade
+	upgraded := result
+	upgraded.SysDescription = "switch OS 2.0"
+	if lldpInfoChanged(nwconfig, upgraded) {
+		t.Error("changed system description detected as changed LLDP information")
+	}
+
+	defer func() { lldpAddressParser = parseNoAlert }()
+	lldpAddressParser = parseSysDescription
+
+	nwconfig.sysDescription = "switch OS 1.0 10.210.8.122/30"
+	result = lldpInfo(nwconfig)
+	result.PeerMAC = *nwconfig.peerHWAddr
+
+	upgraded = result
+	upgraded.SysDescription = "switch OS 2.0 10.210.8.122/30"
+	if lldpInfoChanged(nwconfig, upgraded) {
+		t.Error("changed system description with the same address detected as changed LLDP information")
+	}
+
+	readdressed := result
+	readdressed.SysDescription = "switch OS 1.0 10.210.8.130/30"
+	if !lldpInfoChanged(nwconfig, readdressed) {
+		t.Error("changed address in the system description not detected")
+	}
+}
+
+func TestLLDPMonitorLinkUp(t *testing.T) {
+	nwconfigs := getFakeNetworkDataConfigs()
+
+	// the fake links are down
+	m := startLLDPMonitor(&cmdConfig{ctx: context.Background(), mode: L3}, nwconfigs)
+	if m == nil {
+		t.Fatal("monitor not started")
+	}
+	defer m.stop()
+
+	m.handleLinkUpdate(nwconfigs, netlink.LinkUpdate{Link: nwconfigs["eth_a"].link})
+	m.handleLinkUpdate(nwconfigs, netlink.LinkUpdate{Link: &fakeLink{fakeAttrs: netlink.LinkAttrs{Name: "eth_unknown"}}})
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if len(m.listening) != 0 {
+		t.Errorf("monitoring links that are down: %v", m.listening)
+	}
+
+	// nil monitor does nothing
+	var none *lldpMonitor
+	none.handleLinkUpdate(nwconfigs, netlink.LinkUpdate{Link: nwconfigs["eth_a"].link})
+}
+
+func TestReconfigureOnLLDPChange(t *testing.T) {
+	networkLink.AddrList = fakeLinkAddrList
+	networkLink.AddrAdd = fakeLinkAddrAdd
+	networkLink.RouteAppend = fakeRouteAppend
+
+	removed := []string{}
+	networkLink.AddrDel = func(link netlink.Link, addr *netlink.Addr) error {
+		removed = append(removed, addr.IPNet.String())
+		return nil
+	}
+
+	JsonMarshal = json.Marshal
+
+	gaudinet := filepath.Join(t.TempDir(), "gaudinet.json")
+	config := &cmdConfig{ctx: context.Background(), mode: L3, gaudinetfile: gaudinet}
+
+	nwconfigs := getFakeNetworkDataConfigs()
+	_ = lldpResults(nwconfigs)
+	nwconfigs["eth_a"].configured = true
+
+	result := lldpInfo(nwconfigs["eth_a"])
+	result.PeerMAC = *nwconfigs["eth_a"].peerHWAddr
+
+	change, err := reconfigureOnLLDPChange(config, nwconfigs, result)
+	if change != "" || err != nil {
+		t.Errorf("unexpected change '%s' without new LLDP information: %v", change, err)
+	}
+
+	result.InterfaceName = "eth_unknown"
+	if change, err := reconfigureOnLLDPChange(config, nwconfigs, result); change != "" || err != nil {
+		t.Errorf("unexpected change '%s' for unknown interface: %v", change, err)
+	}
+	result.InterfaceName = "eth_a"
+
+	// switch port change is only recorded
+	result.SysName, result.PortID = "switch-b", "swp12"
+	change, err = reconfigureOnLLDPChange(config, nwconfigs, result)
+	if err != nil || !strings.Contains(change, "switch-b") || !strings.Contains(change, "swp12") {
+		t.Errorf("unexpected change '%s': %v", change, err)
+	}
+	if nwconfigs["eth_a"].sysName != "switch-b" || nwconfigs["eth_a"].portID != "swp12" || len(removed) != 0 {
+		t.Errorf("unexpected switch port change of interface %+v, removed %v", nwconfigs["eth_a"], removed)
+	}
+
+	// peer MAC change keeps the address
+	fakeAddrsAdded = nil
+	result.PeerMAC = net.HardwareAddr{0x01, 0x01, 0x02, 0x02, 0x03, 0x04}
+
+	change, err = reconfigureOnLLDPChange(config, nwconfigs, result)
+	if err != nil || !strings.Contains(change, "01:01:02:02:03:04") {
+		t.Errorf("unexpected change '%s': %v", change, err)
+	}
+	if len(removed) != 0 || len(fakeAddrsAdded) != 0 {
+		t.Errorf("address changed with the same address, removed %v added %v", removed, fakeAddrsAdded)
+	}
+
+	data, err := os.ReadFile(gaudinet)
+	if err != nil || !strings.Contains(string(data), "01:01:02:02:03:04") {
+		t.Errorf("gaudinet file not updated with the peer MAC: %s: %v", data, err)
+	}
+
+	// switch port address change replaces the address
+	result.PortDescription = "no-alert 10.210.8.130/30"
+
+	change, err = reconfigureOnLLDPChange(config, nwconfigs, result)
+	if err != nil || !strings.Contains(change, "10.210.8.129/30") || !strings.Contains(change, "10.210.8.121/30") {
+		t.Errorf("unexpected change '%s': %v", change, err)
+	}
+	if len(removed) != 1 || removed[0] != "192.192.192.1/24" {
+		t.Errorf("old addresses not removed: %v", removed)
+	}
+	if len(fakeAddrsAdded) != 1 || fakeAddrsAdded[0].IPNet.String() != "10.210.8.129/30" {
+		t.Errorf("new address not configured: %v", fakeAddrsAdded)
+	}
+
+	data, err = os.ReadFile(gaudinet)
+	if err != nil || !strings.Contains(string(data), "10.210.8.129") {
+		t.Errorf("gaudinet file not updated with the address: %s: %v", data, err)
+	}
+
+	// invalid address
+	result.PortDescription = "no-alert 10.210.8.130/24"
+	if _, err := reconfigureOnLLDPChange(config, nwconfigs, result); err == nil {
+		t.Error("expected an error with an invalid address")
+	}
+
+	networkLink.AddrAdd = fakeLinkAddrAddErr
+	result.PortDescription = "no-alert 10.210.8.134/30"
+	if _, err := reconfigureOnLLDPChange(config, nwconfigs, result); err == nil {
+		t.Error("expected an error when the address cannot be configured")
+	}
+
+	// the same LLDP information is retried until the reconfiguration succeeds
+	if _, err := reconfigureOnLLDPChange(config, nwconfigs, result); err == nil {
+		t.Error("expected the failed reconfiguration to be retried")
+	}
+
+	networkLink.AddrAdd = fakeLinkAddrAdd
+	fakeAddrsAdded = nil
+
+	change, err = reconfigureOnLLDPChange(config, nwconfigs, result)
+	if err != nil || !strings.Contains(change, "10.210.8.133/30") {
+		t.Errorf("unexpected change '%s': %v", change, err)
+	}
+	if len(fakeAddrsAdded) != 1 || fakeAddrsAdded[0].IPNet.String() != "10.210.8.133/30" {
+		t.Errorf("address not configured on retry: %v", fakeAddrsAdded)
+	}
+
+	if change, err := reconfigureOnLLDPChange(config, nwconfigs, result); change != "" || err != nil {
+		t.Errorf("unexpected change '%s' after a successful retry: %v", change, err)
+	}
+}
+
+func TestHandleLLDPUpdate(t *testing.T) {
+	networkLink.LinkByName = fakeLinkByName
+	networkLink.AddrList = fakeLinkAddrList
+	networkLink.AddrAdd = fakeLinkAddrAdd
+	networkLink.RouteAppend = fakeRouteAppend
+	networkLink.AddrDel = func(link netlink.Link, addr *netlink.Addr) error {
+		return nil
+	}
+
+	r := fakeNodeStateReporter(t, testPolicy())
+	config := &cmdConfig{ctx: context.Background(), mode: L3}
+
+	nwconfigs := getFakeNetworkDataConfigs()
+	_ = lldpResults(nwconfigs)
+
+	r.report(config, nwconfigs, networkv1alpha1.NodeStateConfigured, nil)
+
+	result := lldpInfo(nwconfigs["eth_a"])
+	result.PeerMAC = *nwconfigs["eth_a"].peerHWAddr
+	result.PortDescription = "no-alert 10.210.8.130/30"
+
+	handleLLDPUpdate(config, r, nil, nil, nwconfigs, result)
+
+	events := &corev1.EventList{}
+	if err := r.client.List(context.Background(), events); err != nil {
+		t.Fatalf("cannot list events: %v", err)
+	}
+	if len(events.Items) != 1 || events.Items[0].Reason != eventReasonTopologyChanged ||
+		events.Items[0].InvolvedObject.Name != testNodeName || events.Items[0].Namespace != metav1.NamespaceDefault {
+		t.Errorf("unexpected events %+v", events.Items)
+	}
+
+	nodeState := &networkv1alpha1.NetworkNodeState{}
+	if err := r.client.Get(context.Background(), client.ObjectKey{Name: testNodeName}, nodeState); err != nil {
+		t.Fatalf("cannot get node state: %v", err)
+	}
+	if nodeState.Status.State != networkv1alpha1.NodeStateConfigured || nodeState.Status.Interfaces[0].LocalAddress != "10.210.8.129/30" {
+		t.Errorf("node state not updated %+v", nodeState.Status)
+	}
+
+	networkLink.AddrAdd = fakeLinkAddrAddErr
+	result.PortDescription = "no-alert 10.210.8.134/30"
+
+	handleLLDPUpdate(config, r, nil, nil, nwconfigs, result)
+
+	if err := r.client.List(context.Background(), events); err != nil {
+		t.Fatalf("cannot list events: %v", err)
+	}
+	if len(events.Items) != 2 {
+		t.Errorf("expected a failure event, got %+v", events.Items)
+	}
+	if err := r.client.Get(context.Background(), client.ObjectKey{Name: testNodeName}, nodeState); err != nil {
+		t.Fatalf("cannot get node state: %v", err)
+	}
+	if nodeState.Status.State != networkv1alpha1.NodeStateFailed {
+		t.Errorf("failure not reported in node state %+v", nodeState.Status)
+	}
+
+	// nil monitor and reporter do nothing
+	var monitor *lldpMonitor
+	if monitor.updates() != nil {
+		t.Error("nil monitor has updates")
+	}
+	monitor.stop()
+
+	var nilReporter *nodeStateReporter
+	nilReporter.event(config, corev1.EventTypeNormal, eventReasonTopologyChanged, "")
+
+	if startLLDPMonitor(&cmdConfig{mode: L3, addressPool: "pool"}, nwconfigs) != nil {
+		t.Error("monitor started with addresses from an address pool")
+	}
+}
@@ -29,11 +29,16 @@ const (
 	LLDPToolBinary = "lldptool"
 
 	pfcDisable = "none"
+
+	pfcBackendLLDPTool = "lldptool"
+	pfcBackendNetlink  = "netlink"
 )
 
 var (
 	lldpPath   string
 	lldpBinary string = LLDPToolBinary
+
+	runLLDPTool = execLLDPTool
 )
 
 func LookupLLDPTool() error {
@@ -68,6 +73,9 @@ func VerifyPFCArgument(pfc string) (string, error) {
 		if i < 0 || i > 7 {
 			return "", fmt.Errorf("PFC value %d not in range 0-7", i)
 		}
+		if result[i] {
+			return "", fmt.Errorf("PFC value %d given more than once", i)
+		}
 		result[i] = true
 	}
 
@@ -80,47 +88,193 @@ func VerifyPFCArgument(pfc string) (string, error) {
 	return strings.Join(ordered, ","), nil
 }
 
-func execPFC(ifname string, pfc string) error {
+// etsConfig holds the traffic class of each priority and the bandwidth
+// percentage of each traffic class.
+type etsConfig struct {
+	prioTC [ieeeMaxTCs]uint8
+	tcBW   [ieeeMaxTCs]uint8
+}
+
+func defaultETS() *etsConfig {
+	return &etsConfig{tcBW: [ieeeMaxTCs]uint8{100}}
+}
+
+func (e *etsConfig) String() string {
+	prioTC, tcBW := []string{}, []string{}
+	for i := 0; i < ieeeMaxTCs; i++ {
+		prioTC = append(prioTC, strconv.Itoa(int(e.prioTC[i])))
+		tcBW = append(tcBW, strconv.Itoa(int(e.tcBW[i])))
+	}
+
+	return fmt.Sprintf("prio-tc %s tc-bw %s", strings.Join(prioTC, ","), strings.Join(tcBW, ","))
+}
+
+func parseETSList(arg string, max int) ([ieeeMaxTCs]uint8, error) {
+	result := [ieeeMaxTCs]uint8{}
+
+	strs := strings.Split(strings.TrimSpace(arg), ",")
+	if len(strs) > ieeeMaxTCs {
+		return result, fmt.Errorf("%d values, max is %d", len(strs), ieeeMaxTCs)
+	}
+
+	for i, s := range strs {
+		v, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return result, fmt.Errorf("value is not a number: %v", err)
+		}
+		if v < 0 || v > max {
+			return result, fmt.Errorf("value %d not in range 0-%d", v, max)
+		}
+		result[i] = uint8(v)
+	}
+
+	return result, nil
+}
+
+// VerifyETSArguments parses the comma separated traffic classes of the
+// priorities, starting from priority 0, and the bandwidth percentages of
+// the traffic classes, starting from traffic class 0. Unlisted priorities
+// use traffic class 0. Returns nil if ETS is not configured.
+func VerifyETSArguments(prioTC string, tcBW string) (*etsConfig, error) {
+	if strings.TrimSpace(prioTC) == "" && strings.TrimSpace(tcBW) == "" {
+		return nil, nil
+	}
+
+	if strings.TrimSpace(tcBW) == "" {
+		return nil, fmt.Errorf("traffic class bandwidths are required")
+	}
+
+	ets := &etsConfig{}
+
 	var err error
+	if strings.TrimSpace(prioTC) != "" {
+		if ets.prioTC, err = parseETSList(prioTC, ieeeMaxTCs-1); err != nil {
+			return nil, fmt.Errorf("invalid traffic class: %v", err)
+		}
+	}
+
+	if ets.tcBW, err = parseETSList(tcBW, 100); err != nil {
+		return nil, fmt.Errorf("invalid bandwidth: %v", err)
+	}
+
+	total := 0
+	for _, bw := range ets.tcBW {
+		total += int(bw)
+	}
+	if total != 100 {
+		return nil, fmt.Errorf("bandwidths add up to %d, not 100", total)
+	}
+
+	for prio, tc := range ets.prioTC {
+		if ets.tcBW[tc] == 0 {
+			return nil, fmt.Errorf("priority %d is in traffic class %d without bandwidth", prio, tc)
+		}
+	}
 
-	cmd := exec.Command(lldpPath, "-L", "-i", ifname, "adminStatus=rxtx")
-	err = cmd.Run()
+	return ets, nil
+}
+
+func execLLDPTool(args ...string) error {
+	cmd := exec.Command(lldpPath, args...)
+	err := cmd.Run()
 	if err == nil {
 		klog.Infof("successfully run '%s'", strings.Join(cmd.Args, " "))
 	} else {
 		klog.Warningf("failed to run '%s': %v", strings.Join(cmd.Args, " "), err)
+	}
+
+	return err
+}
+
+func execPFC(ifname string, pfc string) error {
+	if err := runLLDPTool("-L", "-i", ifname, "adminStatus=rxtx"); err != nil {
 		return err
 	}
 
 	enabled := fmt.Sprintf("enabled=%s", pfc)
-	cmd = exec.Command(lldpPath, "-T", "-i", ifname, "-V", "PFC",
-		"enableTx=yes", enabled)
-	err = cmd.Run()
-	if err == nil {
-		klog.Infof("successfully run '%s'", strings.Join(cmd.Args, " "))
-	} else {
-		klog.Warningf("failed to run '%s': %v", strings.Join(cmd.Args, " "), err)
+
+	return runLLDPTool("-T", "-i", ifname, "-V", "PFC", "enableTx=yes", enabled)
+}
+
+// execETS configures ETS with lldptool. All traffic classes use the ETS
+// transmission selection algorithm.
+func execETS(ifname string, ets *etsConfig) error {
+	if err := runLLDPTool("-L", "-i", ifname, "adminStatus=rxtx"); err != nil {
 		return err
 	}
 
+	up2tc, tsa, tcbw := []string{}, []string{}, []string{}
+	for i := 0; i < ieeeMaxTCs; i++ {
+		up2tc = append(up2tc, fmt.Sprintf("%d:%d", i, ets.prioTC[i]))
+		tsa = append(tsa, fmt.Sprintf("%d:ets", i))
+		tcbw = append(tcbw, strconv.Itoa(int(ets.tcBW[i])))
+	}
+
+	return runLLDPTool("-T", "-i", ifname, "-V", "ETS-CFG", "enableTx=yes", "willing=no",
+		"up2tc="+strings.Join(up2tc, ","), "tsa="+strings.Join(tsa, ","), "tcbw="+strings.Join(tcbw, ","))
+}
+
+// setPFC configures PFC on the interface with lldptool or natively over
+// DCB netlink.
+func setPFC(config *cmdConfig, ifname string, pfc string) error {
+	if config.pfcBackend == pfcBackendNetlink {
+		return setDCBPFC(ifname, pfc)
+	}
+
+	return execPFC(ifname, pfc)
+}
+
+func EnableAllPFC(config *cmdConfig, networkConfigs map[string]*networkConfiguration) error {
+	for ifname := range networkConfigs {
+		if err := setPFC(config, ifname, config.pfc); err != nil {
+			return err
+		}
+
+		klog.V(3).Infof("Enabled PFCs '%s' for interface %s", config.pfc, ifname)
+	}
+
+	return nil
+}
+
+// setETS configures ETS on the interface with lldptool or natively over
+// DCB netlink.
+func setETS(config *cmdConfig, ifname string, ets *etsConfig) error {
+	if config.pfcBackend == pfcBackendNetlink {
+		return setDCBETS(ifname, ets)
+	}
+
+	return execETS(ifname, ets)
+}
+
+func EnableAllETS(config *cmdConfig, networkConfigs map[string]*networkConfiguration) error {
+	for ifname := range networkConfigs {
+		if err := setETS(config, ifname, config.ets); err != nil {
+			return err
+		}
+
+		klog.V(3).Infof("Enabled ETS '%s' for interface %s", config.ets, ifname)
+	}
+
 	return nil
 }
 
-func EnableAllPFC(pfc string, networkConfigs map[string]*networkConfiguration) error {
+// RestoreAllETS restores the default ETS configuration with all priorities
+// in traffic class 0.
+func RestoreAllETS(config *cmdConfig, networkConfigs map[string]*networkConfiguration) error {
 	for ifname := range networkConfigs {
-		if err := execPFC(ifname, pfc); err != nil {
+		if err := setETS(config, ifname, defaultETS()); err != nil {
 			return err
 		}
 
-		klog.V(3).Infof("Enabled PFCs '%s' for interface %s", pfc, ifname)
+		klog.V(3).Infof("Restored default ETS for interface %s", ifname)
 	}
 
 	return nil
 }
 
-func DisableAllPFC(networkConfigs map[string]*networkConfiguration) error {
+func DisableAllPFC(config *cmdConfig, networkConfigs map[string]*networkConfiguration) error {
 	for ifname := range networkConfigs {
-		if err := execPFC(ifname, pfcDisable); err != nil {
+		if err := setPFC(config, ifname, pfcDisable); err != nil {
 			return err
 		}
 
@@ -17,7 +17,10 @@
 package main
 
 import (
+	"context"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -38,6 +41,9 @@ func TestVerifyPFCArgument(t *testing.T) {
 		{"9", false, ""},
 		{"-9", false, ""},
 		{"1,2,-42", false, ""},
+		{"1,1", false, ""},
+		{"1,", false, ""},
+		{"00010000", false, ""},
 		{"7,5,3,6,4", true, "3,4,5,6,7"},
 		{"   7,5    ,  3 , 6 , 4                ", true, "3,4,5,6,7"},
 	}
@@ -53,6 +59,15 @@ func TestVerifyPFCArgument(t *testing.T) {
 	}
 }
 
+func TestPFCInput(t *testing.T) {
+	for _, pfc := range []string{"1,1", "9", "00010000"} {
+		config := &cmdConfig{ctx: context.Background(), mode: L3, pfc: pfc}
+		if err := sanitizeInput(config); err == nil || !strings.Contains(err.Error(), "PFC") {
+			t.Errorf("expected a PFC error with PFC priorities '%s', got: %v", pfc, err)
+		}
+	}
+}
+
 const (
 	lldpBinarySuccess = "true"
 	lldpBinaryFailure = "false"
@@ -94,3 +109,62 @@ func TestEnablePFC(t *testing.T) {
 		t.Errorf("%s disabling returned success when it should not have", lldpBinary)
 	}
 }
+
+func TestVerifyETSArguments(t *testing.T) {
+	tests := []struct {
+		prioTC   string
+		tcBW     string
+		success  bool
+		expected *etsConfig
+	}{
+		{"", "", true, nil},
+		{"", "100", true, defaultETS()},
+		{"0,0,0,1", "50,50", true, &etsConfig{
+			prioTC: [ieeeMaxTCs]uint8{0, 0, 0, 1},
+			tcBW:   [ieeeMaxTCs]uint8{50, 50},
+		}},
+		{" 0, 1 ,2,2,2,2,2,2 ", "10, 20, 70", true, &etsConfig{
+			prioTC: [ieeeMaxTCs]uint8{0, 1, 2, 2, 2, 2, 2, 2},
+			tcBW:   [ieeeMaxTCs]uint8{10, 20, 70},
+		}},
+		{"0,1", "", false, nil},
+		{"0,8", "50,50", false, nil},
+		{"0,0,0,0,0,0,0,0,0", "100", false, nil},
+		{"foo", "100", false, nil},
+		{"", "50,40", false, nil},
+		{"", "101", false, nil},
+		{"0,2", "50,50", false, nil},
+	}
+
+	for _, tt := range tests {
+		ets, err := VerifyETSArguments(tt.prioTC, tt.tcBW)
+		if (err == nil) != tt.success {
+			t.Errorf("ETS '%s' '%s': unexpected error %v", tt.prioTC, tt.tcBW, err)
+		}
+		if !reflect.DeepEqual(ets, tt.expected) {
+			t.Errorf("ETS '%s' '%s': expected %v, got %v", tt.prioTC, tt.tcBW, tt.expected, ets)
+		}
+	}
+}
+
+func TestEnableETS(t *testing.T) {
+	ets := &etsConfig{prioTC: [ieeeMaxTCs]uint8{0, 0, 0, 1}, tcBW: [ieeeMaxTCs]uint8{50, 50}}
+
+	lldpBinary = lldpBinarySuccess
+	if err := LookupLLDPTool(); err != nil {
+		t.Errorf("lldp binary '%s' not found at path '%s': %v", lldpBinary, os.Getenv("PATH"), err)
+	}
+
+	if err := execETS("foo0", ets); err != nil {
+		t.Errorf("%s enabling failed: %v", lldpBinary, err)
+	}
+
+	lldpBinary = lldpBinaryFailure
+	if err := LookupLLDPTool(); err != nil {
+		t.Errorf("lldp binary '%s' not found at path '%s': %v", lldpBinary, os.Getenv("PATH"), err)
+	}
+
+	if err := execETS("foo0", ets); err == nil {
+		t.Errorf("%s enabling returned success when it should not have", lldpBinary)
+	}
+}
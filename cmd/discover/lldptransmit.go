@@ -0,0 +1,199 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/vishvananda/netlink"
+	"k8s.io/klog/v2"
+
+	"github.com/intel/network-operator/pkg/lldp"
+)
+
+const lldpSysDescription = "Intel Gaudi scale-out network"
+
+// lldpTransmitter advertises the node name and the interfaces in LLDP
+// for back-to-back topologies and for identifying the node in the fabric.
+type lldpTransmitter struct {
+	ctx            context.Context
+	interval       time.Duration
+	mutex          sync.Mutex
+	advertisements map[string]lldp.Advertisement
+	transmitting   map[string]bool
+	stopped        bool
+	cancel         context.CancelFunc
+	wg             sync.WaitGroup
+	stopOnce       sync.Once
+}
+
+// lldpSysName returns the node name, or the host name if no node name
+// was given.
+func lldpSysName(config *cmdConfig) string {
+	if config.nodeName != "" {
+		return config.nodeName
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return hostname
+}
+
+// interfaceAdvertisement returns the LLDP information advertised for the
+// interface. The port description contains the module ID and, once
+// known, the local address in the 'ip=<CIDR>' format so that a directly
+// connected peer can derive its address from it.
+func interfaceAdvertisement(sysName, ifname string, nwconfig *networkConfiguration) lldp.Advertisement {
+	portDescription := ifname
+	if nwconfig.moduleId != "" {
+		portDescription = fmt.Sprintf("module %s %s", nwconfig.moduleId, ifname)
+	}
+
+	if nwconfig.localAddr != nil {
+		portDescription += " ip=" + localCIDR(nwconfig)
+	}
+
+	return lldp.Advertisement{
+		PortDescription: portDescription,
+		SysName:         sysName,
+		SysDescription:  lldpSysDescription,
+	}
+}
+
+// startLLDPTransmit starts transmitting LLDP frames on the interfaces that
+// are up. The other interfaces start transmitting when their link comes up.
+// Returns nil if transmitting is not enabled.
+func startLLDPTransmit(config *cmdConfig, networkConfigs map[string]*networkConfiguration) *lldpTransmitter {
+	if !config.lldpTransmit || config.dryRun {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(config.ctx)
+	t := &lldpTransmitter{
+		ctx:            ctx,
+		interval:       config.lldpTransmitInterval,
+		advertisements: map[string]lldp.Advertisement{},
+		transmitting:   map[string]bool{},
+		cancel:         cancel,
+	}
+
+	t.update(config, networkConfigs)
+
+	for ifname, nwconfig := range networkConfigs {
+		if !t.transmit(ifname, nwconfig) {
+			klog.Infof("Link '%s' is down, transmitting LLDP when it comes up", ifname)
+		}
+	}
+
+	return t
+}
+
+// transmit starts transmitting LLDP frames on the interface unless its link
+// is down or it is already transmitting. Returns false if the link is down.
+func (t *lldpTransmitter) transmit(ifname string, nwconfig *networkConfiguration) bool {
+	if nwconfig.link.Attrs().Flags&net.FlagUp == 0 {
+		return false
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.stopped || t.transmitting[ifname] {
+		return true
+	}
+	t.transmitting[ifname] = true
+
+	client := lldp.NewClient(t.ctx, ifname, *nwconfig.localHwAddr)
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+
+		if err := client.Transmit(t.interval, func() lldp.Advertisement {
+			t.mutex.Lock()
+			defer t.mutex.Unlock()
+
+			return t.advertisements[ifname]
+		}, func(err error) {
+			klog.Warningf("Cannot transmit LLDP on '%s', retrying: %v", ifname, err)
+		}); err != nil {
+			klog.Warningf("Cannot transmit LLDP on '%s': %v", ifname, err)
+		}
+
+		// Let the transmit be restarted when the link comes up again
+		t.mutex.Lock()
+		delete(t.transmitting, ifname)
+		t.mutex.Unlock()
+	}()
+
+	klog.Infof("Started LLDP transmit for '%s'", ifname)
+
+	return true
+}
+
+// handleLinkUpdate starts transmitting on an interface whose link comes up.
+func (t *lldpTransmitter) handleLinkUpdate(networkConfigs map[string]*networkConfiguration, update netlink.LinkUpdate) {
+	if t == nil {
+		return
+	}
+
+	ifname := update.Link.Attrs().Name
+	if nwconfig, exists := networkConfigs[ifname]; exists {
+		t.transmit(ifname, nwconfig)
+	}
+}
+
+// update refreshes the advertised information from the interface
+// configurations.
+func (t *lldpTransmitter) update(config *cmdConfig, networkConfigs map[string]*networkConfiguration) {
+	if t == nil {
+		return
+	}
+
+	sysName := lldpSysName(config)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for ifname, nwconfig := range networkConfigs {
+		t.advertisements[ifname] = interfaceAdvertisement(sysName, ifname, nwconfig)
+	}
+}
+
+// stop stops transmitting and waits for the peers to be told to forget
+// the advertised information.
+func (t *lldpTransmitter) stop() {
+	if t == nil {
+		return
+	}
+
+	t.stopOnce.Do(func() {
+		t.mutex.Lock()
+		t.stopped = true
+		t.mutex.Unlock()
+
+		t.cancel()
+		t.wg.Wait()
+	})
+}
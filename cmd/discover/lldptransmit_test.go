@@ -0,0 +1,112 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/vishvananda/netlink"
+
+	"github.com/intel/network-operator/pkg/lldp"
+)
+
+func TestInterfaceAdvertisement(t *testing.T) {
+	config := &cmdConfig{ctx: context.Background(), nodeName: testNodeName}
+
+	if transmitter := startLLDPTransmit(config, nil); transmitter != nil {
+		t.Error("transmitter started without being enabled")
+	}
+
+	nwconfigs := getFakeNetworkDataConfigs()
+	nwconfigs["eth_a"].moduleId = "3"
+
+	adv := interfaceAdvertisement(lldpSysName(config), "eth_a", nwconfigs["eth_a"])
+	if adv.SysName != testNodeName || adv.PortDescription != "module 3 eth_a" || adv.SysDescription == "" {
+		t.Errorf("unexpected advertisement %+v", adv)
+	}
+
+	_ = lldpResults(nwconfigs)
+
+	adv = interfaceAdvertisement(lldpSysName(config), "eth_a", nwconfigs["eth_a"])
+	if adv.PortDescription != "module 3 eth_a ip=10.210.8.121/30" {
+		t.Errorf("unexpected port description '%s'", adv.PortDescription)
+	}
+
+	// the peer derives its address from the advertised address
+	parser, err := newAddressParser(addressFormatIPEquals, "", "")
+	if err != nil {
+		t.Fatalf("cannot create parser: %v", err)
+	}
+	peer, _, err := parser(lldp.DiscoveryResult{PortDescription: adv.PortDescription})
+	if err != nil || peer.String() != "10.210.8.121" {
+		t.Errorf("peer cannot parse the advertised address: %s: %v", peer, err)
+	}
+
+	adv = interfaceAdvertisement(lldpSysName(config), "eth_b", nwconfigs["eth_b"])
+	if adv.PortDescription != "eth_b" {
+		t.Errorf("unexpected port description '%s'", adv.PortDescription)
+	}
+
+	// nil transmitter does nothing
+	var transmitter *lldpTransmitter
+	transmitter.update(config, nwconfigs)
+	transmitter.stop()
+}
+
+func TestLLDPTransmitLinkUp(t *testing.T) {
+	config := &cmdConfig{ctx: context.Background(), nodeName: testNodeName, lldpTransmit: true}
+	nwconfigs := getFakeNetworkDataConfigs()
+
+	// the fake links are down
+	transmitter := startLLDPTransmit(config, nwconfigs)
+	if transmitter == nil {
+		t.Fatal("transmitter not started")
+	}
+
+	transmitter.handleLinkUpdate(nwconfigs, netlink.LinkUpdate{Link: nwconfigs["eth_a"].link})
+	transmitter.handleLinkUpdate(nwconfigs, netlink.LinkUpdate{Link: &fakeLink{fakeAttrs: netlink.LinkAttrs{Name: "eth_unknown", Flags: net.FlagUp}}})
+
+	transmitter.mutex.Lock()
+	if len(transmitter.transmitting) != 0 {
+		t.Errorf("transmitting on links that are down: %v", transmitter.transmitting)
+	}
+	transmitter.mutex.Unlock()
+
+	// the transmit of a link coming up ends when the interface cannot be
+	// opened and is started again by the next link update
+	nwconfigs["eth_b"].link.Attrs().Flags |= net.FlagUp
+	nwconfigs["eth_b"].localHwAddr = &nwconfigs["eth_b"].link.Attrs().HardwareAddr
+	transmitter.handleLinkUpdate(nwconfigs, netlink.LinkUpdate{Link: nwconfigs["eth_b"].link})
+
+	transmitter.stop()
+
+	// no transmit is started after stopping
+	nwconfigs["eth_a"].link.Attrs().Flags |= net.FlagUp
+	transmitter.handleLinkUpdate(nwconfigs, netlink.LinkUpdate{Link: nwconfigs["eth_a"].link})
+
+	transmitter.mutex.Lock()
+	defer transmitter.mutex.Unlock()
+	if len(transmitter.transmitting) != 0 {
+		t.Errorf("transmitting after stop: %v", transmitter.transmitting)
+	}
+
+	// nil transmitter does nothing
+	var none *lldpTransmitter
+	none.handleLinkUpdate(nwconfigs, netlink.LinkUpdate{Link: nwconfigs["eth_a"].link})
+}
@@ -33,6 +33,7 @@ import (
 
 	"github.com/intel/network-operator/pkg/lldp"
 
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
 	nm "github.com/intel/network-operator/internal/nm"
 	buildVersion "github.com/intel/network-operator/internal/version"
 )
@@ -40,10 +41,11 @@ import (
 const (
 	L2 = "L2"
 	L3 = "L3"
+)
 
-	nfdFeatureDir         = "/etc/kubernetes/node-feature-discovery/features.d/"
-	nfdLabelFile          = nfdFeatureDir + "scale-out-readiness.txt"
-	nfdScaleOutReadyLabel = "intel.feature.node.kubernetes.io/gaudi-scale-out=true"
+var (
+	nfdFeatureDir = "/etc/kubernetes/node-feature-discovery/features.d/"
+	nfdLabelFile  = nfdFeatureDir + "scale-out-readiness.txt"
 )
 
 type cmdConfig struct {
@@ -58,7 +60,35 @@ type cmdConfig struct {
 	networkd           string
 	mtu                int
 	pfc                string
+	pfcBackend         string
+	etsPrioTC          string
+	etsTCBW            string
+	ets                *etsConfig
+	dscpPriorities     string
+	dscp               []dcbApp
 	metricsBindAddress string
+	policy             string
+	nodeName           string
+	routedPrefix       int
+	routeDestinations  string
+	routeDsts          []*net.IPNet
+	addressFormat      string
+	addressRegex       string
+	addressOrgTLV      string
+	addressPlan        string
+	addressPool        string
+	vlan               int
+	l2AddressSource    string
+	stateFile          string
+	dryRun             bool
+	planFormat         string
+	report             string
+	verifyLLDP         bool
+	minReadyPorts      int
+	readiness          string
+
+	lldpTransmit         bool
+	lldpTransmitInterval time.Duration
 }
 
 func sanitizeInput(config *cmdConfig) error {
@@ -87,6 +117,102 @@ func sanitizeInput(config *cmdConfig) error {
 		return fmt.Errorf("Invalid PFC configuration: %v", err)
 	}
 
+	config.ets, err = VerifyETSArguments(config.etsPrioTC, config.etsTCBW)
+	if err != nil {
+		return fmt.Errorf("Invalid ETS configuration: %v", err)
+	}
+
+	config.dscp, err = VerifyDSCPArgument(config.dscpPriorities)
+	if err != nil {
+		return fmt.Errorf("Invalid DSCP priorities: %v", err)
+	}
+
+	switch config.pfcBackend {
+	case "":
+		config.pfcBackend = pfcBackendLLDPTool
+	case pfcBackendLLDPTool, pfcBackendNetlink:
+	default:
+		return fmt.Errorf("Invalid PFC backend '%s'", config.pfcBackend)
+	}
+
+	if config.routedPrefix < 0 || config.routedPrefix > 128 {
+		return fmt.Errorf("Invalid routed prefix length %d", config.routedPrefix)
+	}
+
+	if config.vlan != 0 && (config.vlan < minVLAN || config.vlan > maxVLAN) {
+		return fmt.Errorf("Invalid VLAN ID %d", config.vlan)
+	}
+
+	if config.vlan != 0 && config.mode != L3 {
+		return fmt.Errorf("VLAN requires mode %s", L3)
+	}
+
+	if config.minReadyPorts < 0 {
+		return fmt.Errorf("Invalid minimum number of ready ports %d", config.minReadyPorts)
+	}
+
+	if config.readiness != "" && config.readiness != readinessNFD && config.readiness != readinessNode {
+		return fmt.Errorf("Invalid readiness '%s'", config.readiness)
+	}
+
+	lldpAddressParser, err = newAddressParser(config.addressFormat, config.addressRegex, config.addressOrgTLV)
+	if err != nil {
+		return fmt.Errorf("Invalid LLDP address format: %v", err)
+	}
+
+	if config.planFormat != planFormatJSON && config.planFormat != planFormatYAML {
+		return fmt.Errorf("Invalid plan format '%s'", config.planFormat)
+	}
+
+	switch config.l2AddressSource {
+	case "":
+	case l2AddressStatic, l2AddressDHCP, l2AddressLinkLocal:
+		if config.mode != L2 {
+			return fmt.Errorf("L2 address source requires mode %s", L2)
+		}
+		if config.l2AddressSource == l2AddressStatic && config.addressPlan == "" {
+			return fmt.Errorf("Static L2 addresses require an address plan")
+		}
+		if config.l2AddressSource != l2AddressStatic && config.addressPlan != "" {
+			return fmt.Errorf("Address plan requires the %s L2 address source, not %s", l2AddressStatic, config.l2AddressSource)
+		}
+	default:
+		return fmt.Errorf("Invalid L2 address source '%s'", config.l2AddressSource)
+	}
+
+	if config.addressPlan != "" && config.addressPool != "" {
+		return fmt.Errorf("Address plan and address pool are mutually exclusive")
+	}
+
+	if config.addressPlan != "" {
+		if config.mode != L3 && config.l2AddressSource != l2AddressStatic {
+			return fmt.Errorf("Address plan requires mode %s or the %s L2 address source", L3, l2AddressStatic)
+		}
+		if config.nodeName == "" {
+			return fmt.Errorf("Address plan requires the node name")
+		}
+	}
+
+	if config.addressPool != "" {
+		if config.mode != L3 {
+			return fmt.Errorf("Address pool requires mode %s", L3)
+		}
+		if config.nodeName == "" {
+			return fmt.Errorf("Address pool requires the node name")
+		}
+	}
+
+	config.routeDsts = nil
+	if config.routeDestinations != "" {
+		for _, dst := range strings.Split(config.routeDestinations, ",") {
+			_, dstNet, err := net.ParseCIDR(strings.TrimSpace(dst))
+			if err != nil {
+				return fmt.Errorf("Invalid route destination '%s': %v", dst, err)
+			}
+			config.routeDsts = append(config.routeDsts, dstNet)
+		}
+	}
+
 	return nil
 }
 
@@ -95,13 +221,6 @@ func detectLLDP(config *cmdConfig, networkConfigs map[string]*networkConfigurati
 	lldpResultChan := make(chan lldp.DiscoveryResult, len(networkConfigs))
 	timeoutctx, cancelctx := context.WithTimeout(config.ctx, config.timeout)
 
-	filterFunc := func(portDescription string) bool {
-		// Check if the port description field has the correct data in it
-		_, _, err := parseIPFromString(portDescription)
-
-		return err == nil
-	}
-
 	defer cancelctx()
 
 	for _, networkconfig := range networkConfigs {
@@ -114,7 +233,7 @@ func detectLLDP(config *cmdConfig, networkConfigs map[string]*networkConfigurati
 		wg.Add(1)
 		go func() {
 			lldpClient := lldp.NewClient(timeoutctx, networkconfig.link.Attrs().Name, *networkconfig.localHwAddr)
-			if err := lldpClient.Start(lldpResultChan, filterFunc); err != nil {
+			if err := lldpClient.Start(lldpResultChan, lldpAddressFilter); err != nil {
 				klog.Infof("Cannot start LLDP client: %v\n", err)
 			}
 			wg.Done()
@@ -129,10 +248,7 @@ func detectLLDP(config *cmdConfig, networkConfigs map[string]*networkConfigurati
 		result := <-lldpResultChan
 
 		if nwconfig, exists := networkConfigs[result.InterfaceName]; exists {
-			nwconfig.portDescription = result.PortDescription
-
-			var hwaddr net.HardwareAddr = result.PeerMAC
-			nwconfig.peerHWAddr = &hwaddr
+			setLLDPInfo(nwconfig, result)
 		}
 	}
 }
@@ -170,6 +286,43 @@ func initializeInterfaces(config *cmdConfig, networkConfigs map[string]*networkC
 	return nil
 }
 
+// configureRequiredInterfaces configures the interface addresses and
+// checks that enough interfaces were configured.
+func configureRequiredInterfaces(config *cmdConfig, networkConfigs map[string]*networkConfiguration) error {
+	numConfigured, numTotal := configureInterfaces(networkConfigs)
+	if required := requiredReadyPorts(config, numTotal); numConfigured < required {
+		if required < numTotal {
+			return fmt.Errorf("Not enough interfaces were configured (%d/%d, %d required).", numConfigured, numTotal, required)
+		}
+		return fmt.Errorf("Not all interfaces were configured (%d/%d).", numConfigured, numTotal)
+	}
+	klog.Infof("Configured %d of %d interfaces\n", numConfigured, numTotal)
+
+	return nil
+}
+
+// configureL2Addresses configures the interface addresses from the L2
+// address source and writes them to gaudinet.json.
+func configureL2Addresses(config *cmdConfig, networkConfigs map[string]*networkConfiguration) error {
+	if _, err := l2AddressResults(config, networkConfigs); err != nil {
+		return err
+	}
+
+	if err := configureRequiredInterfaces(config, networkConfigs); err != nil {
+		return err
+	}
+
+	setGatewayMACs(config, networkConfigs)
+
+	if config.gaudinetfile != "" {
+		if err := WriteGaudiNet(config.gaudinetfile, networkConfigs); err != nil {
+			klog.Errorf("Error: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
 func writeL3Configuration(config *cmdConfig, networkConfigs map[string]*networkConfiguration) error {
 	if config.gaudinetfile != "" {
 		if err := WriteGaudiNet(config.gaudinetfile, networkConfigs); err != nil {
@@ -186,53 +339,140 @@ func writeL3Configuration(config *cmdConfig, networkConfigs map[string]*networkC
 	return nil
 }
 
-func postCleanups(config *cmdConfig, networkConfigs map[string]*networkConfiguration) {
+func postCleanups(config *cmdConfig, reporter *nodeStateReporter, node *nodeReadiness, networkConfigs map[string]*networkConfiguration) {
 	klog.Info("Clean up before exiting...")
 
-	err := os.Remove(nfdLabelFile)
-	if err != nil {
+	// the operator taints the node asynchronously, Pods may still be
+	// scheduled to it while the interfaces go down
+	reporter.report(config, networkConfigs, networkv1alpha1.NodeStateStopped, nil)
+
+	if err := removeReadinessLabel(); err != nil {
 		klog.Warningf("Failed to remove NFD label file: %+v\n", err)
 	}
 
+	if err := node.remove(config); err != nil {
+		klog.Warning(err.Error())
+	}
+
 	if config.pfc != "" {
-		if err := DisableAllPFC(networkConfigs); err != nil {
+		if err := DisableAllPFC(config, networkConfigs); err != nil {
 			klog.Warningf("Failed to disable LLDP PFC on all interfaces: %v", err)
 		}
 	}
 
-	klog.Infof("Restoring interfaces to original state...")
-	if err := removeExistingIPs(networkConfigs); err != nil {
-		klog.Warningf("Failed to remove any existing IPs from interfaces: %+v\n", err)
+	if config.ets != nil {
+		if err := RestoreAllETS(config, networkConfigs); err != nil {
+			klog.Warningf("Failed to restore default ETS on all interfaces: %v", err)
+		}
 	}
 
-	if err := interfacesRestoreDown(networkConfigs); err != nil {
-		klog.Warningf("Failed to restore interfaces to original state: %+v\n", err)
+	if len(config.dscp) > 0 {
+		if err := RevertAllDSCP(config, networkConfigs); err != nil {
+			klog.Warningf("Failed to revert DSCP priorities on all interfaces: %v", err)
+		}
 	}
-}
 
-func cmdRun(config *cmdConfig) error {
-	metrics := make(chan error, 1)
-	err := sanitizeInput(config)
-	if err != nil {
-		return err
+	if config.networkd != "" {
+		DeleteSystemdNetworkdVLANs(config.networkd, networkConfigs)
+	}
+
+	restoreInterfaces(networkConfigs)
+
+	if config.stateFile != "" {
+		removeCheckpoint(config.stateFile)
 	}
+}
 
+// prepareNode cleans up after any previous agent before the interfaces
+// are changed.
+func prepareNode(config *cmdConfig, node *nodeReadiness) error {
 	if err := preCleanups(config); err != nil {
 		return fmt.Errorf("Failed to pre-cleanup: %v", err)
 	}
 
-	if config.pfc != "" {
+	if err := node.remove(config); err != nil {
+		klog.Warning(err.Error())
+	}
+
+	if (config.pfc != "" || config.ets != nil || len(config.dscp) > 0) && config.pfcBackend == pfcBackendLLDPTool {
 		if err := LookupLLDPTool(); err != nil {
 			return fmt.Errorf("Could not find lldptool: %v", err)
 		}
 	}
 
+	// a previous agent was killed before it could clean up
+	if config.stateFile != "" {
+		if err := restoreCheckpoint(config.stateFile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func disableNetworkManager(interfaces []string) error {
+	nmapi, err := nm.NewNetworkManager()
+	if err != nil {
+		return fmt.Errorf("Failed to create NetworkManager: %v", err)
+	}
+
+	if err := nm.DisableNetworkManagerForInterfaces(nmapi, interfaces); err != nil {
+		return fmt.Errorf("Failed to disable interfaces in NetworkManager: %v", err)
+	}
+
+	return nil
+}
+
+// saveCheckpoint saves the original interface state to the state file,
+// if any.
+func saveCheckpoint(config *cmdConfig, networkConfigs map[string]*networkConfiguration) error {
+	if config.stateFile == "" || config.dryRun {
+		return nil
+	}
+
+	return writeCheckpoint(config.stateFile, networkConfigs)
+}
+
+func cmdRun(config *cmdConfig) (err error) {
+	var networkConfigs map[string]*networkConfiguration
+
+	metrics := make(chan error, 1)
+	err = sanitizeInput(config)
+	if err != nil {
+		return err
+	}
+
+	reporter, reporterErr := newNodeStateReporter(config)
+	if reporterErr != nil {
+		klog.Warningf("Cannot report node state: %v", reporterErr)
+	}
+
+	defer func() {
+		if err != nil {
+			reporter.report(config, networkConfigs, networkv1alpha1.NodeStateFailed, err)
+			writeReport(config, networkConfigs, networkv1alpha1.NodeStateFailed, err)
+		}
+	}()
+
+	node, err := newNodeReadiness(config)
+	if err != nil {
+		return fmt.Errorf("Cannot publish readiness on the Node: %v", err)
+	}
+
+	if !config.dryRun {
+		if err := prepareNode(config, node); err != nil {
+			return err
+		}
+	}
+
 	interfaces := []string{}
 	if len(config.ifaces) > 0 {
 		interfaces = strings.Split(config.ifaces, ",")
 	}
 
-	allinterfaces, networkConfigs, err := getNetworkConfigs(interfaces)
+	var allinterfaces []string
+
+	allinterfaces, networkConfigs, err = getNetworkConfigs(interfaces)
 	if err != nil {
 		return err
 	}
@@ -240,15 +480,29 @@ func cmdRun(config *cmdConfig) error {
 		return fmt.Errorf("No interfaces found")
 	}
 
-	if config.disableNM {
-		nmapi, err := nm.NewNetworkManager()
-		if err != nil {
-			return fmt.Errorf("Failed to create NetworkManager: %v", err)
-		}
+	setVLANs(config, networkConfigs)
 
-		err = nm.DisableNetworkManagerForInterfaces(nmapi, allinterfaces)
-		if err != nil {
-			return fmt.Errorf("Failed to disable interfaces in NetworkManager: %v", err)
+	if err := saveOriginalAddresses(networkConfigs); err != nil {
+		return fmt.Errorf("Failed to save the original interface addresses: %v", err)
+	}
+
+	if err := saveCheckpoint(config, networkConfigs); err != nil {
+		return err
+	}
+
+	var plan *dryRunPlan
+	if config.dryRun {
+		plan = startDryRun(networkConfigs)
+		defer func() {
+			err = plan.finish(config, err)
+		}()
+	}
+
+	if config.disableNM {
+		if plan != nil {
+			plan.record("", "disable-networkmanager", strings.Join(allinterfaces, ","))
+		} else if err := disableNetworkManager(allinterfaces); err != nil {
+			return err
 		}
 	}
 
@@ -256,18 +510,45 @@ func cmdRun(config *cmdConfig) error {
 		return err
 	}
 
-	startMetricsServer(config, metrics, networkConfigs)
+	transmitter := startLLDPTransmit(config, networkConfigs)
+	defer transmitter.stop()
+
+	exporter := startMetricsServer(config, metrics, networkConfigs)
 
 	if config.mode == L3 {
-		detectLLDP(config, networkConfigs)
-		foundpeers := lldpResults(networkConfigs)
+		var foundpeers bool
+
+		if config.addressPlan != "" {
+			if foundpeers, err = addressPlanResults(config, networkConfigs); err != nil {
+				return err
+			}
+		} else if config.addressPool != "" {
+			if foundpeers, err = addressPoolResults(config, reporter, networkConfigs); err != nil {
+				return err
+			}
+		} else {
+			detectLLDP(config, networkConfigs)
+			foundpeers = lldpResults(networkConfigs)
+		}
+		if err := setRoutedNetworks(config, networkConfigs); err != nil {
+			return err
+		}
+		transmitter.update(config, networkConfigs)
+
+		if config.configure {
+			if err := createVLANs(config, networkConfigs); err != nil {
+				return fmt.Errorf("Failed to create VLAN interfaces: %v", err)
+			}
+
+			if err := saveCheckpoint(config, networkConfigs); err != nil {
+				return err
+			}
+		}
 
 		if config.configure && foundpeers {
-			numConfigured, numTotal := configureInterfaces(networkConfigs)
-			if numConfigured < numTotal {
-				return fmt.Errorf("Not all interfaces were configured (%d/%d).", numConfigured, numTotal)
+			if err := configureRequiredInterfaces(config, networkConfigs); err != nil {
+				return err
 			}
-			klog.Infof("Configured %d of %d interfaces\n", numConfigured, numTotal)
 		}
 
 		if err := writeL3Configuration(config, networkConfigs); err != nil {
@@ -275,9 +556,22 @@ func cmdRun(config *cmdConfig) error {
 		}
 	}
 
+	if config.mode == L2 && config.l2AddressSource != "" && config.configure {
+		if err := configureL2Addresses(config, networkConfigs); err != nil {
+			return err
+		}
+	}
+
+	exporter.update(networkConfigs)
+
 	logResults(config, networkConfigs)
 
 	if !config.configure {
+		reporter.report(config, networkConfigs, networkv1alpha1.NodeStateDiscovered, nil)
+		writeReport(config, networkConfigs, networkv1alpha1.NodeStateDiscovered, nil)
+
+		transmitter.stop()
+
 		if err := interfacesRestoreDown(networkConfigs); err != nil {
 			return err
 		}
@@ -289,9 +583,9 @@ func cmdRun(config *cmdConfig) error {
 		var err error
 
 		if config.pfc == pfcDisable {
-			err = DisableAllPFC(networkConfigs)
+			err = DisableAllPFC(config, networkConfigs)
 		} else {
-			err = EnableAllPFC(config.pfc, networkConfigs)
+			err = EnableAllPFC(config, networkConfigs)
 		}
 
 		if err != nil {
@@ -299,30 +593,76 @@ func cmdRun(config *cmdConfig) error {
 		}
 	}
 
-	if config.keepRunning {
-		if s, err := os.Stat(nfdFeatureDir); err == nil && s.IsDir() {
-			content := nfdScaleOutReadyLabel + "\n"
+	if config.ets != nil {
+		if err := EnableAllETS(config, networkConfigs); err != nil {
+			return fmt.Errorf("Failed to configure ETS: %v", err)
+		}
+	}
 
-			if err := os.WriteFile(nfdLabelFile, []byte(content), 0644); err != nil {
-				return fmt.Errorf("Failed to write NFD label to indicate scale-out readiness: %+v\n", err)
-			}
+	if len(config.dscp) > 0 {
+		if err := EnableAllDSCP(config, networkConfigs); err != nil {
+			return fmt.Errorf("Failed to configure DSCP priorities: %v", err)
+		}
+	}
+
+	if plan != nil {
+		return nil
+	}
+
+	reporter.report(config, networkConfigs, networkv1alpha1.NodeStateConfigured, nil)
+	writeReport(config, networkConfigs, networkv1alpha1.NodeStateConfigured, nil)
+
+	if config.keepRunning {
+		if _, err := publishReadiness(config, node, networkConfigs, nil); err != nil {
+			return err
 		}
 
 		klog.Infof("Configurations done. Idling...")
 
-		defer postCleanups(config, networkConfigs)
+		defer postCleanups(config, reporter, node, networkConfigs)
+
+		renewer := startDHCPRenewer(networkConfigs)
+		defer renewer.stop()
+
+		monitor := startLLDPMonitor(config, networkConfigs)
+		defer monitor.stop()
+
+		watcher := startLinkWatcher(node)
+		defer watcher.stop()
 
 		term := make(chan os.Signal, 1)
 		signal.Notify(term, os.Interrupt, syscall.SIGTERM)
 
-		select {
-		case <-term:
-			klog.Infof("Exited")
-		case err := <-metrics:
-			klog.Fatalf("Metrics server returned: %v", err)
-			return err
+		for {
+			select {
+			case <-term:
+				klog.Infof("Exited")
+				return nil
+			case err := <-metrics:
+				klog.Fatalf("Metrics server returned: %v", err)
+				return err
+			case result := <-monitor.updates():
+				handleLLDPUpdate(config, reporter, transmitter, exporter, networkConfigs, result)
+			case update, ok := <-watcher.linkUpdates():
+				if !ok {
+					klog.Warning("Link update subscription closed")
+					watcher.links = nil
+					continue
+				}
+				watcher.handleLinkUpdate(config, reporter, networkConfigs, update)
+				monitor.handleLinkUpdate(networkConfigs, update)
+				transmitter.handleLinkUpdate(networkConfigs, update)
+			case update, ok := <-watcher.addrUpdates():
+				if !ok {
+					klog.Warning("Address update subscription closed")
+					watcher.addrs = nil
+					continue
+				}
+				watcher.handleAddrUpdate(config, reporter, networkConfigs, update)
+			case ifname := <-renewer.expiredLeases():
+				watcher.handleLeaseExpiry(config, reporter, node, networkConfigs, ifname)
+			}
 		}
-
 	}
 
 	return nil
@@ -348,6 +688,25 @@ func setupCmd() (*cobra.Command, error) {
 	cmd.Flags().AddGoFlagSet(&fs)
 	cmd.Flags().SortFlags = false
 
+	restore := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the interfaces to the original state saved in the state file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if config.stateFile == "" {
+				return fmt.Errorf("No state file given")
+			}
+
+			return restoreCheckpoint(config.stateFile)
+		},
+	}
+	restore.Flags().AddGoFlagSet(&fs)
+	restore.Flags().StringVarP(&config.stateFile, "state-file", "", "",
+		"State file with the original interface state")
+
+	cmd.AddCommand(restore)
+
 	cmd.Flags().StringVarP(&config.mode, "mode", "", L3,
 		"'L2' for network layer 2 or 'L3' for network layer 3 (L3) using LLDP")
 	cmd.Flags().BoolVarP(&config.configure, "configure", "", false,
@@ -368,8 +727,56 @@ func setupCmd() (*cobra.Command, error) {
 		"MTU value to set for interfaces")
 	cmd.Flags().StringVarP(&config.pfc, "pfc", "", "",
 		"Comma separated list of Priority Flow Control priorities (0-7) to enable")
+	cmd.Flags().StringVarP(&config.pfcBackend, "pfc-backend", "", pfcBackendLLDPTool,
+		"Configure PFC, ETS and DSCP priorities with 'lldptool', requiring lldpad, or natively over DCB netlink with 'netlink'")
+	cmd.Flags().StringVarP(&config.etsPrioTC, "ets-prio-tc", "", "",
+		"Comma separated list of the ETS traffic classes (0-7) of the priorities starting from priority 0")
+	cmd.Flags().StringVarP(&config.etsTCBW, "ets-tc-bw", "", "",
+		"Comma separated list of the ETS bandwidth percentages of the traffic classes starting from traffic class 0")
+	cmd.Flags().StringVarP(&config.dscpPriorities, "dscp-priorities", "", "",
+		"Comma separated list of 'dscp:priority' mappings to trust DSCP and classify traffic with")
 	cmd.Flags().StringVarP(&config.metricsBindAddress, "metrics-bind-address", "", "",
 		"Enable metrics exporter by specifying the address and/or port for the metrics endpoint.")
+	cmd.Flags().IntVarP(&config.routedPrefix, "routed-prefix", "", 0,
+		"Prefix length of the routed network reached through the switch port in L3 mode. Defaults to 16 for IPv4 and 64 for IPv6")
+	cmd.Flags().StringVarP(&config.routeDestinations, "route-destinations", "", "",
+		"Comma separated list of destination networks in CIDR notation to route through the switch port in L3 mode. Overrides --routed-prefix")
+	cmd.Flags().StringVarP(&config.addressFormat, "lldp-address-format", "", addressFormatNoAlert,
+		"Where to find the switch port address in LLDP: no-alert, ip-equals, sys-description, org-tlv or regex")
+	cmd.Flags().StringVarP(&config.addressRegex, "lldp-address-regex", "", "",
+		"Regular expression with one capture group matching the CIDR in the port or system description, for the regex format")
+	cmd.Flags().StringVarP(&config.addressOrgTLV, "lldp-org-tlv", "", "",
+		"Organizationally specific TLV OUI and subtype as 'aabbcc:N' carrying the CIDR as text, for the org-tlv format")
+	cmd.Flags().StringVarP(&config.addressPlan, "address-plan", "", "",
+		"Directory with a YAML address plan file per node name to use in L3 mode instead of the addresses advertised in LLDP")
+	cmd.Flags().StringVarP(&config.l2AddressSource, "l2-address-source", "", "",
+		"Where the interface addresses come from in L2 mode: 'static' from the address plan, 'dhcp' or 'link-local' for the IPv6 link-local addresses")
+	cmd.Flags().StringVarP(&config.addressPool, "address-pool", "", "",
+		"ScaleOutAddressPool to get the addresses allocated by the operator from in L3 mode instead of the addresses advertised in LLDP")
+	cmd.Flags().IntVarP(&config.vlan, "vlan", "", 0,
+		"802.1Q VLAN ID (1-4094) of the sub-interfaces to configure the addresses and routes on in L3 mode")
+	cmd.Flags().BoolVarP(&config.verifyLLDP, "verify-lldp", "", false,
+		"Verify the address plan against the addresses advertised in LLDP")
+	cmd.Flags().BoolVarP(&config.lldpTransmit, "lldp-transmit", "", false,
+		"Transmit LLDP frames with the node name and the interface module ID and address")
+	cmd.Flags().DurationVarP(&config.lldpTransmitInterval, "lldp-transmit-interval", "", lldp.DefaultTransmitInterval,
+		"Interval for transmitting LLDP frames")
+	cmd.Flags().IntVarP(&config.minReadyPorts, "min-ready-ports", "", 0,
+		"Minimum number of working interfaces for the node to be labeled ready. Defaults to all interfaces")
+	cmd.Flags().StringVarP(&config.readiness, "readiness", "", readinessNFD,
+		"Publish the scale-out readiness as NFD labels with 'nfd' or on the Node object with 'node'")
+	cmd.Flags().StringVarP(&config.policy, "policy", "", "",
+		"NetworkClusterPolicy name to report the node state for in a NetworkNodeState object")
+	cmd.Flags().StringVarP(&config.stateFile, "state-file", "", "",
+		"Save the original interface state to the given file to restore it after an unclean exit or with 'discover restore'")
+	cmd.Flags().BoolVarP(&config.dryRun, "dry-run", "", false,
+		"Discover the interfaces and print the planned changes without applying them")
+	cmd.Flags().StringVarP(&config.planFormat, "plan-format", "", planFormatJSON,
+		"Format of the dry run plan: 'json' or 'yaml'")
+	cmd.Flags().StringVarP(&config.report, "report", "", "",
+		"Write a JSON report of the interfaces and their configuration to the given file, or to the standard output with '-'")
+	cmd.Flags().StringVarP(&config.nodeName, "node-name", "", os.Getenv("NODE_NAME"),
+		"Node name for the NetworkNodeState object")
 
 	return cmd, nil
 }
@@ -30,6 +30,9 @@ import (
 const (
 	defaultPrefix     = "gaudi_scaleout_"
 	defaultMetricsURL = "/metrics"
+
+	linkInfoName = "link_info"
+	linkInfoDesc = "Switch and switch port of the scale-out network link as received via LLDP"
 )
 
 type ethStats struct {
@@ -61,34 +64,57 @@ var (
 	}
 )
 
-func newNetworkMetricsInfo(moduleid string, macaddr string, ifname string, stats *ethStats) networkMetricsInfo {
+func staticLabels(moduleid string, macaddr string, ifname string) prometheus.Labels {
 	staticlabels := prometheus.Labels{"macaddr": strings.ToLower(macaddr), "ifname": ifname}
 	if moduleid != "" {
 		staticlabels["moduleid"] = moduleid
 	}
 
+	return staticlabels
+}
+
+func newNetworkMetricsInfo(moduleid string, macaddr string, ifname string, stats *ethStats) networkMetricsInfo {
 	return networkMetricsInfo{
 		stats: stats,
 		prometheusDesc: prometheus.NewDesc(
 			defaultPrefix+stats.statisticsName,
 			stats.statisticsDesc,
 			nil,
-			staticlabels,
+			staticLabels(moduleid, macaddr, ifname),
 		),
 	}
 }
 
+// switchInfo is the LLDP peer of an interface exported in the link info
+// metric.
+type switchInfo struct {
+	sysName string
+	portID  string
+}
+
 type Exporter struct {
-	mutex   sync.RWMutex
-	metrics map[string][]networkMetricsInfo
+	mutex    sync.RWMutex
+	metrics  map[string][]networkMetricsInfo
+	linkInfo map[string]*prometheus.Desc
+	switches map[string]switchInfo
 }
 
 func newExporter(networkConfigs map[string]*networkConfiguration) *Exporter {
 	e := Exporter{
-		metrics: make(map[string][]networkMetricsInfo),
+		metrics:  make(map[string][]networkMetricsInfo),
+		linkInfo: make(map[string]*prometheus.Desc),
+		switches: make(map[string]switchInfo),
 	}
 
 	for ifname, nwconfig := range networkConfigs {
+		e.linkInfo[ifname] = prometheus.NewDesc(
+			defaultPrefix+linkInfoName,
+			linkInfoDesc,
+			[]string{"switch_name", "switch_port_id"},
+			staticLabels(nwconfig.moduleId, nwconfig.localHwAddr.String(), ifname),
+		)
+		e.switches[ifname] = switchInfo{sysName: nwconfig.sysName, portID: nwconfig.portID}
+
 		var metricsInfo []networkMetricsInfo
 
 		for _, stats := range networkStatistics {
@@ -105,12 +131,33 @@ func newExporter(networkConfigs map[string]*networkConfiguration) *Exporter {
 	return &e
 }
 
+// update refreshes the switch information of the link info metrics from
+// the interface configurations.
+func (e *Exporter) update(networkConfigs map[string]*networkConfiguration) {
+	if e == nil {
+		return
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for ifname, nwconfig := range networkConfigs {
+		if _, exists := e.switches[ifname]; exists {
+			e.switches[ifname] = switchInfo{sysName: nwconfig.sysName, portID: nwconfig.portID}
+		}
+	}
+}
+
 func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	for _, m := range e.metrics {
 		for _, i := range m {
 			ch <- i.prometheusDesc
 		}
 	}
+
+	for _, desc := range e.linkInfo {
+		ch <- desc
+	}
 }
 
 func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
@@ -134,15 +181,22 @@ func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 				float64(sum))
 		}
 	}
+
+	for ifname, desc := range e.linkInfo {
+		peer := e.switches[ifname]
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, peer.sysName, peer.portID)
+	}
 }
 
-func startMetricsServer(config *cmdConfig, res chan<- error, networkConfigs map[string]*networkConfiguration) {
+func startMetricsServer(config *cmdConfig, res chan<- error, networkConfigs map[string]*networkConfiguration) *Exporter {
 	if config.metricsBindAddress == "" {
-		return
+		return nil
 	}
 
+	exporter := newExporter(networkConfigs)
+
 	registry := prometheus.NewRegistry()
-	registry.MustRegister(newExporter(networkConfigs))
+	registry.MustRegister(exporter)
 
 	server := http.NewServeMux()
 	server.Handle(defaultMetricsURL, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
@@ -151,4 +205,6 @@ func startMetricsServer(config *cmdConfig, res chan<- error, networkConfigs map[
 		klog.Infof("Enabled metrics endpoint '%s'", hostPort)
 		res <- http.ListenAndServe(hostPort, server)
 	}(server, config.metricsBindAddress, res)
+
+	return exporter
 }
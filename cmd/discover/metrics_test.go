@@ -0,0 +1,87 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// linkInfoLabels returns the labels of the link info metric of each
+// interface.
+func linkInfoLabels(t *testing.T, e *Exporter) map[string]map[string]string {
+	ch := make(chan prometheus.Metric, 100)
+	e.Collect(ch)
+	close(ch)
+
+	labels := map[string]map[string]string{}
+	for metric := range ch {
+		if !strings.Contains(metric.Desc().String(), defaultPrefix+linkInfoName) {
+			continue
+		}
+
+		m := &dto.Metric{}
+		if err := metric.Write(m); err != nil {
+			t.Fatalf("cannot write metric: %v", err)
+		}
+		if m.GetGauge().GetValue() != 1 {
+			t.Errorf("unexpected link info value %v", m.GetGauge().GetValue())
+		}
+
+		l := map[string]string{}
+		for _, pair := range m.GetLabel() {
+			l[pair.GetName()] = pair.GetValue()
+		}
+		labels[l["ifname"]] = l
+	}
+
+	return labels
+}
+
+func TestExporterLinkInfo(t *testing.T) {
+	nwconfigs := getFakeNetworkDataConfigs()
+	for _, nwconfig := range nwconfigs {
+		hwaddr := nwconfig.link.Attrs().HardwareAddr
+		nwconfig.localHwAddr = &hwaddr
+	}
+	nwconfigs["eth_a"].sysName = "switch-a"
+	nwconfigs["eth_a"].portID = "swp1"
+
+	e := newExporter(nwconfigs)
+
+	labels := linkInfoLabels(t, e)
+	if len(labels) != len(nwconfigs) {
+		t.Fatalf("expected link info of %d interfaces, got %v", len(nwconfigs), labels)
+	}
+	if labels["eth_a"]["switch_name"] != "switch-a" || labels["eth_a"]["switch_port_id"] != "swp1" {
+		t.Errorf("unexpected link info labels %v", labels["eth_a"])
+	}
+
+	nwconfigs["eth_a"].portID = "swp2"
+	e.update(nwconfigs)
+
+	if labels := linkInfoLabels(t, e); labels["eth_a"]["switch_port_id"] != "swp2" {
+		t.Errorf("link info not updated: %v", labels["eth_a"])
+	}
+
+	// no exporter without metrics
+	var none *Exporter
+	none.update(nwconfigs)
+}
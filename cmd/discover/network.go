@@ -28,6 +28,8 @@ import (
 	"github.com/vishvananda/netlink"
 	"golang.org/x/sys/unix"
 	"k8s.io/klog/v2"
+
+	"github.com/intel/network-operator/pkg/lldp"
 )
 
 const (
@@ -48,10 +50,15 @@ type networkLinkFn struct {
 	AddrAdd       func(link netlink.Link, addr *netlink.Addr) error
 	AddrDel       func(link netlink.Link, addr *netlink.Addr) error
 	LinkSubscribe func(ch chan<- netlink.LinkUpdate, done <-chan struct{}) error
+	AddrSubscribe func(ch chan<- netlink.AddrUpdate, done <-chan struct{}) error
 	RouteAppend   func(route *netlink.Route) error
 	LinkSetUp     func(link netlink.Link) error
 	LinkSetDown   func(link netlink.Link) error
 	LinkSetMTU    func(link netlink.Link, mtu int) error
+	LinkAdd       func(link netlink.Link) error
+	LinkDel       func(link netlink.Link) error
+	NeighList     func(linkIndex, family int) ([]netlink.Neigh, error)
+	RouteList     func(link netlink.Link, family int) ([]netlink.Route, error)
 }
 
 var networkLink = networkLinkFn{
@@ -60,22 +67,49 @@ var networkLink = networkLinkFn{
 	AddrAdd:       netlink.AddrAdd,
 	AddrDel:       netlink.AddrDel,
 	LinkSubscribe: netlink.LinkSubscribe,
+	AddrSubscribe: netlink.AddrSubscribe,
 	RouteAppend:   netlink.RouteAppend,
 	LinkSetUp:     netlink.LinkSetUp,
 	LinkSetDown:   netlink.LinkSetDown,
 	LinkSetMTU:    netlink.LinkSetMTU,
+	LinkAdd:       netlink.LinkAdd,
+	LinkDel:       netlink.LinkDel,
+	NeighList:     netlink.NeighList,
+	RouteList:     netlink.RouteList,
 }
 
 type networkConfiguration struct {
+	pciAddress      string
 	moduleId        string
 	link            netlink.Link
 	origState       net.Flags
+	origMTU         int
+	origAddrs       []netlink.Addr
+	origRoutes      []netlink.Route
 	expectResponse  bool
 	portDescription string
+	portID          string
+	sysName         string
+	sysDescription  string
+	orgTLVs         []lldp.OrgSpecificTLV
 	lldpPeer        *net.IP
 	localAddr       *net.IP
+	localPrefixLen  int
+	routedPrefixLen int
+	routeDsts       []*net.IPNet
+	planRouteDsts   []*net.IPNet
 	peerHWAddr      *net.HardwareAddr
 	localHwAddr     *net.HardwareAddr
+	configured      bool
+	origAppTrust    []uint8
+	appTrustSet     bool
+	vlanID          int
+	vlanLink        netlink.Link
+	vlanCreated     bool
+	gateway         *net.IP
+	dhcpLease       *dhcpLease
+	// reconfiguration on changed LLDP information has not succeeded yet
+	reconfigurePending bool
 }
 
 func getSysfsRoot() string {
@@ -129,16 +163,18 @@ func getModuleIds() (map[string]string, error) {
 	return moduleIds, nil
 }
 
-func newNetworkConfiguration(ifname string, moduleId string) (*networkConfiguration, error) {
+func newNetworkConfiguration(ifname string, pciAddress string, moduleId string) (*networkConfiguration, error) {
 	link, err := networkLink.LinkByName(ifname)
 	if err != nil {
 		return nil, fmt.Errorf("Link '%s' not found: %v", ifname, err)
 	}
 
 	return &networkConfiguration{
+		pciAddress:  pciAddress,
 		moduleId:    moduleId,
 		link:        link,
 		origState:   link.Attrs().Flags,
+		origMTU:     link.Attrs().MTU,
 		localHwAddr: &link.Attrs().HardwareAddr,
 	}, nil
 }
@@ -186,7 +222,7 @@ func getNetworkConfigs(interfaces []string) ([]string, map[string]*networkConfig
 
 		for _, n := range netdevices {
 			ifname := filepath.Base(n)
-			nwconfig, err := newNetworkConfiguration(ifname, id)
+			nwconfig, err := newNetworkConfiguration(ifname, pciaddr, id)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -201,7 +237,7 @@ func getNetworkConfigs(interfaces []string) ([]string, map[string]*networkConfig
 			continue
 		}
 
-		nwconfig, err := newNetworkConfiguration(ifname, "")
+		nwconfig, err := newNetworkConfiguration(ifname, "", "")
 		if err != nil {
 			return nil, nil, err
 		}
@@ -222,40 +258,110 @@ func parseIPFromString(portDescription string) (net.IP, *net.IPNet, error) {
 	return net.ParseCIDR(substrings[1])
 }
 
-func selectMask30L3Address(nwconfig *networkConfiguration) (*net.IP, *net.IP, error) {
-	var (
-		peerNetwork *net.IPNet
-		peeraddr    net.IP
-		localaddr   net.IP
-		err         error
-	)
+// lldpInfo returns the LLDP information received on the interface.
+func lldpInfo(nwconfig *networkConfiguration) lldp.DiscoveryResult {
+	return lldp.DiscoveryResult{
+		InterfaceName:   nwconfig.link.Attrs().Name,
+		SysName:         nwconfig.sysName,
+		SysDescription:  nwconfig.sysDescription,
+		PortDescription: nwconfig.portDescription,
+		PortID:          nwconfig.portID,
+		OrgTLVs:         nwconfig.orgTLVs,
+	}
+}
+
+// setLLDPInfo stores the LLDP information received on the interface.
+func setLLDPInfo(nwconfig *networkConfiguration, result lldp.DiscoveryResult) {
+	nwconfig.portDescription = result.PortDescription
+	nwconfig.portID = result.PortID
+	nwconfig.sysName = result.SysName
+	nwconfig.sysDescription = result.SysDescription
+	nwconfig.orgTLVs = result.OrgTLVs
+
+	var hwaddr net.HardwareAddr = result.PeerMAC
+	nwconfig.peerHWAddr = &hwaddr
+}
+
+// lldpAddressFilter returns true if the LLDP information has the address
+// in it.
+func lldpAddressFilter(result lldp.DiscoveryResult) bool {
+	_, _, err := lldpAddressParser(result)
+
+	return err == nil
+}
+
+// localCIDR returns the local address with the point-to-point prefix
+// length, or an empty string if there is no address.
+func localCIDR(nwconfig *networkConfiguration) string {
+	if nwconfig.localAddr == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%d", nwconfig.localAddr, prefixLength(nwconfig, RouteMaskPointToPoint))
+}
+
+func isIPv6(ip net.IP) bool {
+	return ip.To4() == nil
+}
 
-	peeraddr, peerNetwork, err = parseIPFromString(nwconfig.portDescription)
+// otherPointToPointAddress returns the other address of a point-to-point
+// network. IPv4 /30 as well as IPv6 /127 and /126 networks are supported.
+func otherPointToPointAddress(addr net.IP, network *net.IPNet) (net.IP, error) {
+	var other net.IP
+
+	mask, bits := network.Mask.Size()
+	switch {
+	case bits == 32 && mask == 30:
+		// toggle the lowest two bits of the IPv4 address
+		ip := addr.To4()
+		other = net.IPv4(ip[0], ip[1], ip[2], ip[3]^0x3)
+	case bits == 128 && mask == 127:
+		// toggle the lowest bit of the IPv6 address
+		other = make(net.IP, net.IPv6len)
+		copy(other, addr.To16())
+		other[net.IPv6len-1] ^= 0x1
+	case bits == 128 && mask == 126:
+		// like IPv4 /30, toggle the lowest two bits of the IPv6 address
+		other = make(net.IP, net.IPv6len)
+		copy(other, addr.To16())
+		other[net.IPv6len-1] ^= 0x3
+	default:
+		return nil, fmt.Errorf("mask is %d, not the expected 30 (IPv4) or 127 or 126 (IPv6)", mask)
+	}
+
+	return other, nil
+}
+
+// selectPointToPointL3Address derives the local address from the peer
+// address and point-to-point network advertised in the LLDP port
+// description. Returns the peer and local addresses and the prefix length.
+func selectPointToPointL3Address(nwconfig *networkConfiguration) (*net.IP, *net.IP, int, error) {
+	peeraddr, peerNetwork, err := lldpAddressParser(lldpInfo(nwconfig))
 	if err != nil {
-		return nil, nil, fmt.Errorf("interface '%s' could not parse CIDR from port description '%s': %v",
+		return nil, nil, 0, fmt.Errorf("interface '%s' could not parse CIDR from port description '%s': %v",
 			nwconfig.link.Attrs().Name, nwconfig.portDescription, err)
 	}
 
 	mask, _ := peerNetwork.Mask.Size()
-	if mask == 30 {
-		// toggle the lowest two bits of the switch IPv4 address to get
-		// the local address
-		peer := peeraddr.To4()
-		localaddr = net.IPv4(peer[0], peer[1], peer[2], peer[3]^0x3)
-	} else {
-		err = fmt.Errorf("interface '%s' mask is %d, not the expected 30",
-			nwconfig.link.Attrs().Name, mask)
+
+	localaddr, err := otherPointToPointAddress(peeraddr, peerNetwork)
+	if err != nil {
+		err = fmt.Errorf("interface '%s' %v", nwconfig.link.Attrs().Name, err)
 	}
 
-	return &peeraddr, &localaddr, err
+	return &peeraddr, &localaddr, mask, err
 }
 
 func logResults(config *cmdConfig, networkConfigs map[string]*networkConfiguration) {
 	for _, nwconfig := range networkConfigs {
 		klog.V(3).Infof("Interface '%s' %s:", nwconfig.link.Attrs().Name, nwconfig.link.Attrs().Flags)
 
+		if nwconfig.vlanLink != nil {
+			klog.V(3).Infof("\tVLAN interface '%s'", nwconfig.vlanLink.Attrs().Name)
+		}
+
 		str := ("\tConfigured addresses: ")
-		addrs, err := networkLink.AddrList(nwconfig.link, netlink.FAMILY_ALL)
+		addrs, err := networkLink.AddrList(l3Link(nwconfig), netlink.FAMILY_ALL)
 		if len(addrs) == 0 || err != nil {
 			str += "no addresses"
 		} else {
@@ -275,6 +381,9 @@ func logResults(config *cmdConfig, networkConfigs map[string]*networkConfigurati
 				addr = nwconfig.peerHWAddr.String()
 			}
 			klog.V(3).Infof("\tPeer MAC address: %s", addr)
+			if nwconfig.sysName != "" || nwconfig.portID != "" {
+				klog.V(3).Infof("\tPeer switch '%s' port '%s'", nwconfig.sysName, nwconfig.portID)
+			}
 
 			addr = noAddress
 			if nwconfig.lldpPeer != nil {
@@ -285,7 +394,7 @@ func logResults(config *cmdConfig, networkConfigs map[string]*networkConfigurati
 			if nwconfig.localAddr != nil {
 				addr = nwconfig.localAddr.String()
 			}
-			klog.V(3).Infof("\tLocal /30 LLDP address: %s", addr)
+			klog.V(3).Infof("\tLocal point-to-point LLDP address: %s", addr)
 		}
 	}
 }
@@ -295,10 +404,11 @@ func lldpResults(networkConfigs map[string]*networkConfiguration) bool {
 
 	for _, nwconfig := range networkConfigs {
 
-		lldpPeer, localAddr, err := selectMask30L3Address(nwconfig)
+		lldpPeer, localAddr, prefixLen, err := selectPointToPointL3Address(nwconfig)
 		if err == nil {
 			nwconfig.lldpPeer = lldpPeer
 			nwconfig.localAddr = localAddr
+			nwconfig.localPrefixLen = prefixLen
 			foundpeers = true
 		} else {
 			klog.Warning(err.Error())
@@ -395,23 +505,126 @@ type RouteMask int
 const (
 	RouteMaskRoutedNetwork RouteMask = 16
 	RouteMaskPointToPoint  RouteMask = 30
+
+	RouteMaskRoutedNetworkIPv6 RouteMask = 64
+	RouteMaskPointToPointIPv6  RouteMask = 127
 )
 
+// networkMask returns the network mask for the given route type matching
+// the address family and point-to-point prefix length of the local address.
+func networkMask(nwconfig *networkConfiguration, mask RouteMask) net.IPMask {
+	if nwconfig.localAddr == nil || !isIPv6(*nwconfig.localAddr) {
+		if mask == RouteMaskPointToPoint && nwconfig.localPrefixLen != 0 {
+			return net.CIDRMask(nwconfig.localPrefixLen, 32)
+		}
+		if mask == RouteMaskRoutedNetwork && nwconfig.routedPrefixLen != 0 {
+			return net.CIDRMask(nwconfig.routedPrefixLen, 32)
+		}
+		return net.CIDRMask(int(mask), 32)
+	}
+
+	switch mask {
+	case RouteMaskRoutedNetwork:
+		if nwconfig.routedPrefixLen != 0 {
+			return net.CIDRMask(nwconfig.routedPrefixLen, 128)
+		}
+		return net.CIDRMask(int(RouteMaskRoutedNetworkIPv6), 128)
+	case RouteMaskPointToPoint:
+		if nwconfig.localPrefixLen != 0 {
+			return net.CIDRMask(nwconfig.localPrefixLen, 128)
+		}
+		return net.CIDRMask(int(RouteMaskPointToPointIPv6), 128)
+	}
+
+	return net.CIDRMask(int(mask), 128)
+}
+
+// routedNetworks returns the destination networks routed through the
+// switch port. These are either the explicitly configured destinations
+// matching the address family of the local address, or the routed network
+// containing the local address.
+func routedNetworks(nwconfig *networkConfiguration) []*net.IPNet {
+	if nwconfig.localAddr == nil {
+		return nil
+	}
+
+	if len(nwconfig.routeDsts) > 0 {
+		dsts := []*net.IPNet{}
+		for _, dst := range nwconfig.routeDsts {
+			if isIPv6(dst.IP) == isIPv6(*nwconfig.localAddr) {
+				dsts = append(dsts, dst)
+			}
+		}
+		return dsts
+	}
+
+	mask := networkMask(nwconfig, RouteMaskRoutedNetwork)
+
+	return []*net.IPNet{{IP: nwconfig.localAddr.Mask(mask), Mask: mask}}
+}
+
+// setRoutedNetworks applies the routed network prefix length and the
+// explicit route destinations given on the command line to the interfaces.
+// The route destinations of an interface in the address plan override the
+// global ones. A prefix length longer than the address family of an
+// interface allows is an error.
+func setRoutedNetworks(config *cmdConfig, networkConfigs map[string]*networkConfiguration) error {
+	for ifname, nwconfig := range networkConfigs {
+		nwconfig.routedPrefixLen = 0
+		nwconfig.routeDsts = config.routeDsts
+		if len(nwconfig.planRouteDsts) > 0 {
+			nwconfig.routeDsts = nwconfig.planRouteDsts
+		}
+
+		if config.routedPrefix == 0 || nwconfig.localAddr == nil {
+			continue
+		}
+
+		bits := 32
+		if isIPv6(*nwconfig.localAddr) {
+			bits = 128
+		}
+
+		if config.routedPrefix > bits {
+			return fmt.Errorf("interface '%s' routed prefix /%d is too long for address %s",
+				ifname, config.routedPrefix, nwconfig.localAddr)
+		}
+
+		pointToPoint := prefixLength(nwconfig, RouteMaskPointToPoint)
+		if config.routedPrefix > pointToPoint {
+			klog.Warningf("Interface '%s' routed prefix /%d does not contain the /%d point-to-point network, using default",
+				ifname, config.routedPrefix, pointToPoint)
+			continue
+		}
+
+		nwconfig.routedPrefixLen = config.routedPrefix
+	}
+
+	return nil
+}
+
+// prefixLength returns the prefix length of the network mask for the
+// given route type.
+func prefixLength(nwconfig *networkConfiguration, mask RouteMask) int {
+	ones, _ := networkMask(nwconfig, mask).Size()
+	return ones
+}
+
 func addRoute(nwconfig *networkConfiguration, mask RouteMask) error {
 	var (
-		err             error
 		networkSrc      net.IP
 		networkGateway  net.IP
 		networkScope    netlink.Scope
 		networkProtocol netlink.RouteProtocol
+		networkDsts     []*net.IPNet
 		routeStr        string
 	)
 
-	networkMask := net.CIDRMask(int(mask), 32)
+	link := l3Link(nwconfig)
+
 	if nwconfig.localAddr == nil {
-		return fmt.Errorf("interface '%s' has no local address", nwconfig.link.Attrs().Name)
+		return fmt.Errorf("interface '%s' has no local address", link.Attrs().Name)
 	}
-	networkAddr := nwconfig.localAddr.Mask(networkMask)
 
 	switch mask {
 	case RouteMaskRoutedNetwork:
@@ -419,45 +632,47 @@ func addRoute(nwconfig *networkConfiguration, mask RouteMask) error {
 		// configuration
 		networkGateway = *nwconfig.lldpPeer
 		routeStr = " gateway " + networkGateway.String()
+		networkDsts = routedNetworks(nwconfig)
 
 	case RouteMaskPointToPoint:
-		// use protocol 'kernel' to create an identical /30 route as
-		// added by the kernel
+		// use protocol 'kernel' to create an identical point-to-point
+		// route as added by the kernel, IPv6 prefix routes have neither
+		// link scope nor a source address
 		networkProtocol = unix.RTPROT_KERNEL
-		networkScope = netlink.SCOPE_LINK
-		networkSrc = *nwconfig.localAddr
+		if !isIPv6(*nwconfig.localAddr) {
+			networkScope = netlink.SCOPE_LINK
+			networkSrc = *nwconfig.localAddr
+		}
+		routeMask := networkMask(nwconfig, mask)
+		networkDsts = []*net.IPNet{{IP: nwconfig.localAddr.Mask(routeMask), Mask: routeMask}}
 	}
 
-	newRoute := &netlink.Route{
-		LinkIndex: nwconfig.link.Attrs().Index,
-		Scope:     networkScope,
-		Protocol:  networkProtocol,
-		Dst: &net.IPNet{
-			IP:   networkAddr,
-			Mask: networkMask,
-		},
-		Src: networkSrc,
-		Gw:  networkGateway,
-	}
+	for _, dst := range networkDsts {
+		newRoute := &netlink.Route{
+			LinkIndex: link.Attrs().Index,
+			Scope:     networkScope,
+			Protocol:  networkProtocol,
+			Dst:       dst,
+			Src:       networkSrc,
+			Gw:        networkGateway,
+		}
 
-	routeStr = newRoute.Dst.String() + routeStr
+		dstStr := newRoute.Dst.String() + routeStr
 
-	if err = networkLink.RouteAppend(newRoute); err == nil {
-		klog.V(3).Infof("Configured route %s for interface '%s'",
-			routeStr, nwconfig.link.Attrs().Name)
-	} else {
-		if errors.Is(err, os.ErrExist) {
-			var noerr error
-			err = noerr
+		if err := networkLink.RouteAppend(newRoute); err == nil {
+			klog.V(3).Infof("Configured route %s for interface '%s'",
+				dstStr, link.Attrs().Name)
+		} else if errors.Is(err, os.ErrExist) {
 			klog.V(3).Infof("Route %s already exists for interface '%s'",
-				routeStr, nwconfig.link.Attrs().Name)
+				dstStr, link.Attrs().Name)
 		} else {
 			klog.Warningf("Could not add route %s for interface '%s': %v",
-				routeStr, nwconfig.link.Attrs().Name, err)
+				dstStr, link.Attrs().Name, err)
+			return err
 		}
 	}
 
-	return err
+	return nil
 }
 
 func interfacesSetMTU(networkConfigurations map[string]*networkConfiguration, mtu int) {
@@ -466,24 +681,120 @@ func interfacesSetMTU(networkConfigurations map[string]*networkConfiguration, mt
 	}
 
 	for _, nwconfig := range networkConfigurations {
-		if err := networkLink.LinkSetMTU(nwconfig.link, mtu); err != nil {
-			klog.Warningf("Could not set MTU %d for interface '%s': %v",
-				mtu, nwconfig.link.Attrs().Name, err)
+		// the VLAN interface MTU cannot exceed the one of its parent
+		for _, link := range interfaceLinks(nwconfig) {
+			if err := networkLink.LinkSetMTU(link, mtu); err != nil {
+				klog.Warningf("Could not set MTU %d for interface '%s': %v",
+					mtu, link.Attrs().Name, err)
+			}
 		}
 	}
 }
 
-func removeExistingIPs(networkConfigs map[string]*networkConfiguration) error {
+// interfacesRestoreMTU sets the original MTU back on the interfaces.
+func interfacesRestoreMTU(networkConfigurations map[string]*networkConfiguration) {
+	for _, nwconfig := range networkConfigurations {
+		if nwconfig.origMTU == 0 {
+			continue
+		}
+
+		if err := networkLink.LinkSetMTU(nwconfig.link, nwconfig.origMTU); err != nil {
+			klog.Warningf("Could not restore MTU %d for interface '%s': %v",
+				nwconfig.origMTU, nwconfig.link.Attrs().Name, err)
+		}
+	}
+}
+
+// saveOriginalAddresses records the addresses and routes of the interfaces
+// before the existing addresses are removed. The IPv6 link-local addresses
+// are never removed, and the routes the kernel adds for the addresses or
+// from router advertisements come back by themselves.
+func saveOriginalAddresses(networkConfigs map[string]*networkConfiguration) error {
 	for _, nwconfig := range networkConfigs {
-		addrs, err := networkLink.AddrList(nwconfig.link, netlink.FAMILY_V4)
+		addrs, err := networkLink.AddrList(nwconfig.link, netlink.FAMILY_ALL)
 		if err != nil {
 			return err
 		}
 
+		nwconfig.origAddrs = nil
 		for _, addr := range addrs {
-			if err := networkLink.AddrDel(nwconfig.link, &addr); err != nil {
+			if isIPv6(addr.IP) && addr.IP.IsLinkLocalUnicast() {
+				continue
+			}
+
+			nwconfig.origAddrs = append(nwconfig.origAddrs, addr)
+		}
+
+		routes, err := networkLink.RouteList(nwconfig.link, netlink.FAMILY_ALL)
+		if err != nil {
+			return err
+		}
+
+		nwconfig.origRoutes = nil
+		for _, route := range routes {
+			if route.Protocol == unix.RTPROT_KERNEL || route.Protocol == unix.RTPROT_RA {
+				continue
+			}
+
+			nwconfig.origRoutes = append(nwconfig.origRoutes, route)
+		}
+
+		klog.V(3).Infof("Interface '%s' has %d original addresses and %d routes",
+			nwconfig.link.Attrs().Name, len(nwconfig.origAddrs), len(nwconfig.origRoutes))
+	}
+
+	return nil
+}
+
+// restoreOriginalAddresses adds the original addresses and then their
+// routes back to the interfaces.
+func restoreOriginalAddresses(networkConfigs map[string]*networkConfiguration) {
+	for _, nwconfig := range networkConfigs {
+		link := nwconfig.link
+		ifname := link.Attrs().Name
+
+		for _, addr := range nwconfig.origAddrs {
+			restored := &netlink.Addr{IPNet: addr.IPNet, Peer: addr.Peer}
+
+			if err := networkLink.AddrAdd(link, restored); err != nil && !errors.Is(err, os.ErrExist) {
+				klog.Warningf("Could not restore address %s for interface '%s': %v", addr.IPNet, ifname, err)
+				continue
+			}
+
+			klog.Infof("Restored address %s for interface '%s'", addr.IPNet, ifname)
+		}
+
+		for _, route := range nwconfig.origRoutes {
+			route.LinkIndex = link.Attrs().Index
+
+			if err := networkLink.RouteAppend(&route); err != nil && !errors.Is(err, os.ErrExist) {
+				klog.Warningf("Could not restore route %s for interface '%s': %v", route, ifname, err)
+				continue
+			}
+
+			klog.V(3).Infof("Restored route %s for interface '%s'", route, ifname)
+		}
+	}
+}
+
+func removeExistingIPs(networkConfigs map[string]*networkConfiguration) error {
+	for _, nwconfig := range networkConfigs {
+		for _, link := range interfaceLinks(nwconfig) {
+			addrs, err := networkLink.AddrList(link, netlink.FAMILY_ALL)
+			if err != nil {
 				return err
 			}
+
+			for _, addr := range addrs {
+				// keep the IPv6 link-local addresses needed by the link
+				if isIPv6(addr.IP) && addr.IP.IsLinkLocalUnicast() {
+					continue
+				}
+
+				if err := networkLink.AddrDel(link, &addr); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
@@ -496,12 +807,15 @@ func configureInterfaces(networkConfigs map[string]*networkConfiguration) (int,
 	klog.Infof("Configuring interfaces...")
 
 	for _, nwconfig := range networkConfigs {
+		nwconfig.configured = false
+
 		if nwconfig.localAddr == nil {
 			continue
 		}
 
-		addrs, err := networkLink.AddrList(nwconfig.link, netlink.FAMILY_V4)
-		ifname := nwconfig.link.Attrs().Name
+		link := l3Link(nwconfig)
+		addrs, err := networkLink.AddrList(link, netlink.FAMILY_ALL)
+		ifname := link.Attrs().Name
 		if err != nil {
 			klog.Warningf("Could not get addresses for link '%s': %v", ifname, err)
 			continue
@@ -524,11 +838,11 @@ func configureInterfaces(networkConfigs map[string]*networkConfiguration) (int,
 			newlinkaddr := &netlink.Addr{
 				IPNet: &net.IPNet{
 					IP:   *nwconfig.localAddr,
-					Mask: net.CIDRMask(30, 32),
+					Mask: networkMask(nwconfig, RouteMaskPointToPoint),
 				},
 			}
-			// AddrAdd will add the corresponding /30 network route
-			if err := networkLink.AddrAdd(nwconfig.link, newlinkaddr); err != nil {
+			// AddrAdd will add the corresponding point-to-point network route
+			if err := networkLink.AddrAdd(link, newlinkaddr); err != nil {
 				klog.Warningf("Could not configure address %s for interface '%s': %v",
 					nwconfig.localAddr.String(), ifname, err)
 				continue
@@ -538,16 +852,20 @@ func configureInterfaces(networkConfigs map[string]*networkConfiguration) (int,
 				newlinkaddr.IPNet.String(), ifname)
 		} else {
 			// IP address exists, but we need to ensure the
-			// existence of the corresponding /30 network route
+			// existence of the corresponding point-to-point network route
 			if err = addRoute(nwconfig, RouteMaskPointToPoint); err != nil {
 				continue
 			}
 		}
 
-		if err = addRoute(nwconfig, RouteMaskRoutedNetwork); err != nil {
-			continue
+		// L2 networks are only reached on-link
+		if nwconfig.lldpPeer != nil {
+			if err = addRoute(nwconfig, RouteMaskRoutedNetwork); err != nil {
+				continue
+			}
 		}
 
+		nwconfig.configured = true
 		configured++
 	}
 
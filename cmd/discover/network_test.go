@@ -26,6 +26,7 @@ import (
 	"testing"
 
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 )
 
 const (
@@ -33,7 +34,7 @@ const (
 	netDevicePath   = "net"
 )
 
-func TestSelectMask30L3Address(t *testing.T) {
+func TestSelectPointToPointL3Address(t *testing.T) {
 	expectedpeer := net.IPv4(10, 210, 8, 122)
 	expectedaddr := net.IPv4(10, 210, 8, 121)
 
@@ -46,13 +47,16 @@ func TestSelectMask30L3Address(t *testing.T) {
 		portDescription: "no-alert " + expectedpeer.String() + "/30",
 	}
 
-	peeraddr, localaddr, err := selectMask30L3Address(&nwconfig)
+	peeraddr, localaddr, prefixLen, err := selectPointToPointL3Address(&nwconfig)
 	if !peeraddr.Equal(expectedpeer) {
 		t.Errorf("Peer addresses do not match, expected %s got %s: %v", expectedpeer.String(), peeraddr.String(), err)
 	}
 	if !localaddr.Equal(expectedaddr) {
 		t.Errorf("Local addresses do not match, expected %s got %s: %v", expectedaddr.String(), localaddr.String(), err)
 	}
+	if prefixLen != 30 {
+		t.Errorf("Prefix length %d, expected 30", prefixLen)
+	}
 
 	addrmask := "/16"
 	addrtext := "10.210.8.122"
@@ -64,11 +68,79 @@ func TestSelectMask30L3Address(t *testing.T) {
 		},
 		portDescription: "no-alert " + addrtext + addrmask,
 	}
-	peeraddr, localaddr, err = selectMask30L3Address(&nwconfig)
+	peeraddr, localaddr, _, err = selectPointToPointL3Address(&nwconfig)
 	if err == nil || peeraddr.String() != addrtext || localaddr.String() != "<nil>" {
 		t.Errorf("netmask %s unexpectedly returned values '%s', '%s' or no error '%v'",
 			addrmask, peeraddr.String(), localaddr.String(), err)
 	}
+
+	ipv6tests := []struct {
+		portDescription string
+		expectedPeer    string
+		expectedLocal   string
+		expectedPrefix  int
+		expectError     bool
+	}{
+		{"no-alert 2001:db8:0:1::1/127", "2001:db8:0:1::1", "2001:db8:0:1::", 127, false},
+		{"no-alert 2001:db8:0:1::/127", "2001:db8:0:1::", "2001:db8:0:1::1", 127, false},
+		{"no-alert 2001:db8:0:1::2/126", "2001:db8:0:1::2", "2001:db8:0:1::1", 126, false},
+		{"no-alert 2001:db8:0:1::1/126", "2001:db8:0:1::1", "2001:db8:0:1::2", 126, false},
+		{"no-alert 2001:db8:0:1::1/64", "2001:db8:0:1::1", "", 64, true},
+	}
+
+	for _, tc := range ipv6tests {
+		nwconfig.portDescription = tc.portDescription
+
+		peeraddr, localaddr, prefixLen, err = selectPointToPointL3Address(&nwconfig)
+		if tc.expectError {
+			if err == nil {
+				t.Errorf("'%s' did not return an error", tc.portDescription)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("'%s' returned an error: %v", tc.portDescription, err)
+			continue
+		}
+		if peeraddr.String() != tc.expectedPeer || localaddr.String() != tc.expectedLocal || prefixLen != tc.expectedPrefix {
+			t.Errorf("'%s' returned peer %s local %s/%d, expected %s %s/%d", tc.portDescription,
+				peeraddr.String(), localaddr.String(), prefixLen,
+				tc.expectedPeer, tc.expectedLocal, tc.expectedPrefix)
+		}
+	}
+}
+
+func TestNetworkMask(t *testing.T) {
+	ipv4 := net.ParseIP("10.210.8.121")
+	ipv6 := net.ParseIP("2001:db8:0:1::")
+
+	tests := []struct {
+		localAddr       net.IP
+		localPrefixLen  int
+		mask            RouteMask
+		expectedNetwork string
+	}{
+		{ipv4, 0, RouteMaskPointToPoint, "10.210.8.120/30"},
+		{ipv4, 30, RouteMaskPointToPoint, "10.210.8.120/30"},
+		{ipv4, 0, RouteMaskRoutedNetwork, "10.210.0.0/16"},
+		{ipv6, 0, RouteMaskPointToPoint, "2001:db8:0:1::/127"},
+		{ipv6, 126, RouteMaskPointToPoint, "2001:db8:0:1::/126"},
+		{ipv6, 127, RouteMaskRoutedNetwork, "2001:db8:0:1::/64"},
+	}
+
+	for _, tc := range tests {
+		nwconfig := &networkConfiguration{
+			localAddr:      &tc.localAddr,
+			localPrefixLen: tc.localPrefixLen,
+		}
+
+		mask := networkMask(nwconfig, tc.mask)
+		network := &net.IPNet{IP: tc.localAddr.Mask(mask), Mask: mask}
+		if network.String() != tc.expectedNetwork {
+			t.Errorf("%s/%d route mask %d gave network %s, expected %s", tc.localAddr, tc.localPrefixLen,
+				tc.mask, network.String(), tc.expectedNetwork)
+		}
+	}
 }
 
 func TestSysFsRoot(t *testing.T) {
@@ -385,6 +457,10 @@ func TestGetNetworkConfigs(t *testing.T) {
 			t.Errorf("expected interface %s module id %s, got %s",
 				ifname, fakenwconfigs[ifname].moduleid, nwconfig.moduleId)
 		}
+		if nwconfig.pciAddress != fakenwconfigs[ifname].pcidevice {
+			t.Errorf("expected interface %s PCI address %s, got %s",
+				ifname, fakenwconfigs[ifname].pcidevice, nwconfig.pciAddress)
+		}
 	}
 }
 
@@ -540,6 +616,149 @@ func TestAddRouteErrors(t *testing.T) {
 	}
 }
 
+func TestSetRoutedNetworks(t *testing.T) {
+	ipv4 := net.ParseIP("10.210.8.121")
+	ipv6 := net.ParseIP("2001:db8:0:1::")
+	_, dst4, _ := net.ParseCIDR("10.220.0.0/16")
+	_, dst6, _ := net.ParseCIDR("2001:db8:100::/48")
+
+	nwconfigs := map[string]*networkConfiguration{
+		"eth_a": {localAddr: &ipv4, localPrefixLen: 30},
+		"eth_b": {localAddr: &ipv6, localPrefixLen: 127},
+		"eth_c": {},
+	}
+
+	config := &cmdConfig{routedPrefix: 20}
+	if err := setRoutedNetworks(config, nwconfigs); err != nil {
+		t.Fatalf("cannot set routed networks: %v", err)
+	}
+
+	if nets := routedNetworks(nwconfigs["eth_a"]); len(nets) != 1 || nets[0].String() != "10.210.0.0/20" {
+		t.Errorf("unexpected IPv4 routed networks %v", nets)
+	}
+	if nets := routedNetworks(nwconfigs["eth_b"]); len(nets) != 1 || nets[0].String() != "2001::/20" {
+		t.Errorf("unexpected IPv6 routed networks %v", nets)
+	}
+	if nets := routedNetworks(nwconfigs["eth_c"]); len(nets) != 0 {
+		t.Errorf("unexpected routed networks without local address %v", nets)
+	}
+
+	// prefix longer than an IPv4 address
+	config.routedPrefix = 64
+	if err := setRoutedNetworks(config, nwconfigs); err == nil {
+		t.Error("expected an error with an IPv6 prefix length for an IPv4 address")
+	}
+
+	// prefix not containing the IPv4 point-to-point network falls back to default
+	config.routedPrefix = 31
+	if err := setRoutedNetworks(config, nwconfigs); err != nil {
+		t.Fatalf("cannot set routed networks: %v", err)
+	}
+
+	if nets := routedNetworks(nwconfigs["eth_a"]); len(nets) != 1 || nets[0].String() != "10.210.0.0/16" {
+		t.Errorf("unexpected IPv4 routed networks %v", nets)
+	}
+	if nets := routedNetworks(nwconfigs["eth_b"]); len(nets) != 1 || nets[0].String() != "2001:db8::/31" {
+		t.Errorf("unexpected IPv6 routed networks %v", nets)
+	}
+
+	config.routeDsts = []*net.IPNet{dst4, dst6}
+	if err := setRoutedNetworks(config, nwconfigs); err != nil {
+		t.Fatalf("cannot set routed networks: %v", err)
+	}
+
+	if nets := routedNetworks(nwconfigs["eth_a"]); len(nets) != 1 || nets[0].String() != dst4.String() {
+		t.Errorf("unexpected IPv4 route destinations %v", nets)
+	}
+	if nets := routedNetworks(nwconfigs["eth_b"]); len(nets) != 1 || nets[0].String() != dst6.String() {
+		t.Errorf("unexpected IPv6 route destinations %v", nets)
+	}
+
+	// per-interface destinations of the address plan override the global ones
+	_, rail, _ := net.ParseCIDR("10.230.0.0/16")
+	nwconfigs["eth_a"].planRouteDsts = []*net.IPNet{rail}
+	if err := setRoutedNetworks(config, nwconfigs); err != nil {
+		t.Fatalf("cannot set routed networks: %v", err)
+	}
+
+	if nets := routedNetworks(nwconfigs["eth_a"]); len(nets) != 1 || nets[0].String() != rail.String() {
+		t.Errorf("unexpected per-interface route destinations %v", nets)
+	}
+	if nets := routedNetworks(nwconfigs["eth_b"]); len(nets) != 1 || nets[0].String() != dst6.String() {
+		t.Errorf("unexpected IPv6 route destinations %v", nets)
+	}
+}
+
+func TestAddRouteDestinations(t *testing.T) {
+	ifName := "eth_a"
+	fnd := getFakeNetworkData()[ifName]
+
+	var routes []*netlink.Route
+	networkLink.RouteAppend = func(route *netlink.Route) error {
+		routes = append(routes, route)
+		return nil
+	}
+
+	localAddr := net.ParseIP("10.210.8.121")
+	peerAddr := net.ParseIP("10.210.8.122")
+	_, dst1, _ := net.ParseCIDR("10.210.0.0/20")
+	_, dst2, _ := net.ParseCIDR("10.230.0.0/16")
+
+	fnd.nwconfig.localAddr = &localAddr
+	fnd.nwconfig.lldpPeer = &peerAddr
+	fnd.nwconfig.routeDsts = []*net.IPNet{dst1, dst2}
+
+	if err := addRoute(&fnd.nwconfig, RouteMaskRoutedNetwork); err != nil {
+		t.Errorf("add route failed: %v", err)
+	}
+
+	if len(routes) != 2 || routes[0].Dst.String() != dst1.String() || routes[1].Dst.String() != dst2.String() {
+		t.Fatalf("unexpected routes %v", routes)
+	}
+
+	for _, route := range routes {
+		if !route.Gw.Equal(peerAddr) {
+			t.Errorf("route %s not via gateway %s", route.String(), peerAddr.String())
+		}
+	}
+}
+
+func TestAddRouteIPv6(t *testing.T) {
+	ifName := "eth_a"
+	fnd := getFakeNetworkData()[ifName]
+
+	var routes []*netlink.Route
+	networkLink.RouteAppend = func(route *netlink.Route) error {
+		routes = append(routes, route)
+		return nil
+	}
+
+	localAddr := net.ParseIP("2001:db8:0:1::")
+	peerAddr := net.ParseIP("2001:db8:0:1::1")
+	fnd.nwconfig.localAddr = &localAddr
+	fnd.nwconfig.lldpPeer = &peerAddr
+	fnd.nwconfig.localPrefixLen = 127
+
+	if err := addRoute(&fnd.nwconfig, RouteMaskPointToPoint); err != nil {
+		t.Errorf("add route failed: %v", err)
+	}
+	if err := addRoute(&fnd.nwconfig, RouteMaskRoutedNetwork); err != nil {
+		t.Errorf("add route failed: %v", err)
+	}
+
+	if len(routes) != 2 {
+		t.Fatalf("expected 2 routes, got %d", len(routes))
+	}
+
+	if routes[0].Dst.String() != "2001:db8:0:1::/127" || routes[0].Src != nil || routes[0].Scope != netlink.SCOPE_UNIVERSE {
+		t.Errorf("unexpected point-to-point route %s", routes[0].String())
+	}
+
+	if routes[1].Dst.String() != "2001:db8:0:1::/64" || !routes[1].Gw.Equal(peerAddr) {
+		t.Errorf("unexpected routed network route %s", routes[1].String())
+	}
+}
+
 func TestNoGaudiDevicesErrors(t *testing.T) {
 	networkLink.LinkByName = fakeLinkByName
 
@@ -722,3 +941,46 @@ func TestRemoveExistingIPsErrors(t *testing.T) {
 		t.Error("removeExistingIPs should have failed")
 	}
 }
+
+func TestSaveOriginalAddresses(t *testing.T) {
+	orig := networkLink
+	t.Cleanup(func() { networkLink = orig })
+
+	networkLink.AddrList = func(link netlink.Link, family int) ([]netlink.Addr, error) {
+		global, _ := netlink.ParseAddr("192.168.10.11/24")
+		linkLocal, _ := netlink.ParseAddr("fe80::10/64")
+		return []netlink.Addr{*global, *linkLocal}, nil
+	}
+	networkLink.RouteList = func(link netlink.Link, family int) ([]netlink.Route, error) {
+		_, prefix, _ := net.ParseCIDR("192.168.10.0/24")
+		_, dst, _ := net.ParseCIDR("10.0.0.0/8")
+		return []netlink.Route{
+			{Dst: prefix, Protocol: unix.RTPROT_KERNEL},
+			{Dst: dst, Gw: net.ParseIP("192.168.10.1"), Protocol: unix.RTPROT_STATIC},
+			{Gw: net.ParseIP("fe80::1"), Protocol: unix.RTPROT_RA},
+		}, nil
+	}
+
+	netConfs := map[string]*networkConfiguration{
+		"eth0": {link: &fakeLink{fakeAttrs: netlink.LinkAttrs{Name: "eth0", Index: 1}}},
+	}
+
+	if err := saveOriginalAddresses(netConfs); err != nil {
+		t.Fatalf("cannot save original addresses: %v", err)
+	}
+
+	nwconfig := netConfs["eth0"]
+	if len(nwconfig.origAddrs) != 1 || nwconfig.origAddrs[0].IPNet.String() != "192.168.10.11/24" {
+		t.Errorf("unexpected original addresses %v", nwconfig.origAddrs)
+	}
+	if len(nwconfig.origRoutes) != 1 || nwconfig.origRoutes[0].Dst.String() != "10.0.0.0/8" {
+		t.Errorf("unexpected original routes %v", nwconfig.origRoutes)
+	}
+
+	networkLink.RouteList = func(link netlink.Link, family int) ([]netlink.Route, error) {
+		return nil, fmt.Errorf("cannot list routes")
+	}
+	if err := saveOriginalAddresses(netConfs); err == nil {
+		t.Error("saveOriginalAddresses should have failed")
+	}
+}
@@ -0,0 +1,203 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+)
+
+const (
+	readinessNFD  = "nfd"
+	readinessNode = "node"
+
+	nodeConditionScaleOutReady corev1.NodeConditionType = networkv1alpha1.ScaleOutReadyCondition
+	nodeReasonScaleOutReady                             = "ScaleOutReady"
+	nodeReasonScaleOutNotReady                          = "ScaleOutNotReady"
+)
+
+// nodeReadiness publishes the scale-out readiness directly on the Node
+// object for clusters without NFD.
+type nodeReadiness struct {
+	client   client.Client
+	nodeName string
+}
+
+func newNodeReadiness(config *cmdConfig) (*nodeReadiness, error) {
+	if config.readiness != readinessNode {
+		return nil, nil
+	}
+
+	if config.nodeName == "" {
+		return nil, fmt.Errorf("no node name given")
+	}
+
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	return &nodeReadiness{
+		client:   c,
+		nodeName: config.nodeName,
+	}, nil
+}
+
+// removeScaleOutLabels removes the scale-out readiness labels.
+func removeScaleOutLabels(labels map[string]string) {
+	for key := range labels {
+		if strings.HasPrefix(key, nfdScaleOutLabel) {
+			delete(labels, key)
+		}
+	}
+}
+
+// setScaleOutCondition sets the scale-out readiness condition of the
+// node. The transition time is only updated when the status changes.
+func setScaleOutCondition(node *corev1.Node, ready bool, message string) {
+	condition := corev1.NodeCondition{
+		Type:              nodeConditionScaleOutReady,
+		Status:            corev1.ConditionFalse,
+		Reason:            nodeReasonScaleOutNotReady,
+		Message:           message,
+		LastHeartbeatTime: metav1.Now(),
+	}
+	if ready {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = nodeReasonScaleOutReady
+	}
+	condition.LastTransitionTime = condition.LastHeartbeatTime
+
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type != nodeConditionScaleOutReady {
+			continue
+		}
+
+		if node.Status.Conditions[i].Status == condition.Status {
+			condition.LastTransitionTime = node.Status.Conditions[i].LastTransitionTime
+		}
+		node.Status.Conditions[i] = condition
+
+		return
+	}
+
+	node.Status.Conditions = append(node.Status.Conditions, condition)
+}
+
+// removeScaleOutCondition removes the scale-out readiness condition of
+// the node.
+func removeScaleOutCondition(node *corev1.Node) {
+	conditions := []corev1.NodeCondition{}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != nodeConditionScaleOutReady {
+			conditions = append(conditions, condition)
+		}
+	}
+	node.Status.Conditions = conditions
+}
+
+// patch applies the changes to the Node labels and conditions.
+func (n *nodeReadiness) patch(config *cmdConfig, change func(node *corev1.Node)) error {
+	ctx, cancel := context.WithTimeout(config.ctx, nodeStateTimeout)
+	defer cancel()
+
+	node := &corev1.Node{}
+	if err := n.client.Get(ctx, client.ObjectKey{Name: n.nodeName}, node); err != nil {
+		return err
+	}
+
+	original := node.DeepCopy()
+	change(node)
+	status := node.Status.DeepCopy()
+
+	if err := n.client.Patch(ctx, node, client.MergeFrom(original)); err != nil {
+		return err
+	}
+
+	// conditions are merged by type to not overwrite the ones of kubelet
+	original = node.DeepCopy()
+	node.Status = *status
+
+	return n.client.Status().Patch(ctx, node, client.StrategicMergeFrom(original))
+}
+
+// update sets the readiness labels and the scale-out readiness condition
+// of the node.
+func (n *nodeReadiness) update(config *cmdConfig, labels []string, ready bool, message string) error {
+	if n == nil {
+		return nil
+	}
+
+	err := n.patch(config, func(node *corev1.Node) {
+		if node.Labels == nil {
+			node.Labels = map[string]string{}
+		}
+
+		removeScaleOutLabels(node.Labels)
+		for _, label := range labels {
+			key, value, _ := strings.Cut(label, "=")
+			node.Labels[key] = value
+		}
+
+		setScaleOutCondition(node, ready, message)
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to update the readiness of Node '%s': %v", n.nodeName, err)
+	}
+
+	klog.V(3).Infof("Updated the readiness of Node '%s' to %t", n.nodeName, ready)
+
+	return nil
+}
+
+// remove removes the readiness labels and the scale-out readiness
+// condition of the node.
+func (n *nodeReadiness) remove(config *cmdConfig) error {
+	if n == nil {
+		return nil
+	}
+
+	err := n.patch(config, func(node *corev1.Node) {
+		removeScaleOutLabels(node.Labels)
+		removeScaleOutCondition(node)
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to remove the readiness of Node '%s': %v", n.nodeName, err)
+	}
+
+	return nil
+}
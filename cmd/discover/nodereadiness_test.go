@@ -0,0 +1,162 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func fakeNodeReadiness(t *testing.T) *nodeReadiness {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("cannot add scheme: %v", err)
+	}
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   testNodeName,
+			Labels: map[string]string{"kubernetes.io/hostname": testNodeName},
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(node).
+		WithStatusSubresource(node).
+		Build()
+
+	return &nodeReadiness{
+		client:   c,
+		nodeName: testNodeName,
+	}
+}
+
+func scaleOutCondition(node *corev1.Node) *corev1.NodeCondition {
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == nodeConditionScaleOutReady {
+			return &node.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func TestNodeReadiness(t *testing.T) {
+	n := fakeNodeReadiness(t)
+	config := &cmdConfig{ctx: context.Background(), mode: L2}
+	nwconfigs := getFakeNetworkDataConfigs()
+
+	getNode := func() *corev1.Node {
+		node := &corev1.Node{}
+		if err := n.client.Get(context.Background(), client.ObjectKey{Name: testNodeName}, node); err != nil {
+			t.Fatalf("cannot get node: %v", err)
+		}
+		return node
+	}
+
+	if ready, err := publishReadiness(config, n, nwconfigs, nil); !ready || err != nil {
+		t.Fatalf("unexpected readiness %v: %v", ready, err)
+	}
+
+	node := getNode()
+	if node.Labels[nfdScaleOutLabel] != "true" || node.Labels[nfdReadyPortsLabel] != "3" ||
+		node.Labels[nfdPortLabelPrefix+"eth_b"] != "true" || node.Labels["kubernetes.io/hostname"] != testNodeName {
+		t.Errorf("unexpected node labels %v", node.Labels)
+	}
+	condition := scaleOutCondition(node)
+	if condition == nil || condition.Status != corev1.ConditionTrue || condition.Reason != nodeReasonScaleOutReady ||
+		condition.Message != "3 of 3 scale-out ports work, 3 required" {
+		t.Errorf("unexpected scale-out condition %+v", condition)
+	}
+	if len(node.Status.Conditions) != 2 {
+		t.Errorf("other node conditions not kept: %+v", node.Status.Conditions)
+	}
+
+	if ready, err := publishReadiness(config, n, nwconfigs, map[string]bool{"eth_b": true}); ready || err != nil {
+		t.Fatalf("unexpected readiness %v: %v", ready, err)
+	}
+
+	node = getNode()
+	if _, exists := node.Labels[nfdScaleOutLabel]; exists || node.Labels[nfdPortLabelPrefix+"eth_b"] != "false" {
+		t.Errorf("unexpected node labels %v", node.Labels)
+	}
+	if condition := scaleOutCondition(node); condition == nil || condition.Status != corev1.ConditionFalse ||
+		condition.Reason != nodeReasonScaleOutNotReady {
+		t.Errorf("unexpected scale-out condition %+v", condition)
+	}
+
+	if err := n.remove(config); err != nil {
+		t.Fatalf("cannot remove readiness: %v", err)
+	}
+
+	node = getNode()
+	if len(node.Labels) != 1 || scaleOutCondition(node) != nil || len(node.Status.Conditions) != 1 {
+		t.Errorf("readiness not removed: %v %+v", node.Labels, node.Status.Conditions)
+	}
+
+	// missing node
+	n.nodeName = "node-b"
+	if err := n.update(config, nil, true, ""); err == nil {
+		t.Error("expected an error without the Node")
+	}
+
+	// nil node readiness does nothing
+	var nilNode *nodeReadiness
+	if err := nilNode.update(config, nil, true, ""); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := nilNode.remove(config); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if r, err := newNodeReadiness(config); r != nil || err != nil {
+		t.Errorf("expected no node readiness by default, got %v: %v", r, err)
+	}
+	if _, err := newNodeReadiness(&cmdConfig{readiness: readinessNode}); err == nil {
+		t.Error("expected an error without node name")
+	}
+}
+
+func TestSetScaleOutCondition(t *testing.T) {
+	node := &corev1.Node{}
+
+	setScaleOutCondition(node, false, "")
+	transition := node.Status.Conditions[0].LastTransitionTime
+	transition.Time = transition.Add(-60e9)
+	node.Status.Conditions[0].LastTransitionTime = transition
+
+	setScaleOutCondition(node, false, "still not ready")
+	if len(node.Status.Conditions) != 1 || !node.Status.Conditions[0].LastTransitionTime.Equal(&transition) {
+		t.Errorf("transition time changed without status change: %+v", node.Status.Conditions)
+	}
+
+	setScaleOutCondition(node, true, "")
+	if node.Status.Conditions[0].LastTransitionTime.Equal(&transition) {
+		t.Errorf("transition time not changed: %+v", node.Status.Conditions)
+	}
+}
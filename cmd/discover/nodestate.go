@@ -0,0 +1,273 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+)
+
+const (
+	nodeStateTimeout = 10 * time.Second
+)
+
+type nodeStateReporter struct {
+	client     client.Client
+	scheme     *runtime.Scheme
+	nodeName   string
+	policyName string
+}
+
+func newNodeStateReporter(config *cmdConfig) (*nodeStateReporter, error) {
+	if config.policy == "" {
+		return nil, nil
+	}
+
+	if config.nodeName == "" {
+		return nil, fmt.Errorf("no node name given")
+	}
+
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := networkv1alpha1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	return &nodeStateReporter{
+		client:     c,
+		scheme:     scheme,
+		nodeName:   config.nodeName,
+		policyName: config.policy,
+	}, nil
+}
+
+func interfaceStates(config *cmdConfig, networkConfigs map[string]*networkConfiguration) []networkv1alpha1.InterfaceState {
+	states := []networkv1alpha1.InterfaceState{}
+
+	for ifname, nwconfig := range networkConfigs {
+		link := nwconfig.link
+		if updated, err := networkLink.LinkByName(ifname); err == nil {
+			link = updated
+		}
+
+		state := networkv1alpha1.InterfaceState{
+			Name:            ifname,
+			ModuleID:        nwconfig.moduleId,
+			Up:              link.Attrs().Flags&net.FlagUp != 0,
+			MTU:             link.Attrs().MTU,
+			PortDescription: nwconfig.portDescription,
+			PortID:          nwconfig.portID,
+			SysName:         nwconfig.sysName,
+			SysDescription:  nwconfig.sysDescription,
+			Configured:      nwconfig.configured,
+		}
+
+		if nwconfig.localHwAddr != nil {
+			state.MAC = nwconfig.localHwAddr.String()
+		}
+		if nwconfig.peerHWAddr != nil {
+			state.PeerMAC = nwconfig.peerHWAddr.String()
+		}
+		if nwconfig.lldpPeer != nil {
+			state.PeerAddress = nwconfig.lldpPeer.String()
+		}
+		state.LocalAddress = localCIDR(nwconfig)
+		if config.configure {
+			state.PFC = config.pfc
+		}
+
+		states = append(states, state)
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Name < states[j].Name
+	})
+
+	return states
+}
+
+// setPolicy makes the node state belong to the policy of the agent: the
+// policy name, its label and the owner reference. Owner references of
+// other policies are dropped so that a node moved to another policy is
+// garbage collected with the new one. Returns true if anything changed.
+func (r *nodeStateReporter) setPolicy(ctx context.Context, nodeState *networkv1alpha1.NetworkNodeState) (bool, error) {
+	// Without the owner the node state would never be garbage collected
+	policy := &networkv1alpha1.NetworkClusterPolicy{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: r.policyName}, policy); err != nil {
+		return false, fmt.Errorf("cannot get NetworkClusterPolicy '%s': %v", r.policyName, err)
+	}
+
+	orig := nodeState.DeepCopy()
+
+	nodeState.Spec.PolicyName = r.policyName
+	if nodeState.Labels == nil {
+		nodeState.Labels = map[string]string{}
+	}
+	nodeState.Labels[networkv1alpha1.NodeStatePolicyLabel] = r.policyName
+
+	owners := []metav1.OwnerReference{}
+	for _, owner := range nodeState.OwnerReferences {
+		if owner.Kind != "NetworkClusterPolicy" || owner.UID == policy.UID {
+			owners = append(owners, owner)
+		}
+	}
+	nodeState.OwnerReferences = owners
+
+	if err := controllerutil.SetOwnerReference(policy, nodeState, r.scheme); err != nil {
+		return false, fmt.Errorf("cannot set NetworkNodeState owner: %v", err)
+	}
+
+	changed := !equality.Semantic.DeepEqual(orig.Spec, nodeState.Spec) ||
+		!equality.Semantic.DeepEqual(orig.Labels, nodeState.Labels) ||
+		!equality.Semantic.DeepEqual(orig.OwnerReferences, nodeState.OwnerReferences)
+
+	return changed, nil
+}
+
+func (r *nodeStateReporter) update(ctx context.Context, status networkv1alpha1.NetworkNodeStateStatus) error {
+	nodeState := &networkv1alpha1.NetworkNodeState{}
+
+	err := r.client.Get(ctx, client.ObjectKey{Name: r.nodeName}, nodeState)
+	if apierrors.IsNotFound(err) {
+		nodeState = &networkv1alpha1.NetworkNodeState{
+			ObjectMeta: metav1.ObjectMeta{Name: r.nodeName},
+		}
+
+		if _, err := r.setPolicy(ctx, nodeState); err != nil {
+			return err
+		}
+
+		if err := r.client.Create(ctx, nodeState); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else if changed, err := r.setPolicy(ctx, nodeState); err != nil {
+		return err
+	} else if changed {
+		if err := r.client.Update(ctx, nodeState); err != nil {
+			return err
+		}
+	}
+
+	nodeState.Status = status
+
+	return r.client.Status().Update(ctx, nodeState)
+}
+
+// report writes the interface configurations and the overall state of
+// the node to its NetworkNodeState object. Failures are only logged as
+// reporting is not essential for configuring the node.
+func (r *nodeStateReporter) report(config *cmdConfig, networkConfigs map[string]*networkConfiguration, state string, reportErr error) {
+	if r == nil {
+		return
+	}
+
+	status := networkv1alpha1.NetworkNodeStateStatus{
+		State:       state,
+		Interfaces:  interfaceStates(config, networkConfigs),
+		LastUpdated: metav1.Now(),
+	}
+	if reportErr != nil {
+		status.Message = reportErr.Error()
+	}
+
+	ctx, cancel := context.WithTimeout(config.ctx, nodeStateTimeout)
+	defer cancel()
+
+	if err := r.update(ctx, status); err != nil {
+		klog.Warningf("Failed to update NetworkNodeState '%s': %v", r.nodeName, err)
+		return
+	}
+
+	klog.V(3).Infof("Updated NetworkNodeState '%s' to state %s", r.nodeName, state)
+}
+
+// event records a Kubernetes Event for the NetworkNodeState object of the
+// node. Failures are only logged like with reporting the state.
+func (r *nodeStateReporter) event(config *cmdConfig, eventType, reason, message string) {
+	if r == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(config.ctx, nodeStateTimeout)
+	defer cancel()
+
+	nodeState := &networkv1alpha1.NetworkNodeState{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: r.nodeName}, nodeState); err != nil {
+		klog.Warningf("Cannot get NetworkNodeState '%s' for event: %v", r.nodeName, err)
+		return
+	}
+
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: r.nodeName + ".",
+			// Events of cluster scoped objects live in the default namespace
+			Namespace: metav1.NamespaceDefault,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: networkv1alpha1.GroupVersion.String(),
+			Kind:       "NetworkNodeState",
+			Name:       nodeState.Name,
+			UID:        nodeState.UID,
+		},
+		Type:                eventType,
+		Reason:              reason,
+		Message:             message,
+		Source:              corev1.EventSource{Component: "linkdiscovery", Host: r.nodeName},
+		ReportingController: "intel.com/linkdiscovery",
+		ReportingInstance:   r.nodeName,
+		FirstTimestamp:      now,
+		LastTimestamp:       now,
+		Count:               1,
+	}
+
+	if err := r.client.Create(ctx, event); err != nil {
+		klog.Warningf("Failed to record event for NetworkNodeState '%s': %v", r.nodeName, err)
+		return
+	}
+
+	klog.V(3).Infof("Recorded %s event %s: %s", eventType, reason, message)
+}
@@ -0,0 +1,213 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+)
+
+const (
+	testNodeName   = "node-a"
+	testPolicyName = "policy-a"
+)
+
+// testPolicy returns the policy the node states of the fake reporter
+// belong to.
+func testPolicy() *networkv1alpha1.NetworkClusterPolicy {
+	return &networkv1alpha1.NetworkClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testPolicyName,
+			UID:  "1234",
+		},
+	}
+}
+
+func fakeNodeStateReporter(t *testing.T, objs ...client.Object) *nodeStateReporter {
+	scheme := runtime.NewScheme()
+	if err := networkv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("cannot add scheme: %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("cannot add scheme: %v", err)
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&networkv1alpha1.NetworkNodeState{}).
+		Build()
+
+	return &nodeStateReporter{
+		client:     c,
+		scheme:     scheme,
+		nodeName:   testNodeName,
+		policyName: testPolicyName,
+	}
+}
+
+func TestNewNodeStateReporter(t *testing.T) {
+	config := &cmdConfig{}
+
+	if r, err := newNodeStateReporter(config); r != nil || err != nil {
+		t.Errorf("expected no reporter and no error without policy, got %v: %v", r, err)
+	}
+
+	config.policy = testPolicyName
+	if _, err := newNodeStateReporter(config); err == nil {
+		t.Error("expected an error without node name")
+	}
+}
+
+func TestInterfaceStates(t *testing.T) {
+	networkLink.LinkByName = fakeLinkByName
+
+	config := &cmdConfig{configure: true, pfc: "0,1,2,3"}
+	nwconfigs := getFakeNetworkDataConfigs()
+	_ = lldpResults(nwconfigs)
+	nwconfigs["eth_a"].sysName = "switch-a"
+	nwconfigs["eth_a"].portID = "swp1"
+
+	states := interfaceStates(config, nwconfigs)
+	if len(states) != len(nwconfigs) {
+		t.Fatalf("expected %d interface states, got %d", len(nwconfigs), len(states))
+	}
+
+	for i, state := range states {
+		if i > 0 && states[i-1].Name > state.Name {
+			t.Errorf("interface states are not sorted: %v", states)
+		}
+
+		if state.PFC != config.pfc {
+			t.Errorf("interface '%s' PFC '%s', expected '%s'", state.Name, state.PFC, config.pfc)
+		}
+	}
+
+	if states[0].Name != "eth_a" || states[0].LocalAddress != "10.210.8.121/30" ||
+		states[0].PeerAddress != "10.210.8.122" || states[0].PeerMAC != "01:01:02:02:03:03" ||
+		states[0].SysName != "switch-a" || states[0].PortID != "swp1" {
+		t.Errorf("unexpected interface state %+v", states[0])
+	}
+
+	if states[1].LocalAddress != "" || states[1].PeerAddress != "" {
+		t.Errorf("interface '%s' should not have addresses: %+v", states[1].Name, states[1])
+	}
+}
+
+func TestNodeStateReport(t *testing.T) {
+	networkLink.LinkByName = fakeLinkByName
+
+	r := fakeNodeStateReporter(t, testPolicy())
+	config := &cmdConfig{ctx: context.Background()}
+	nwconfigs := getFakeNetworkDataConfigs()
+
+	r.report(config, nwconfigs, networkv1alpha1.NodeStateConfigured, nil)
+
+	nodeState := &networkv1alpha1.NetworkNodeState{}
+	if err := r.client.Get(context.Background(), client.ObjectKey{Name: testNodeName}, nodeState); err != nil {
+		t.Fatalf("node state was not created: %v", err)
+	}
+
+	if nodeState.Spec.PolicyName != testPolicyName ||
+		nodeState.Labels[networkv1alpha1.NodeStatePolicyLabel] != testPolicyName {
+		t.Errorf("node state not reported for policy '%s': %+v", testPolicyName, nodeState)
+	}
+	if len(nodeState.OwnerReferences) != 1 || nodeState.OwnerReferences[0].Name != testPolicyName {
+		t.Errorf("node state is not owned by the policy: %+v", nodeState.OwnerReferences)
+	}
+	if nodeState.Status.State != networkv1alpha1.NodeStateConfigured || len(nodeState.Status.Interfaces) != len(nwconfigs) {
+		t.Errorf("unexpected node state status %+v", nodeState.Status)
+	}
+
+	r.report(config, nwconfigs, networkv1alpha1.NodeStateFailed, fmt.Errorf("Not all interfaces were configured (2/3)."))
+
+	if err := r.client.Get(context.Background(), client.ObjectKey{Name: testNodeName}, nodeState); err != nil {
+		t.Fatalf("node state disappeared: %v", err)
+	}
+	if nodeState.Status.State != networkv1alpha1.NodeStateFailed || nodeState.Status.Message == "" {
+		t.Errorf("failure not reported in node state status %+v", nodeState.Status)
+	}
+
+	// nil reporter does nothing
+	var nilReporter *nodeStateReporter
+	nilReporter.report(config, nwconfigs, networkv1alpha1.NodeStateConfigured, nil)
+}
+
+func TestNodeStateReportPolicyChange(t *testing.T) {
+	networkLink.LinkByName = fakeLinkByName
+
+	oldPolicy := &networkv1alpha1.NetworkClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy-old", UID: "1111"},
+	}
+	policy := testPolicy()
+	old := &networkv1alpha1.NetworkNodeState{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   testNodeName,
+			Labels: map[string]string{networkv1alpha1.NodeStatePolicyLabel: oldPolicy.Name},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: networkv1alpha1.GroupVersion.String(),
+				Kind:       "NetworkClusterPolicy",
+				Name:       oldPolicy.Name,
+				UID:        oldPolicy.UID,
+			}},
+		},
+		Spec: networkv1alpha1.NetworkNodeStateSpec{PolicyName: oldPolicy.Name},
+	}
+	r := fakeNodeStateReporter(t, oldPolicy, policy, old)
+	config := &cmdConfig{ctx: context.Background()}
+	nwconfigs := getFakeNetworkDataConfigs()
+
+	r.report(config, nwconfigs, networkv1alpha1.NodeStateConfigured, nil)
+
+	nodeState := &networkv1alpha1.NetworkNodeState{}
+	if err := r.client.Get(context.Background(), client.ObjectKey{Name: testNodeName}, nodeState); err != nil {
+		t.Fatalf("node state disappeared: %v", err)
+	}
+	if nodeState.Spec.PolicyName != testPolicyName ||
+		nodeState.Labels[networkv1alpha1.NodeStatePolicyLabel] != testPolicyName {
+		t.Errorf("node state not moved to policy '%s': %+v", testPolicyName, nodeState)
+	}
+	if len(nodeState.OwnerReferences) != 1 || nodeState.OwnerReferences[0].UID != policy.UID {
+		t.Errorf("node state is not owned by the new policy: %+v", nodeState.OwnerReferences)
+	}
+	if nodeState.Status.State != networkv1alpha1.NodeStateConfigured {
+		t.Errorf("unexpected node state status %+v", nodeState.Status)
+	}
+}
+
+func TestNodeStateReportNoPolicy(t *testing.T) {
+	networkLink.LinkByName = fakeLinkByName
+
+	r := fakeNodeStateReporter(t)
+	config := &cmdConfig{ctx: context.Background()}
+
+	r.report(config, getFakeNetworkDataConfigs(), networkv1alpha1.NodeStateConfigured, nil)
+
+	nodeState := &networkv1alpha1.NetworkNodeState{}
+	if err := r.client.Get(context.Background(), client.ObjectKey{Name: testNodeName}, nodeState); err == nil {
+		t.Errorf("node state created without an owner: %+v", nodeState)
+	}
+}
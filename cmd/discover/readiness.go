@@ -0,0 +1,140 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+)
+
+const (
+	nfdScaleOutLabel   = networkv1alpha1.ScaleOutLabel
+	nfdReadyPortsLabel = nfdScaleOutLabel + "-ports"
+	nfdTotalPortsLabel = nfdScaleOutLabel + "-ports-total"
+	nfdPortLabelPrefix = nfdScaleOutLabel + "-port-"
+)
+
+// portReady returns true if the interface works: its link has not gone
+// down and, in L3 mode or with an L2 address source, it is configured with
+// an address.
+func portReady(config *cmdConfig, ifname string, nwconfig *networkConfiguration, unready map[string]bool) bool {
+	if unready[ifname] {
+		return false
+	}
+
+	return (config.mode != L3 && config.l2AddressSource == "") || nwconfig.configured
+}
+
+// requiredReadyPorts returns the number of working interfaces needed for
+// the node to be ready, by default all of them.
+func requiredReadyPorts(config *cmdConfig, total int) int {
+	if config.minReadyPorts > 0 {
+		return config.minReadyPorts
+	}
+
+	return total
+}
+
+// readyPortCount returns the number of working interfaces.
+func readyPortCount(config *cmdConfig, networkConfigs map[string]*networkConfiguration, unready map[string]bool) int {
+	readyPorts := 0
+
+	for ifname, nwconfig := range networkConfigs {
+		if portReady(config, ifname, nwconfig, unready) {
+			readyPorts++
+		}
+	}
+
+	return readyPorts
+}
+
+// readinessLabels returns the NFD labels with the number of working
+// interfaces and the state of each of them, and whether there are enough
+// working interfaces for the node to be ready. The scale-out readiness
+// label is only included for ready nodes.
+func readinessLabels(config *cmdConfig, networkConfigs map[string]*networkConfiguration, unready map[string]bool) ([]string, bool) {
+	portLabels := []string{}
+
+	for ifname, nwconfig := range networkConfigs {
+		ready := portReady(config, ifname, nwconfig, unready)
+
+		label := nfdPortLabelPrefix + ifname
+		if errs := validation.IsQualifiedName(label); len(errs) > 0 {
+			klog.Warningf("Interface '%s' cannot be used in a label: %s", ifname, strings.Join(errs, ", "))
+			continue
+		}
+
+		portLabels = append(portLabels, fmt.Sprintf("%s=%t", label, ready))
+	}
+
+	sort.Strings(portLabels)
+
+	readyPorts := readyPortCount(config, networkConfigs, unready)
+	nodeReady := readyPorts >= requiredReadyPorts(config, len(networkConfigs))
+
+	labels := []string{}
+	if nodeReady {
+		labels = append(labels, nfdScaleOutLabel+"=true")
+	}
+	labels = append(labels,
+		fmt.Sprintf("%s=%d", nfdReadyPortsLabel, readyPorts),
+		fmt.Sprintf("%s=%d", nfdTotalPortsLabel, len(networkConfigs)))
+
+	return append(labels, portLabels...), nodeReady
+}
+
+// publishReadiness publishes the readiness labels on the Node object if
+// given, and otherwise for NFD if NFD local features are in use on the
+// node. Returns whether the node is ready.
+func publishReadiness(config *cmdConfig, node *nodeReadiness, networkConfigs map[string]*networkConfiguration, unready map[string]bool) (bool, error) {
+	labels, ready := readinessLabels(config, networkConfigs, unready)
+
+	if node != nil {
+		message := fmt.Sprintf("%d of %d scale-out ports work, %d required",
+			readyPortCount(config, networkConfigs, unready), len(networkConfigs),
+			requiredReadyPorts(config, len(networkConfigs)))
+
+		return ready, node.update(config, labels, ready, message)
+	}
+
+	if s, err := os.Stat(nfdFeatureDir); err == nil && s.IsDir() {
+		content := strings.Join(labels, "\n") + "\n"
+
+		if err := os.WriteFile(nfdLabelFile, []byte(content), 0644); err != nil {
+			return ready, fmt.Errorf("Failed to write NFD label to indicate scale-out readiness: %+v\n", err)
+		}
+	}
+
+	return ready, nil
+}
+
+// removeReadinessLabel removes the NFD labels indicating scale-out
+// readiness.
+func removeReadinessLabel() error {
+	if err := os.Remove(nfdLabelFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadinessLabels(t *testing.T) {
+	config := &cmdConfig{mode: L3}
+
+	nwconfigs := getFakeNetworkDataConfigs()
+	nwconfigs["eth_a"].configured = true
+	nwconfigs["eth_c"].configured = true
+
+	labels, ready := readinessLabels(config, nwconfigs, nil)
+	if ready {
+		t.Error("node ready without all interfaces configured")
+	}
+	if !reflect.DeepEqual(labels, []string{
+		"intel.feature.node.kubernetes.io/gaudi-scale-out-ports=2",
+		"intel.feature.node.kubernetes.io/gaudi-scale-out-ports-total=3",
+		"intel.feature.node.kubernetes.io/gaudi-scale-out-port-eth_a=true",
+		"intel.feature.node.kubernetes.io/gaudi-scale-out-port-eth_b=false",
+		"intel.feature.node.kubernetes.io/gaudi-scale-out-port-eth_c=true",
+	}) {
+		t.Errorf("unexpected labels %v", labels)
+	}
+
+	config.minReadyPorts = 2
+	labels, ready = readinessLabels(config, nwconfigs, nil)
+	if !ready || labels[0] != "intel.feature.node.kubernetes.io/gaudi-scale-out=true" {
+		t.Errorf("node not ready with the minimum number of ports: %v", labels)
+	}
+
+	_, ready = readinessLabels(config, nwconfigs, map[string]bool{"eth_c": true})
+	if ready {
+		t.Error("node ready with an interface down")
+	}
+
+	// in L2 mode the interfaces only need to be up
+	config = &cmdConfig{mode: L2}
+	if _, ready = readinessLabels(config, getFakeNetworkDataConfigs(), nil); !ready {
+		t.Error("L2 node not ready")
+	}
+
+	// interface names not valid in labels are left out
+	nwconfigs["eth/d"] = nwconfigs["eth_a"]
+	if labels, _ = readinessLabels(config, nwconfigs, nil); len(labels) != 6 {
+		t.Errorf("unexpected labels %v", labels)
+	}
+}
+
+func TestWriteReadinessLabels(t *testing.T) {
+	defer func(dir, file string) {
+		nfdFeatureDir, nfdLabelFile = dir, file
+	}(nfdFeatureDir, nfdLabelFile)
+
+	nfdFeatureDir = filepath.Join(t.TempDir(), "features.d")
+	nfdLabelFile = filepath.Join(nfdFeatureDir, "scale-out-readiness.txt")
+
+	config := &cmdConfig{mode: L2}
+	nwconfigs := getFakeNetworkDataConfigs()
+
+	// without NFD no labels are written
+	if ready, err := publishReadiness(config, nil, nwconfigs, nil); !ready || err != nil {
+		t.Errorf("unexpected readiness %v: %v", ready, err)
+	}
+	if _, err := os.Stat(nfdLabelFile); !os.IsNotExist(err) {
+		t.Errorf("labels written without NFD: %v", err)
+	}
+
+	if err := os.Mkdir(nfdFeatureDir, 0755); err != nil {
+		t.Fatalf("cannot create NFD directory: %v", err)
+	}
+
+	if _, err := publishReadiness(config, nil, nwconfigs, nil); err != nil {
+		t.Errorf("cannot write labels: %v", err)
+	}
+
+	data, err := os.ReadFile(nfdLabelFile)
+	if err != nil {
+		t.Fatalf("labels not written: %v", err)
+	}
+
+	labels, _ := readinessLabels(config, nwconfigs, nil)
+	expected := ""
+	for _, label := range labels {
+		expected += label + "\n"
+	}
+	if string(data) != expected {
+		t.Errorf("unexpected labels file content '%s'", data)
+	}
+
+	if err := removeReadinessLabel(); err != nil {
+		t.Errorf("cannot remove labels: %v", err)
+	}
+	if err := removeReadinessLabel(); err != nil {
+		t.Errorf("removing missing labels failed: %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/vishvananda/netlink"
+	"k8s.io/klog/v2"
+)
+
+const (
+	reportStdout = "-"
+
+	outcomeDiscovered    = "discovered"
+	outcomeConfigured    = "configured"
+	outcomeNotConfigured = "not-configured"
+	// L2 mode without addresses only sets the links up
+	outcomeLinkOnly = "link-only"
+)
+
+// where the report is written to with --report=-
+var reportOutput io.Writer = os.Stdout
+
+// interfaceReport is the report record of one interface.
+type interfaceReport struct {
+	Name           string   `json:"ifname"`
+	PCIAddress     string   `json:"pciAddress,omitempty"`
+	ModuleID       string   `json:"moduleId,omitempty"`
+	VLAN           string   `json:"vlan,omitempty"`
+	Up             bool     `json:"up"`
+	LinkState      string   `json:"linkState"`
+	MTU            int      `json:"mtu"`
+	Addresses      []string `json:"addresses"`
+	PeerMAC        string   `json:"peerMac,omitempty"`
+	PeerAddress    string   `json:"peerAddress,omitempty"`
+	PortID         string   `json:"portId,omitempty"`
+	SysName        string   `json:"sysName,omitempty"`
+	SysDescription string   `json:"sysDescription,omitempty"`
+	Outcome        string   `json:"outcome"`
+}
+
+// discoverReport is the machine-readable result of a discover run.
+type discoverReport struct {
+	Node       string            `json:"node,omitempty"`
+	Mode       string            `json:"mode"`
+	State      string            `json:"state"`
+	Message    string            `json:"message,omitempty"`
+	Time       string            `json:"time"`
+	Interfaces []interfaceReport `json:"interfaces"`
+}
+
+func interfaceOutcome(config *cmdConfig, nwconfig *networkConfiguration) string {
+	switch {
+	case !config.configure:
+		return outcomeDiscovered
+	case nwconfig.configured:
+		return outcomeConfigured
+	case config.mode == L2 && config.l2AddressSource == "":
+		return outcomeLinkOnly
+	default:
+		return outcomeNotConfigured
+	}
+}
+
+func interfaceReports(config *cmdConfig, networkConfigs map[string]*networkConfiguration) []interfaceReport {
+	reports := []interfaceReport{}
+
+	for ifname, nwconfig := range networkConfigs {
+		link := nwconfig.link
+		if updated, err := networkLink.LinkByName(ifname); err == nil {
+			link = updated
+		}
+
+		report := interfaceReport{
+			Name:           ifname,
+			PCIAddress:     nwconfig.pciAddress,
+			ModuleID:       nwconfig.moduleId,
+			Up:             link.Attrs().Flags&net.FlagUp != 0,
+			LinkState:      link.Attrs().OperState.String(),
+			MTU:            link.Attrs().MTU,
+			Addresses:      []string{},
+			PortID:         nwconfig.portID,
+			SysName:        nwconfig.sysName,
+			SysDescription: nwconfig.sysDescription,
+			Outcome:        interfaceOutcome(config, nwconfig),
+		}
+
+		if nwconfig.vlanLink != nil {
+			report.VLAN = nwconfig.vlanLink.Attrs().Name
+		}
+
+		addrs, err := networkLink.AddrList(l3Link(nwconfig), netlink.FAMILY_ALL)
+		if err != nil {
+			klog.Warningf("Cannot list interface '%s' addresses for the report: %v", ifname, err)
+		}
+		for _, addr := range addrs {
+			report.Addresses = append(report.Addresses, addr.IPNet.String())
+		}
+
+		if nwconfig.peerHWAddr != nil {
+			report.PeerMAC = nwconfig.peerHWAddr.String()
+		}
+		if nwconfig.lldpPeer != nil {
+			report.PeerAddress = nwconfig.lldpPeer.String()
+		} else if nwconfig.gateway != nil {
+			report.PeerAddress = nwconfig.gateway.String()
+		}
+
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Name < reports[j].Name
+	})
+
+	return reports
+}
+
+// writeReport writes the report to the --report file, or to the standard
+// output with '-'. The file is replaced atomically so that readers never
+// see a partial report. Failures are only logged like with the node state.
+func writeReport(config *cmdConfig, networkConfigs map[string]*networkConfiguration, state string, reportErr error) {
+	if config.report == "" {
+		return
+	}
+
+	report := discoverReport{
+		Node:       config.nodeName,
+		Mode:       config.mode,
+		State:      state,
+		Time:       time.Now().UTC().Format(time.RFC3339),
+		Interfaces: interfaceReports(config, networkConfigs),
+	}
+	if reportErr != nil {
+		report.Message = reportErr.Error()
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		klog.Warningf("Cannot marshal report: %v", err)
+		return
+	}
+	data = append(data, '\n')
+
+	if config.report == reportStdout {
+		if _, err := reportOutput.Write(data); err != nil {
+			klog.Warningf("Cannot write report: %v", err)
+		}
+		return
+	}
+
+	if err := writeFileAtomic(config.report, data); err != nil {
+		klog.Warningf("Cannot write report: %v", err)
+		return
+	}
+
+	klog.V(3).Infof("Wrote report to %s", config.report)
+}
+
+// writeFileAtomic writes the file through a temporary file in the same
+// directory, creating the directory if needed.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	return nil
+}
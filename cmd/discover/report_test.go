@@ -0,0 +1,118 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/vishvananda/netlink"
+)
+
+func TestWriteReport(t *testing.T) {
+	orig, origOutput := networkLink, reportOutput
+	t.Cleanup(func() { networkLink, reportOutput = orig, origOutput })
+
+	networkLink.LinkByName = func(name string) (netlink.Link, error) {
+		return nil, fmt.Errorf("link '%s' not found", name)
+	}
+	networkLink.AddrList = func(link netlink.Link, family int) ([]netlink.Addr, error) {
+		if link.Attrs().Name != "eth0" {
+			return nil, nil
+		}
+		addr, _ := netlink.ParseAddr("10.210.8.121/30")
+		return []netlink.Addr{*addr}, nil
+	}
+
+	eth0 := fakeVLANConfig("eth0", 1, "10.210.8.121/30")
+	eth0.pciAddress = "0000:aa:00.0"
+	eth0.moduleId = "3"
+	eth0.link.Attrs().Flags = net.FlagUp
+	eth0.link.Attrs().OperState = netlink.OperUp
+	eth0.link.Attrs().MTU = 8000
+	eth0.sysName = "leaf1"
+	eth0.sysDescription = "switch no-alert 10.210.8.122/30"
+	eth0.configured = true
+	peerMAC, _ := net.ParseMAC("02:00:00:00:00:01")
+	eth0.peerHWAddr = &peerMAC
+
+	eth1 := fakeL2Config("eth1", 2, "00:00:00:00:00:11")
+	eth1.link.Attrs().OperState = netlink.OperDown
+
+	networkConfigs := map[string]*networkConfiguration{"eth1": eth1, "eth0": eth0}
+
+	config := &cmdConfig{configure: true, mode: L3, nodeName: "node1", report: reportStdout}
+
+	var out bytes.Buffer
+	reportOutput = &out
+
+	writeReport(config, networkConfigs, "Failed", fmt.Errorf("Not all interfaces were configured (1/2)."))
+
+	report := discoverReport{}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("cannot parse report '%s': %v", out.String(), err)
+	}
+
+	if report.Node != "node1" || report.Mode != L3 || report.State != "Failed" ||
+		report.Message != "Not all interfaces were configured (1/2)." || report.Time == "" {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	expected := []interfaceReport{
+		{
+			Name: "eth0", PCIAddress: "0000:aa:00.0", ModuleID: "3", Up: true, LinkState: "up",
+			MTU: 8000, Addresses: []string{"10.210.8.121/30"},
+			PeerMAC: "02:00:00:00:00:01", PeerAddress: "10.210.8.122",
+			SysName: "leaf1", SysDescription: "switch no-alert 10.210.8.122/30",
+			Outcome: outcomeConfigured,
+		},
+		{Name: "eth1", LinkState: "down", Addresses: []string{}, Outcome: outcomeNotConfigured},
+	}
+	if !reflect.DeepEqual(report.Interfaces, expected) {
+		t.Errorf("unexpected interface reports %+v, expected %+v", report.Interfaces, expected)
+	}
+
+	config = &cmdConfig{mode: L2, report: filepath.Join(t.TempDir(), "reports", "discover.json")}
+
+	writeReport(config, networkConfigs, "Discovered", nil)
+
+	data, err := os.ReadFile(config.report)
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+
+	report = discoverReport{}
+	if err := json.Unmarshal(data, &report); err != nil || len(report.Interfaces) != 2 {
+		t.Fatalf("unexpected report '%s': %v", string(data), err)
+	}
+	for _, r := range report.Interfaces {
+		if r.Outcome != outcomeDiscovered {
+			t.Errorf("unexpected interface '%s' outcome %s", r.Name, r.Outcome)
+		}
+	}
+
+	config.configure = true
+	if outcome := interfaceOutcome(config, eth1); outcome != outcomeLinkOnly {
+		t.Errorf("unexpected L2 outcome %s", outcome)
+	}
+}
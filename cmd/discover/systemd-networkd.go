@@ -18,7 +18,6 @@ package main
 
 import (
 	"fmt"
-	"net"
 	"os"
 	"path/filepath"
 
@@ -33,6 +32,10 @@ func networkdFilename(networkdpath string, ifname string) string {
 	return filepath.Join(networkdpath, ifname+".network")
 }
 
+func netdevFilename(networkdpath string, ifname string) string {
+	return filepath.Join(networkdpath, ifname+".netdev")
+}
+
 func checkNetworkConfig(ifname string, nwconfig *networkConfiguration) error {
 	if nwconfig.link == nil {
 		return fmt.Errorf("no link information for %s", ifname)
@@ -48,31 +51,91 @@ func checkNetworkConfig(ifname string, nwconfig *networkConfiguration) error {
 	return nil
 }
 
+// l3Network returns the [Network] and [Route] sections with the address
+// and routes of the interface.
+func l3Network(ifname string, nwconfig *networkConfiguration) string {
+	network := fmt.Sprintf("[Network]\n"+
+		"Description=Networkd configuration for %s created by network-operator\n"+
+		"Address=%s/%d\n",
+		ifname,
+		nwconfig.localAddr.String(), prefixLength(nwconfig, RouteMaskPointToPoint),
+	)
+
+	for _, dst := range routedNetworks(nwconfig) {
+		network += fmt.Sprintf("\n"+
+			"[Route]\n"+
+			"Destination=%s\n",
+			dst.String(),
+		)
+	}
+
+	return network
+}
+
+func writeNetworkdFile(filename string, content string) error {
+	if err := WriteFile(filename, []byte(content), 0644); err != nil {
+		return fmt.Errorf("could not write networkd config file '%s': %v", filename, err)
+	}
+
+	return nil
+}
+
 func writeNetwork(networkdpath string, ifname string, nwconfig *networkConfiguration) error {
-	networkMask := net.CIDRMask(int(RouteMaskRoutedNetwork), 32)
-	networkAddr := nwconfig.localAddr.Mask(networkMask)
+	network := fmt.Sprintf("[Match]\n"+
+		"MACAddress=%s\n"+
+		"\n",
+		nwconfig.link.Attrs().HardwareAddr.String(),
+	)
+	network += l3Network(ifname, nwconfig)
+
+	return writeNetworkdFile(networkdFilename(networkdpath, ifname), network)
+}
+
+// writeVLANNetwork writes the VLAN netdev and the network of its
+// sub-interface carrying the address and routes. The network of the
+// interface only attaches the VLAN, matching the type as the sub-interface
+// has the same MAC address.
+func writeVLANNetwork(networkdpath string, ifname string, nwconfig *networkConfiguration) error {
+	vlan := vlanName(ifname, nwconfig.vlanID)
 
 	network := fmt.Sprintf("[Match]\n"+
 		"MACAddress=%s\n"+
+		"Type=ether\n"+
 		"\n"+
 		"[Network]\n"+
 		"Description=Networkd configuration for %s created by network-operator\n"+
-		"Address=%s/%d\n"+
-		"\n"+
-		"[Route]\n"+
-		"Destination=%s/%d\n",
+		"VLAN=%s\n",
 		nwconfig.link.Attrs().HardwareAddr.String(),
 		ifname,
-		nwconfig.localAddr.String(), int(RouteMaskPointToPoint),
-		networkAddr, int(RouteMaskRoutedNetwork),
+		vlan,
 	)
 
-	filename := networkdFilename(networkdpath, ifname)
-	if err := os.WriteFile(filename, []byte(network), 0644); err != nil {
-		return fmt.Errorf("could not write networkd config file '%s': %v", filename, err)
+	netdev := fmt.Sprintf("[NetDev]\n"+
+		"Name=%s\n"+
+		"Kind=vlan\n"+
+		"\n"+
+		"[VLAN]\n"+
+		"Id=%d\n",
+		vlan,
+		nwconfig.vlanID,
+	)
+
+	vlanNetwork := fmt.Sprintf("[Match]\n"+
+		"Name=%s\n"+
+		"\n",
+		vlan,
+	)
+	vlanNetwork += l3Network(vlan, nwconfig)
+
+	if err := writeNetworkdFile(networkdFilename(networkdpath, ifname), network); err != nil {
+		return err
 	}
 
-	return nil
+	if err := writeNetworkdFile(netdevFilename(networkdpath, vlan), netdev); err != nil {
+		return err
+	}
+
+	return writeNetworkdFile(networkdFilename(networkdpath, vlan), vlanNetwork)
 }
 
 func WriteSystemdNetworkd(networkdpath string, networkConfigs map[string]*networkConfiguration) ([]string, error) {
@@ -85,6 +148,17 @@ func WriteSystemdNetworkd(networkdpath string, networkConfigs map[string]*networ
 	}
 
 	for ifname, nwconfig := range networkConfigs {
+		if nwconfig.vlanID != 0 {
+			// the files of both are removed on failure
+			configured = append(configured, ifname, vlanName(ifname, nwconfig.vlanID))
+
+			if err := writeVLANNetwork(networkdpath, ifname, nwconfig); err != nil {
+				DeleteSystemdNetworkd(networkdpath, configured)
+				return nil, err
+			}
+			continue
+		}
+
 		if err := writeNetwork(networkdpath, ifname, nwconfig); err != nil {
 			DeleteSystemdNetworkd(networkdpath, configured)
 			return nil, err
@@ -97,9 +171,23 @@ func WriteSystemdNetworkd(networkdpath string, networkConfigs map[string]*networ
 
 func DeleteSystemdNetworkd(networkdpath string, configuredInterfaces []string) {
 	for _, ifname := range configuredInterfaces {
-		filename := networkdFilename(networkdpath, ifname)
-		if err := os.Remove(filename); err != nil {
-			klog.Warningf("Failed to remove systemd-networkd file '%s': %v\n", filename, err)
+		for _, filename := range []string{networkdFilename(networkdpath, ifname), netdevFilename(networkdpath, ifname)} {
+			if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+				klog.Warningf("Failed to remove systemd-networkd file '%s': %v\n", filename, err)
+			}
+		}
+	}
+}
+
+// DeleteSystemdNetworkdVLANs removes the files of the interfaces with a
+// VLAN, as the VLAN sub-interfaces are removed when exiting.
+func DeleteSystemdNetworkdVLANs(networkdpath string, networkConfigs map[string]*networkConfiguration) {
+	names := []string{}
+	for ifname, nwconfig := range networkConfigs {
+		if nwconfig.vlanID != 0 {
+			names = append(names, ifname, vlanName(ifname, nwconfig.vlanID))
 		}
 	}
+
+	DeleteSystemdNetworkd(networkdpath, names)
 }
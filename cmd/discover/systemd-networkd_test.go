@@ -90,6 +90,83 @@ func fakesystemdnetworkdconfigs() (map[string]*networkConfiguration, map[string]
 	return nwconfigs, expectedoutput
 }
 
+func TestSystemdNetworkdIPv6(t *testing.T) {
+	testDir, err := os.MkdirTemp("", "networkoperator.")
+	if err != nil {
+		t.Errorf("cannot create tmp dir: %v", err)
+	}
+	defer os.RemoveAll(testDir)
+
+	addr := net.ParseIP("2001:db8:0:1::3")
+	nwconfig := &networkConfiguration{
+		link: &fakeLink{
+			fakeAttrs: netlink.LinkAttrs{
+				HardwareAddr: net.HardwareAddr{0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f},
+			},
+		},
+		localAddr:      &addr,
+		localPrefixLen: 127,
+	}
+
+	expectedstr := "[Match]\nMACAddress=0a:0b:0c:0d:0e:0f\n\n" +
+		"[Network]\nDescription=Networkd configuration for eth_a created by network-operator\n" +
+		"Address=2001:db8:0:1::3/127\n\n" +
+		"[Route]\nDestination=2001:db8:0:1::/64\n"
+
+	if _, err := WriteSystemdNetworkd(testDir, map[string]*networkConfiguration{"eth_a": nwconfig}); err != nil {
+		t.Fatalf("could not create config file: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(testDir, "eth_a.network"))
+	if err != nil {
+		t.Fatalf("could not read config file: %v", err)
+	}
+
+	if string(content) != expectedstr {
+		t.Errorf("expected content '%s', got '%s'", expectedstr, string(content))
+	}
+}
+
+func TestSystemdNetworkdRouteDestinations(t *testing.T) {
+	testDir, err := os.MkdirTemp("", "networkoperator.")
+	if err != nil {
+		t.Errorf("cannot create tmp dir: %v", err)
+	}
+	defer os.RemoveAll(testDir)
+
+	addr := net.IPv4(10, 210, 8, 121)
+	_, dst1, _ := net.ParseCIDR("10.210.0.0/20")
+	_, dst2, _ := net.ParseCIDR("10.230.0.0/16")
+	nwconfig := &networkConfiguration{
+		link: &fakeLink{
+			fakeAttrs: netlink.LinkAttrs{
+				HardwareAddr: net.HardwareAddr{0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f},
+			},
+		},
+		localAddr: &addr,
+		routeDsts: []*net.IPNet{dst1, dst2},
+	}
+
+	expectedstr := "[Match]\nMACAddress=0a:0b:0c:0d:0e:0f\n\n" +
+		"[Network]\nDescription=Networkd configuration for eth_a created by network-operator\n" +
+		"Address=10.210.8.121/30\n\n" +
+		"[Route]\nDestination=10.210.0.0/20\n\n" +
+		"[Route]\nDestination=10.230.0.0/16\n"
+
+	if _, err := WriteSystemdNetworkd(testDir, map[string]*networkConfiguration{"eth_a": nwconfig}); err != nil {
+		t.Fatalf("could not create config file: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(testDir, "eth_a.network"))
+	if err != nil {
+		t.Fatalf("could not read config file: %v", err)
+	}
+
+	if string(content) != expectedstr {
+		t.Errorf("expected content '%s', got '%s'", expectedstr, string(content))
+	}
+}
+
 func TestSystemdNetworkdConfig(t *testing.T) {
 	testDir, err := os.MkdirTemp("", "networkoperator.")
 	if err != nil {
@@ -200,3 +277,61 @@ func TestDeleteSystemdNetworkd(t *testing.T) {
 		}
 	}
 }
+
+func TestSystemdNetworkdVLAN(t *testing.T) {
+	testDir, err := os.MkdirTemp("", "networkoperator.")
+	if err != nil {
+		t.Errorf("cannot create tmp dir: %v", err)
+	}
+	defer os.RemoveAll(testDir)
+
+	addr := net.IPv4(10, 210, 8, 121)
+	nwconfig := &networkConfiguration{
+		link: &fakeLink{
+			fakeAttrs: netlink.LinkAttrs{
+				HardwareAddr: net.HardwareAddr{0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f},
+			},
+		},
+		localAddr: &addr,
+		vlanID:    100,
+	}
+	nwconfigs := map[string]*networkConfiguration{"eth_a": nwconfig}
+
+	expected := map[string]string{
+		"eth_a.network": "[Match]\nMACAddress=0a:0b:0c:0d:0e:0f\nType=ether\n\n" +
+			"[Network]\nDescription=Networkd configuration for eth_a created by network-operator\n" +
+			"VLAN=eth_a.100\n",
+		"eth_a.100.netdev": "[NetDev]\nName=eth_a.100\nKind=vlan\n\n" +
+			"[VLAN]\nId=100\n",
+		"eth_a.100.network": "[Match]\nName=eth_a.100\n\n" +
+			"[Network]\nDescription=Networkd configuration for eth_a.100 created by network-operator\n" +
+			"Address=10.210.8.121/30\n\n" +
+			"[Route]\nDestination=10.210.0.0/16\n",
+	}
+
+	configured, err := WriteSystemdNetworkd(testDir, nwconfigs)
+	if err != nil {
+		t.Fatalf("could not create config files: %v", err)
+	}
+	if len(configured) != 2 {
+		t.Errorf("expected the interface and its VLAN, got %v", configured)
+	}
+
+	for filename, expectedstr := range expected {
+		content, err := os.ReadFile(filepath.Join(testDir, filename))
+		if err != nil {
+			t.Fatalf("could not read config file: %v", err)
+		}
+
+		if string(content) != expectedstr {
+			t.Errorf("%s: expected content '%s', got '%s'", filename, expectedstr, string(content))
+		}
+	}
+
+	DeleteSystemdNetworkdVLANs(testDir, nwconfigs)
+
+	paths, _ := filepath.Glob(filepath.Join(testDir, "*"))
+	if len(paths) != 0 {
+		t.Errorf("VLAN files not removed: %v", paths)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/vishvananda/netlink"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// maximum length of a network interface name, IFNAMSIZ - 1
+	maxInterfaceNameLen = 15
+
+	minVLAN = 1
+	maxVLAN = 4094
+)
+
+// vlanName returns the name of the VLAN sub-interface of the interface.
+func vlanName(ifname string, vlanID int) string {
+	return fmt.Sprintf("%s.%d", ifname, vlanID)
+}
+
+// l3Link returns the link carrying the addresses and routes of the
+// interface, which is the VLAN sub-interface if one was created.
+func l3Link(nwconfig *networkConfiguration) netlink.Link {
+	if nwconfig.vlanLink != nil {
+		return nwconfig.vlanLink
+	}
+
+	return nwconfig.link
+}
+
+// interfaceLinks returns the link of the interface followed by its VLAN
+// sub-interface if one was created.
+func interfaceLinks(nwconfig *networkConfiguration) []netlink.Link {
+	if nwconfig.vlanLink != nil {
+		return []netlink.Link{nwconfig.link, nwconfig.vlanLink}
+	}
+
+	return []netlink.Link{nwconfig.link}
+}
+
+// setVLANs sets the VLAN ID given on the command line for all interfaces.
+// Address plan entries may override it per interface later on.
+func setVLANs(config *cmdConfig, networkConfigs map[string]*networkConfiguration) {
+	for _, nwconfig := range networkConfigs {
+		nwconfig.vlanID = config.vlan
+	}
+}
+
+// createVLAN creates the 802.1Q sub-interface of the interface and sets it
+// up. An existing sub-interface is reused if it is the VLAN of the
+// interface, but only the ones created by the agent are removed again.
+func createVLAN(ifname string, nwconfig *networkConfiguration, mtu int) error {
+	name := vlanName(ifname, nwconfig.vlanID)
+	if len(name) > maxInterfaceNameLen {
+		return fmt.Errorf("VLAN interface name '%s' is longer than %d characters", name, maxInterfaceNameLen)
+	}
+
+	attrs := netlink.NewLinkAttrs()
+	attrs.Name = name
+	attrs.ParentIndex = nwconfig.link.Attrs().Index
+	attrs.MTU = mtu
+
+	vlan := &netlink.Vlan{
+		LinkAttrs:    attrs,
+		VlanId:       nwconfig.vlanID,
+		VlanProtocol: netlink.VLAN_PROTOCOL_8021Q,
+	}
+
+	created := true
+	if err := networkLink.LinkAdd(vlan); err != nil {
+		if !errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("cannot create VLAN interface '%s': %v", name, err)
+		}
+		created = false
+	}
+
+	link, err := networkLink.LinkByName(name)
+	if err != nil {
+		return fmt.Errorf("VLAN interface '%s' not found: %v", name, err)
+	}
+
+	if !created {
+		existing, ok := link.(*netlink.Vlan)
+		if !ok || existing.VlanId != nwconfig.vlanID || existing.ParentIndex != attrs.ParentIndex {
+			return fmt.Errorf("interface '%s' exists but is not VLAN %d of interface '%s'", name, nwconfig.vlanID, ifname)
+		}
+	}
+
+	// set before bringing the link up so that a failure removes it
+	nwconfig.vlanLink = link
+	if created {
+		nwconfig.vlanCreated = true
+	}
+
+	if err := networkLink.LinkSetUp(link); err != nil {
+		return fmt.Errorf("cannot set VLAN interface '%s' up: %v", name, err)
+	}
+
+	if created {
+		klog.Infof("Created VLAN interface '%s' with VLAN ID %d", name, nwconfig.vlanID)
+	} else {
+		klog.Infof("Using existing VLAN interface '%s' with VLAN ID %d", name, nwconfig.vlanID)
+	}
+
+	return nil
+}
+
+// restoreVLAN recreates the VLAN sub-interface of the interface when it was
+// removed together with its parent, e.g. by a driver reset.
+func restoreVLAN(ifname string, nwconfig *networkConfiguration) error {
+	if nwconfig.vlanLink == nil {
+		return nil
+	}
+
+	// the MTU is set afterwards, once the one of the parent is restored
+	return createVLAN(ifname, nwconfig, 0)
+}
+
+// createVLANs creates the VLAN sub-interfaces of all interfaces with a
+// VLAN ID. On failure the sub-interfaces already created are removed.
+func createVLANs(config *cmdConfig, networkConfigs map[string]*networkConfiguration) error {
+	for ifname, nwconfig := range networkConfigs {
+		if nwconfig.vlanID == 0 || nwconfig.vlanLink != nil {
+			continue
+		}
+
+		if err := createVLAN(ifname, nwconfig, config.mtu); err != nil {
+			deleteVLANs(networkConfigs)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteVLANs removes the VLAN sub-interfaces created for the interfaces.
+// Existing sub-interfaces the agent reused are left in place.
+func deleteVLANs(networkConfigs map[string]*networkConfiguration) {
+	for _, nwconfig := range networkConfigs {
+		if nwconfig.vlanLink == nil {
+			continue
+		}
+
+		if !nwconfig.vlanCreated {
+			nwconfig.vlanLink = nil
+			continue
+		}
+
+		name := nwconfig.vlanLink.Attrs().Name
+		if err := networkLink.LinkDel(nwconfig.vlanLink); err != nil {
+			klog.Warningf("Cannot remove VLAN interface '%s': %v", name, err)
+		} else {
+			klog.Infof("Removed VLAN interface '%s'", name)
+		}
+
+		nwconfig.vlanLink = nil
+		nwconfig.vlanCreated = false
+	}
+}
@@ -0,0 +1,288 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/vishvananda/netlink"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+)
+
+// fakeVLANs records the VLAN interfaces and their addresses and routes in
+// place of the kernel.
+type fakeVLANs struct {
+	vlans  map[string]*netlink.Vlan
+	up     map[string]bool
+	addrs  map[string][]string
+	routes map[int][]string
+}
+
+func newFakeVLANs() *fakeVLANs {
+	return &fakeVLANs{
+		vlans:  map[string]*netlink.Vlan{},
+		up:     map[string]bool{},
+		addrs:  map[string][]string{},
+		routes: map[int][]string{},
+	}
+}
+
+func (f *fakeVLANs) install(t *testing.T) {
+	orig := networkLink
+	t.Cleanup(func() { networkLink = orig })
+
+	networkLink.LinkAdd = func(link netlink.Link) error {
+		vlan, ok := link.(*netlink.Vlan)
+		if !ok {
+			return fmt.Errorf("unexpected link type %s", link.Type())
+		}
+		if _, exists := f.vlans[vlan.Name]; exists {
+			return os.ErrExist
+		}
+		vlan.Index = 100 + len(f.vlans)
+		f.vlans[vlan.Name] = vlan
+		return nil
+	}
+	networkLink.LinkDel = func(link netlink.Link) error {
+		delete(f.vlans, link.Attrs().Name)
+		return nil
+	}
+	networkLink.LinkByName = func(name string) (netlink.Link, error) {
+		if vlan, exists := f.vlans[name]; exists {
+			return vlan, nil
+		}
+		return nil, fmt.Errorf("link '%s' not found", name)
+	}
+	networkLink.LinkSetUp = func(link netlink.Link) error {
+		f.up[link.Attrs().Name] = true
+		return nil
+	}
+	networkLink.AddrList = func(link netlink.Link, family int) ([]netlink.Addr, error) {
+		return nil, nil
+	}
+	networkLink.AddrAdd = func(link netlink.Link, addr *netlink.Addr) error {
+		f.addrs[link.Attrs().Name] = append(f.addrs[link.Attrs().Name], addr.IPNet.String())
+		return nil
+	}
+	networkLink.RouteAppend = func(route *netlink.Route) error {
+		f.routes[route.LinkIndex] = append(f.routes[route.LinkIndex], route.Dst.String())
+		return nil
+	}
+}
+
+func fakeVLANConfig(name string, index int, addr string) *networkConfiguration {
+	ip, network, _ := net.ParseCIDR(addr)
+	prefixLen, _ := network.Mask.Size()
+	peer, _ := otherPointToPointAddress(ip, network)
+
+	return &networkConfiguration{
+		link:           &fakeLink{fakeAttrs: netlink.LinkAttrs{Name: name, Index: index}},
+		localAddr:      &ip,
+		localPrefixLen: prefixLen,
+		lldpPeer:       &peer,
+	}
+}
+
+func TestVLANs(t *testing.T) {
+	fake := newFakeVLANs()
+	fake.install(t)
+
+	config := &cmdConfig{vlan: 100, mtu: 9000}
+	networkConfigs := map[string]*networkConfiguration{
+		"eth0": fakeVLANConfig("eth0", 1, "10.210.8.121/30"),
+		"eth1": fakeVLANConfig("eth1", 2, "10.210.8.125/30"),
+	}
+
+	setVLANs(config, networkConfigs)
+
+	// per port override from the address plan
+	plan := []networkv1alpha1.InterfaceAddress{{Interface: "eth1", Address: "10.210.8.125/30", VLAN: 200}}
+	applyAddressPlan(plan, networkConfigs)
+
+	if err := createVLANs(config, networkConfigs); err != nil {
+		t.Fatalf("cannot create VLANs: %v", err)
+	}
+
+	expected := map[string]struct {
+		name   string
+		id     int
+		parent int
+	}{
+		"eth0": {"eth0.100", 100, 1},
+		"eth1": {"eth1.200", 200, 2},
+	}
+
+	for ifname, e := range expected {
+		vlan, exists := fake.vlans[e.name]
+		if !exists {
+			t.Fatalf("%s: VLAN interface '%s' not created", ifname, e.name)
+		}
+		if vlan.VlanId != e.id || vlan.ParentIndex != e.parent || vlan.MTU != config.mtu {
+			t.Errorf("%s: unexpected VLAN interface %+v", ifname, vlan.LinkAttrs)
+		}
+		if !fake.up[e.name] {
+			t.Errorf("%s: VLAN interface '%s' not set up", ifname, e.name)
+		}
+		if l3Link(networkConfigs[ifname]).Attrs().Name != e.name {
+			t.Errorf("%s: addresses not configured on the VLAN interface", ifname)
+		}
+	}
+
+	if configured, total := configureInterfaces(networkConfigs); configured != total {
+		t.Errorf("configured %d of %d interfaces", configured, total)
+	}
+
+	if addrs := fake.addrs["eth0.100"]; len(addrs) != 1 || addrs[0] != "10.210.8.121/30" {
+		t.Errorf("unexpected VLAN interface addresses %v", addrs)
+	}
+	if len(fake.addrs["eth0"]) != 0 {
+		t.Errorf("unexpected addresses on the parent interface %v", fake.addrs["eth0"])
+	}
+
+	index := fake.vlans["eth0.100"].Index
+	if routes := fake.routes[index]; len(routes) != 1 || routes[0] != "10.210.0.0/16" {
+		t.Errorf("unexpected VLAN interface routes %v", routes)
+	}
+
+	// creating the VLANs again reuses the existing interfaces
+	for _, nwconfig := range networkConfigs {
+		nwconfig.vlanLink = nil
+	}
+	if err := createVLANs(config, networkConfigs); err != nil {
+		t.Fatalf("cannot reuse VLANs: %v", err)
+	}
+
+	deleteVLANs(networkConfigs)
+
+	if len(fake.vlans) != 0 {
+		t.Errorf("VLAN interfaces not removed: %v", fake.vlans)
+	}
+	for ifname, nwconfig := range networkConfigs {
+		if l3Link(nwconfig) != nwconfig.link {
+			t.Errorf("%s: VLAN interface still in use", ifname)
+		}
+	}
+}
+
+func TestVLANsFailure(t *testing.T) {
+	fake := newFakeVLANs()
+	fake.install(t)
+
+	config := &cmdConfig{vlan: 4094}
+	networkConfigs := map[string]*networkConfiguration{
+		"eth0":            fakeVLANConfig("eth0", 1, "10.210.8.121/30"),
+		"eth_gaudi_long0": fakeVLANConfig("eth_gaudi_long0", 2, "10.210.8.125/30"),
+	}
+
+	setVLANs(config, networkConfigs)
+
+	if err := createVLANs(config, networkConfigs); err == nil {
+		t.Errorf("expected an error for a too long VLAN interface name")
+	}
+
+	if len(fake.vlans) != 0 {
+		t.Errorf("VLAN interfaces not removed on failure: %v", fake.vlans)
+	}
+}
+
+func TestVLANsExisting(t *testing.T) {
+	fake := newFakeVLANs()
+	fake.install(t)
+
+	config := &cmdConfig{vlan: 100}
+	networkConfigs := map[string]*networkConfiguration{
+		"eth0": fakeVLANConfig("eth0", 1, "10.210.8.121/30"),
+	}
+
+	setVLANs(config, networkConfigs)
+
+	existing := func(id, parent int) *netlink.Vlan {
+		attrs := netlink.NewLinkAttrs()
+		attrs.Name = "eth0.100"
+		attrs.Index = 50
+		attrs.ParentIndex = parent
+		return &netlink.Vlan{LinkAttrs: attrs, VlanId: id}
+	}
+
+	for name, vlan := range map[string]*netlink.Vlan{
+		"other VLAN ID": existing(200, 1),
+		"other parent":  existing(100, 2),
+	} {
+		fake.vlans["eth0.100"] = vlan
+		if err := createVLANs(config, networkConfigs); err == nil {
+			t.Errorf("%s: expected an error for a mismatching interface", name)
+		}
+		if fake.vlans["eth0.100"] != vlan {
+			t.Errorf("%s: mismatching interface removed", name)
+		}
+	}
+
+	fake.vlans["eth0.100"] = existing(100, 1)
+	if err := createVLANs(config, networkConfigs); err != nil {
+		t.Fatalf("cannot reuse VLAN: %v", err)
+	}
+	if l3Link(networkConfigs["eth0"]).Attrs().Index != 50 {
+		t.Errorf("existing VLAN interface not used")
+	}
+
+	deleteVLANs(networkConfigs)
+
+	if _, exists := fake.vlans["eth0.100"]; !exists {
+		t.Errorf("VLAN interface not created by the agent removed")
+	}
+}
+
+func TestVLANRestore(t *testing.T) {
+	fake := newFakeVLANs()
+	fake.install(t)
+
+	config := &cmdConfig{vlan: 100}
+	networkConfigs := map[string]*networkConfiguration{
+		"eth0": fakeVLANConfig("eth0", 1, "10.210.8.121/30"),
+	}
+
+	setVLANs(config, networkConfigs)
+
+	if err := createVLANs(config, networkConfigs); err != nil {
+		t.Fatalf("cannot create VLANs: %v", err)
+	}
+
+	// a driver reset removes the sub-interface with its parent
+	delete(fake.vlans, "eth0.100")
+	fake.up = map[string]bool{}
+	nwconfig := networkConfigs["eth0"]
+	nwconfig.link = &fakeLink{fakeAttrs: netlink.LinkAttrs{Name: "eth0", Index: 3}}
+
+	if err := restoreInterface(config, "eth0", nwconfig); err != nil {
+		t.Fatalf("cannot restore interface: %v", err)
+	}
+
+	vlan, exists := fake.vlans["eth0.100"]
+	if !exists || vlan.ParentIndex != 3 || !fake.up["eth0.100"] {
+		t.Fatalf("VLAN interface not recreated: %v", fake.vlans)
+	}
+	if l3Link(nwconfig) != vlan {
+		t.Errorf("stale VLAN interface still in use")
+	}
+	if addrs := fake.addrs["eth0.100"]; len(addrs) != 1 || addrs[0] != "10.210.8.121/30" {
+		t.Errorf("address not restored on the VLAN interface: %v", addrs)
+	}
+}
@@ -211,6 +211,20 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "NetworkClusterPolicy")
 		os.Exit(1)
 	}
+	if err = (&controller.ScaleOutAddressPoolReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ScaleOutAddressPool")
+		os.Exit(1)
+	}
+	if err = (&controller.NodeTaintReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NodeTaint")
+		os.Exit(1)
+	}
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		if err = (&networkv1alpha1.NetworkClusterPolicy{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "NetworkClusterPolicy")
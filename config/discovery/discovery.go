@@ -31,6 +31,15 @@ var contentGaudiDiscoveryDs []byte
 //go:embed generic/linkdiscovery-serviceaccount.yaml
 var contentLinkDiscoveryServiceAccount []byte
 
+//go:embed generic/linkdiscovery-clusterrole.yaml
+var contentLinkDiscoveryClusterRole []byte
+
+//go:embed generic/linkdiscovery-node-clusterrole.yaml
+var contentLinkDiscoveryNodeClusterRole []byte
+
+//go:embed generic/linkdiscovery-clusterrolebinding.yaml
+var contentLinkDiscoveryClusterRoleBinding []byte
+
 //go:embed openshift/rolebinding.yaml
 var contentOpenshiftRoleBinding []byte
 
@@ -45,6 +54,18 @@ func GaudiLinkDiscoveryServiceAccount() *core.ServiceAccount {
 	return helpers.GetServiceAccount(contentLinkDiscoveryServiceAccount).DeepCopy()
 }
 
+func GaudiLinkDiscoveryClusterRole() *rbac.ClusterRole {
+	return helpers.GetClusterRole(contentLinkDiscoveryClusterRole).DeepCopy()
+}
+
+func GaudiLinkDiscoveryNodeClusterRole() *rbac.ClusterRole {
+	return helpers.GetClusterRole(contentLinkDiscoveryNodeClusterRole).DeepCopy()
+}
+
+func GaudiLinkDiscoveryClusterRoleBinding() *rbac.ClusterRoleBinding {
+	return helpers.GetClusterRoleBinding(contentLinkDiscoveryClusterRoleBinding).DeepCopy()
+}
+
 func OpenShiftRoleBinding() *rbac.RoleBinding {
 	return helpers.GetRoleBinding(contentOpenshiftRoleBinding).DeepCopy()
 }
@@ -32,6 +32,27 @@ func TestGaudiServiceAccount(t *testing.T) {
 	}
 }
 
+func TestGaudiClusterRole(t *testing.T) {
+	cr := GaudiLinkDiscoveryClusterRole()
+	if cr == nil || len(cr.Rules) == 0 {
+		t.Error("expected to receive a valid cluster role")
+	}
+}
+
+func TestGaudiNodeClusterRole(t *testing.T) {
+	cr := GaudiLinkDiscoveryNodeClusterRole()
+	if cr == nil || len(cr.Rules) == 0 {
+		t.Error("expected to receive a valid node cluster role")
+	}
+}
+
+func TestGaudiClusterRoleBinding(t *testing.T) {
+	crb := GaudiLinkDiscoveryClusterRoleBinding()
+	if crb == nil || crb.RoleRef.Name != GaudiLinkDiscoveryClusterRole().Name {
+		t.Error("expected to receive a valid cluster role binding")
+	}
+}
+
 func TestOpenShiftRoleBinding(t *testing.T) {
 	rb := OpenShiftRoleBinding()
 	if rb == nil {
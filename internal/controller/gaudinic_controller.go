@@ -16,8 +16,13 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
@@ -26,12 +31,16 @@ import (
 	v1 "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
 
 	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
 	discovery "github.com/intel/network-operator/config/discovery"
@@ -52,15 +61,30 @@ const (
 	layerSelectionL2 = "L2"
 	layerSelectionL3 = "L3"
 
+	l2AddressSourceStatic = "static"
+
+	readinessNode = "node"
+
+	// keeps the policy until the readiness is removed from the Nodes
+	nodeReadinessFinalizer = "intel.com/node-readiness"
+
 	gaudinetPathHost      = "/etc/habanalabs/gaudinet.json"
 	gaudinetPathContainer = "/host" + gaudinetPathHost
 
+	// original interface state saved by the agent across restarts
+	statePathHost   = "/var/lib/network-operator"
+	stateVolumeName = "statepath"
+
 	discoveryContainer = "configurator"
 	lldpadID           = "lldpad"
 
 	emptyDirSize = "32Mi"
 
 	scaleOutMonitoringPort = 50152
+
+	addressPlanVolume        = "address-plan"
+	addressPlanPathContainer = "/address-plan"
+	addressPlanHashKey       = "intel.com/address-plan-hash"
 )
 
 func addHostVolume(ds *apps.DaemonSet, volumeType v1.HostPathType, volumeName, hostPath, containerPath string) {
@@ -133,13 +157,39 @@ func delHostVolumeIfExists(ds *apps.DaemonSet, volumeName string) {
 	}
 }
 
-func (r *GaudiNICReconciler) createOpenShiftCollateral(ctx context.Context, log logr.Logger, parent metav1.Object, serviceAccountName string) {
-	if serviceAccountName == "" {
-		return
+func addConfigMapVolume(ds *apps.DaemonSet, volumeName, configMapName, containerPath string) {
+	optional := true
+
+	volumeAdd := v1.Volume{
+		Name: volumeName,
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: configMapName},
+				Optional:             &optional,
+			},
+		},
 	}
 
-	log.Info("Creating OpenShift collateral")
+	for i, vol := range ds.Spec.Template.Spec.Volumes {
+		if vol.Name == volumeName {
+			ds.Spec.Template.Spec.Volumes[i] = volumeAdd
+			return
+		}
+	}
+
+	ds.Spec.Template.Spec.Volumes = append(ds.Spec.Template.Spec.Volumes, volumeAdd)
 
+	if len(ds.Spec.Template.Spec.Containers) > 0 {
+		c := &ds.Spec.Template.Spec.Containers[0]
+		c.VolumeMounts = append(c.VolumeMounts, v1.VolumeMount{
+			Name:      volumeName,
+			ReadOnly:  true,
+			MountPath: containerPath,
+		})
+	}
+}
+
+func (r *GaudiNICReconciler) createServiceAccount(ctx context.Context, log logr.Logger, parent metav1.Object, serviceAccountName string) error {
 	sa := discovery.GaudiLinkDiscoveryServiceAccount()
 	sa.Name = serviceAccountName
 	sa.ObjectMeta.Namespace = r.Namespace
@@ -147,19 +197,337 @@ func (r *GaudiNICReconciler) createOpenShiftCollateral(ctx context.Context, log
 	if err := ctrl.SetControllerReference(parent, sa, r.Scheme); err != nil {
 		log.Error(err, "unable to set controller reference (service account)")
 
-		return
+		return err
 	}
 
 	if err := r.Create(ctx, sa); err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			log.Error(err, "unable to create service account")
-
-			return
+		if apierrors.IsAlreadyExists(err) {
+			return nil
 		}
+
+		log.Error(err, "unable to create service account")
+
+		return err
 	}
 
 	log.Info("Service account created", "name", sa.Name)
 
+	return nil
+}
+
+// applyClusterRole creates the cluster role or updates its rules if they
+// differ from the expected ones.
+func (r *GaudiNICReconciler) applyClusterRole(ctx context.Context, log logr.Logger, parent metav1.Object, cr *rbac.ClusterRole) error {
+	existing := &rbac.ClusterRole{}
+	if err := r.Get(ctx, client.ObjectKey{Name: cr.Name}, existing); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			log.Error(err, "unable to fetch cluster role", "name", cr.Name)
+
+			return err
+		}
+
+		if err := ctrl.SetControllerReference(parent, cr, r.Scheme); err != nil {
+			log.Error(err, "unable to set controller reference (clusterrole)")
+
+			return err
+		}
+
+		if err := r.Create(ctx, cr); err != nil {
+			log.Error(err, "unable to create cluster role", "name", cr.Name)
+
+			return err
+		}
+
+		log.Info("Cluster role created", "name", cr.Name)
+
+		return nil
+	}
+
+	if len(cmp.Diff(existing.Rules, cr.Rules, cmpopts.EquateEmpty())) == 0 {
+		return nil
+	}
+
+	existing.Rules = cr.Rules
+	if err := r.Update(ctx, existing); err != nil {
+		log.Error(err, "unable to update cluster role", "name", cr.Name)
+
+		return err
+	}
+
+	log.Info("Cluster role updated", "name", cr.Name)
+
+	return nil
+}
+
+// applyClusterRoleBinding creates the cluster role binding or updates its
+// subjects if they differ from the expected ones.
+func (r *GaudiNICReconciler) applyClusterRoleBinding(ctx context.Context, log logr.Logger, parent metav1.Object, crb *rbac.ClusterRoleBinding) error {
+	existing := &rbac.ClusterRoleBinding{}
+	if err := r.Get(ctx, client.ObjectKey{Name: crb.Name}, existing); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			log.Error(err, "unable to fetch cluster role binding", "name", crb.Name)
+
+			return err
+		}
+
+		if err := ctrl.SetControllerReference(parent, crb, r.Scheme); err != nil {
+			log.Error(err, "unable to set controller reference (clusterrolebinding)")
+
+			return err
+		}
+
+		if err := r.Create(ctx, crb); err != nil {
+			log.Error(err, "unable to create cluster role binding", "name", crb.Name)
+
+			return err
+		}
+
+		log.Info("Cluster role binding created", "name", crb.Name)
+
+		return nil
+	}
+
+	if len(cmp.Diff(existing.Subjects, crb.Subjects, cmpopts.EquateEmpty())) == 0 {
+		return nil
+	}
+
+	existing.Subjects = crb.Subjects
+	if err := r.Update(ctx, existing); err != nil {
+		log.Error(err, "unable to update cluster role binding", "name", crb.Name)
+
+		return err
+	}
+
+	log.Info("Cluster role binding updated", "name", crb.Name)
+
+	return nil
+}
+
+// linkDiscoveryBinding returns the binding of the named cluster role to the
+// service account of the discover agents.
+func (r *GaudiNICReconciler) linkDiscoveryBinding(name, serviceAccountName string) *rbac.ClusterRoleBinding {
+	crb := discovery.GaudiLinkDiscoveryClusterRoleBinding()
+	crb.Name = name
+	crb.RoleRef.Name = name
+	crb.Subjects = []rbac.Subject{
+		{
+			Kind:      "ServiceAccount",
+			Name:      serviceAccountName,
+			Namespace: r.Namespace,
+		},
+	}
+
+	return crb
+}
+
+// reconcileNodeStateCollateral keeps the service account of the discover
+// agents and the cluster role they report their NetworkNodeState with up to
+// date, also for policies created by earlier operator versions.
+func (r *GaudiNICReconciler) reconcileNodeStateCollateral(ctx context.Context, log logr.Logger, parent metav1.Object, serviceAccountName string) error {
+	if err := r.createServiceAccount(ctx, log, parent, serviceAccountName); err != nil {
+		return err
+	}
+
+	cr := discovery.GaudiLinkDiscoveryClusterRole()
+	cr.Name = parent.GetName() + "-linkdiscovery"
+
+	if err := r.applyClusterRole(ctx, log, parent, cr); err != nil {
+		return err
+	}
+
+	if err := r.applyClusterRoleBinding(ctx, log, parent, r.linkDiscoveryBinding(cr.Name, serviceAccountName)); err != nil {
+		return err
+	}
+
+	if r.isOpenShift {
+		return r.createOpenShiftCollateral(ctx, log, parent, serviceAccountName)
+	}
+
+	return nil
+}
+
+// nodeReadinessCollateral returns the cluster role and binding granting the
+// agents access to the Node objects.
+func (r *GaudiNICReconciler) nodeReadinessCollateral(netconf *networkv1alpha1.NetworkClusterPolicy) (*rbac.ClusterRole, *rbac.ClusterRoleBinding) {
+	cr := discovery.GaudiLinkDiscoveryNodeClusterRole()
+	cr.Name = netconf.Name + "-linkdiscovery-node"
+
+	return cr, r.linkDiscoveryBinding(cr.Name, netconf.Name+"-sa")
+}
+
+// grantNodeReadiness grants the agents access to the Node objects while the
+// policy publishes readiness on the Node. The policy is finalized first so
+// that the readiness is removed from the Nodes also when it is deleted.
+func (r *GaudiNICReconciler) grantNodeReadiness(ctx context.Context, log logr.Logger, netconf *networkv1alpha1.NetworkClusterPolicy) error {
+	if controllerutil.AddFinalizer(netconf, nodeReadinessFinalizer) {
+		if err := r.Update(ctx, netconf); err != nil {
+			log.Error(err, "unable to add node readiness finalizer")
+
+			return err
+		}
+	}
+
+	cr, crb := r.nodeReadinessCollateral(netconf)
+
+	if err := r.applyClusterRole(ctx, log, netconf, cr); err != nil {
+		return err
+	}
+
+	return r.applyClusterRoleBinding(ctx, log, netconf, crb)
+}
+
+// revokeNodeReadiness removes the readiness the agents published on the
+// selected Nodes and revokes their access to the Node objects. It must only
+// be called once no agent publishing readiness on the Node is running.
+func (r *GaudiNICReconciler) revokeNodeReadiness(ctx context.Context, log logr.Logger, netconf *networkv1alpha1.NetworkClusterPolicy) error {
+	if err := r.clearNodeReadiness(ctx, log, netconf); err != nil {
+		return err
+	}
+
+	cr, crb := r.nodeReadinessCollateral(netconf)
+
+	for _, obj := range []client.Object{crb, cr} {
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "unable to delete node readiness collateral", "name", obj.GetName())
+
+			return err
+		}
+	}
+
+	if controllerutil.RemoveFinalizer(netconf, nodeReadinessFinalizer) {
+		if err := r.Update(ctx, netconf); err != nil {
+			log.Error(err, "unable to remove node readiness finalizer")
+
+			return err
+		}
+
+		log.Info("Node readiness removed")
+	}
+
+	return nil
+}
+
+// finalizeNodeReadiness stops the agents of a policy which no longer
+// configures the Gaudi scale-out or is being deleted, and then removes the
+// readiness they published on the Nodes.
+func (r *GaudiNICReconciler) finalizeNodeReadiness(ctx context.Context, log logr.Logger, netconf *networkv1alpha1.NetworkClusterPolicy) error {
+	if !controllerutil.ContainsFinalizer(netconf, nodeReadinessFinalizer) {
+		return nil
+	}
+
+	ds := &apps.DaemonSet{}
+	if err := r.Get(ctx, client.ObjectKey{Name: netconf.Name, Namespace: r.Namespace}, ds); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			log.Error(err, "unable to fetch DaemonSet")
+
+			return err
+		}
+
+		return r.revokeNodeReadiness(ctx, log, netconf)
+	}
+
+	// the policy is reconciled again once the DaemonSet and its Pods are gone
+	if ds.DeletionTimestamp.IsZero() {
+		log.Info("Stopping the agents before removing node readiness")
+
+		if err := r.Delete(ctx, ds, client.PropagationPolicy(metav1.DeletePropagationForeground)); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "unable to delete daemonset", "DaemonSet", ds.Name)
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// daemonSetRolledOut returns true if all Pods of the DaemonSet run its
+// current template. With no surge, the old Pods are then gone.
+func daemonSetRolledOut(ds *apps.DaemonSet) bool {
+	return ds.Status.ObservedGeneration >= ds.Generation &&
+		ds.Status.UpdatedNumberScheduled == ds.Status.DesiredNumberScheduled
+}
+
+// nodeReadinessPublished returns true if another policy publishing readiness
+// on the Node selects the node.
+func nodeReadinessPublished(policies []networkv1alpha1.NetworkClusterPolicy, name string, node *v1.Node) bool {
+	for _, policy := range policies {
+		if policy.Name == name || !policy.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if policy.Spec.ConfigurationType != gaudiScaleOutSelection || policy.Spec.GaudiScaleOut.Readiness != readinessNode {
+			continue
+		}
+
+		if labels.SelectorFromSet(policy.Spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clearNodeReadiness removes the readiness labels and condition from the
+// Nodes no other policy publishes readiness on.
+func (r *GaudiNICReconciler) clearNodeReadiness(ctx context.Context, log logr.Logger, netconf *networkv1alpha1.NetworkClusterPolicy) error {
+	policies := &networkv1alpha1.NetworkClusterPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		log.Error(err, "unable to list policies")
+
+		return err
+	}
+
+	nodes := &v1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		log.Error(err, "unable to list nodes")
+
+		return err
+	}
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+
+		conditions := []v1.NodeCondition{}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type != networkv1alpha1.ScaleOutReadyCondition {
+				conditions = append(conditions, condition)
+			}
+		}
+
+		if len(conditions) == len(node.Status.Conditions) || nodeReadinessPublished(policies.Items, netconf.Name, node) {
+			continue
+		}
+
+		original := node.DeepCopy()
+		for key := range node.Labels {
+			if strings.HasPrefix(key, networkv1alpha1.ScaleOutLabel) {
+				delete(node.Labels, key)
+			}
+		}
+
+		if err := r.Patch(ctx, node, client.MergeFrom(original)); err != nil {
+			log.Error(err, "unable to remove node readiness labels", "node", node.Name)
+
+			return err
+		}
+
+		// conditions are merged by type to not overwrite the ones of kubelet
+		original = node.DeepCopy()
+		node.Status.Conditions = conditions
+
+		if err := r.Status().Patch(ctx, node, client.StrategicMergeFrom(original)); err != nil {
+			log.Error(err, "unable to remove node readiness condition", "node", node.Name)
+
+			return err
+		}
+
+		log.Info("Node readiness cleared", "node", node.Name)
+	}
+
+	return nil
+}
+
+func (r *GaudiNICReconciler) createOpenShiftCollateral(ctx context.Context, log logr.Logger, parent metav1.Object, serviceAccountName string) error {
 	rb := discovery.OpenShiftRoleBinding()
 	rb.Name = serviceAccountName + "-rb"
 	rb.ObjectMeta.Namespace = r.Namespace
@@ -174,18 +542,22 @@ func (r *GaudiNICReconciler) createOpenShiftCollateral(ctx context.Context, log
 	if err := ctrl.SetControllerReference(parent, rb, r.Scheme); err != nil {
 		log.Error(err, "unable to set controller reference (rolebinding)")
 
-		return
+		return err
 	}
 
 	if err := r.Create(ctx, rb); err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			log.Error(err, "unable to create role binding")
-
-			return
+		if apierrors.IsAlreadyExists(err) {
+			return nil
 		}
+
+		log.Error(err, "unable to create role binding")
+
+		return err
 	}
 
 	log.Info("Role binding created", "name", rb.Name)
+
+	return nil
 }
 
 func addLLDPADContainer(ds *apps.DaemonSet, netconf *networkv1alpha1.NetworkClusterPolicy) {
@@ -300,11 +672,23 @@ func updateGaudiScaleOutDaemonSet(ds *apps.DaemonSet, netconf *networkv1alpha1.N
 		ds.Spec.Template.Spec.Containers[0].Image = netconf.Spec.GaudiScaleOut.Image
 	}
 
+	// the agent configures the nodes tainted until they are ready
+	ds.Spec.Template.Spec.Tolerations = nil
+	if netconf.Spec.TaintNotReadyNodes {
+		ds.Spec.Template.Spec.Tolerations = []v1.Toleration{{
+			Key:      networkv1alpha1.ScaleOutNotReadyTaint,
+			Operator: v1.TolerationOpExists,
+			Effect:   v1.TaintEffectNoSchedule,
+		}}
+	}
+
 	// TODO: add possibility to set cpu&memory requests/limits
 
 	args := []string{
 		"--configure=true", "--keep-running",
 		fmt.Sprintf("--mode=%s", netconf.Spec.GaudiScaleOut.Layer),
+		fmt.Sprintf("--policy=%s", netconf.Name),
+		fmt.Sprintf("--state-file=%s", filepath.Join(statePathHost, netconf.Name+".json")),
 	}
 
 	// Add log level to the args
@@ -326,20 +710,84 @@ func updateGaudiScaleOutDaemonSet(ds *apps.DaemonSet, netconf *networkv1alpha1.N
 	case layerSelectionL3:
 		args = append(args, "--wait=90s", fmt.Sprintf("--gaudinet=%s", gaudinetPathContainer))
 
+		if netconf.Spec.GaudiScaleOut.RoutedPrefixLength > 0 {
+			args = append(args, fmt.Sprintf("--routed-prefix=%d", netconf.Spec.GaudiScaleOut.RoutedPrefixLength))
+		}
+		if len(netconf.Spec.GaudiScaleOut.RouteDestinations) > 0 {
+			args = append(args, fmt.Sprintf("--route-destinations=%s", strings.Join(netconf.Spec.GaudiScaleOut.RouteDestinations, ",")))
+		}
+		if lldpAddress := netconf.Spec.GaudiScaleOut.LLDPAddress; lldpAddress != nil && lldpAddress.Format != "" {
+			args = append(args, fmt.Sprintf("--lldp-address-format=%s", lldpAddress.Format))
+
+			if lldpAddress.Regex != "" {
+				args = append(args, fmt.Sprintf("--lldp-address-regex=%s", lldpAddress.Regex))
+			}
+			if lldpAddress.OrgTLV != "" {
+				args = append(args, fmt.Sprintf("--lldp-org-tlv=%s", lldpAddress.OrgTLV))
+			}
+		}
+
+		args = addressPlanArgs(ds, netconf, args)
+
+		if netconf.Spec.GaudiScaleOut.AddressPool != "" {
+			args = append(args, fmt.Sprintf("--address-pool=%s", netconf.Spec.GaudiScaleOut.AddressPool))
+		}
+		if netconf.Spec.GaudiScaleOut.VLAN > 0 {
+			args = append(args, fmt.Sprintf("--vlan=%d", netconf.Spec.GaudiScaleOut.VLAN))
+		}
+
 		addHostVolume(ds, v1.HostPathDirectoryOrCreate, "gaudinetpath", filepath.Dir(gaudinetPathHost), filepath.Dir(gaudinetPathContainer))
 	case layerSelectionL2:
-		delHostVolumeIfExists(ds, "gaudinetpath")
+		if source := netconf.Spec.GaudiScaleOut.L2AddressSource; source != "" {
+			args = append(args, fmt.Sprintf("--l2-address-source=%s", source), fmt.Sprintf("--gaudinet=%s", gaudinetPathContainer))
+			addHostVolume(ds, v1.HostPathDirectoryOrCreate, "gaudinetpath", filepath.Dir(gaudinetPathHost), filepath.Dir(gaudinetPathContainer))
+		} else {
+			delHostVolumeIfExists(ds, "gaudinetpath")
+		}
+
+		args = addressPlanArgs(ds, netconf, args)
+	}
+
+	addHostVolume(ds, v1.HostPathDirectoryOrCreate, stateVolumeName, statePathHost, statePathHost)
+
+	if pfc := pfcArgument(netconf.Spec.GaudiScaleOut.PFCPriorities); pfc != "" {
+		args = append(args, fmt.Sprintf("--pfc=%s", pfc))
+	}
+
+	if ets := netconf.Spec.GaudiScaleOut.ETS; ets != nil {
+		if len(ets.PriorityTrafficClasses) > 0 {
+			args = append(args, fmt.Sprintf("--ets-prio-tc=%s", joinInts(ets.PriorityTrafficClasses)))
+		}
+		args = append(args, fmt.Sprintf("--ets-tc-bw=%s", joinInts(ets.Bandwidths)))
 	}
 
-	if netconf.Spec.GaudiScaleOut.PFCPriorities != "" {
-		pfcEnabled := ""
-		switch netconf.Spec.GaudiScaleOut.PFCPriorities {
-		case "00000000":
-			pfcEnabled = "none"
-		case "11110000":
-			pfcEnabled = "0,1,2,3"
+	if len(netconf.Spec.GaudiScaleOut.DSCPPriorities) > 0 {
+		mappings := []string{}
+		for _, p := range netconf.Spec.GaudiScaleOut.DSCPPriorities {
+			mappings = append(mappings, fmt.Sprintf("%d:%d", p.DSCP, p.Priority))
 		}
-		args = append(args, fmt.Sprintf("--pfc=%s", pfcEnabled))
+		args = append(args, fmt.Sprintf("--dscp-priorities=%s", strings.Join(mappings, ",")))
+	}
+
+	if netconf.Spec.GaudiScaleOut.PFCBackend != "" && (netconf.Spec.GaudiScaleOut.PFCPriorities != "" ||
+		netconf.Spec.GaudiScaleOut.ETS != nil || len(netconf.Spec.GaudiScaleOut.DSCPPriorities) > 0) {
+		args = append(args, fmt.Sprintf("--pfc-backend=%s", netconf.Spec.GaudiScaleOut.PFCBackend))
+	}
+
+	if netconf.Spec.GaudiScaleOut.DryRun {
+		args = append(args, "--dry-run")
+	}
+
+	if netconf.Spec.GaudiScaleOut.LLDPTransmit {
+		args = append(args, "--lldp-transmit")
+	}
+
+	if netconf.Spec.GaudiScaleOut.MinReadyPorts > 0 {
+		args = append(args, fmt.Sprintf("--min-ready-ports=%d", netconf.Spec.GaudiScaleOut.MinReadyPorts))
+	}
+
+	if netconf.Spec.GaudiScaleOut.Readiness != "" {
+		args = append(args, fmt.Sprintf("--readiness=%s", netconf.Spec.GaudiScaleOut.Readiness))
 	}
 
 	if netconf.Spec.GaudiScaleOut.NetworkMetrics {
@@ -358,21 +806,222 @@ func updateGaudiScaleOutDaemonSet(ds *apps.DaemonSet, netconf *networkv1alpha1.N
 	}
 }
 
-func (r *GaudiNICReconciler) createGaudiScaleOutDaemonset(netconf client.Object, ctx context.Context, log logr.Logger) (ctrl.Result, error) {
+// pfcArgument converts the PFC priorities of the policy to the priority
+// list of the discover agent, or 'none' to disable PFC. Returns an empty
+// string if PFC is not configured. Invalid priorities, only possible when
+// the webhook is bypassed, are passed as is for the agent to reject them
+// instead of silently leaving PFC unconfigured.
+func pfcArgument(priorities string) string {
+	if priorities == "" {
+		return ""
+	}
+
+	enabled, err := networkv1alpha1.ParsePFCPriorities(priorities)
+	if err != nil {
+		return priorities
+	}
+	if len(enabled) == 0 {
+		return "none"
+	}
+
+	return joinInts(enabled)
+}
+
+// joinInts returns the values as a comma separated list.
+func joinInts(values []int) string {
+	strs := []string{}
+	for _, v := range values {
+		strs = append(strs, strconv.Itoa(v))
+	}
+
+	return strings.Join(strs, ",")
+}
+
+// addressPlanConfigMapName returns the name of the ConfigMap holding the
+// address plan, either the one referenced in the policy or the one
+// created by the operator from the node address plans in the policy.
+func addressPlanConfigMapName(netconf *networkv1alpha1.NetworkClusterPolicy) string {
+	if addressPlan := netconf.Spec.GaudiScaleOut.AddressPlan; addressPlan != nil && addressPlan.ConfigMapName != "" {
+		return addressPlan.ConfigMapName
+	}
+
+	return netconf.Name + "-address-plan"
+}
+
+// addressPlanData renders the node address plans of the policy as ConfigMap
+// data with the interface addresses of each node under the node name.
+func addressPlanData(netconf *networkv1alpha1.NetworkClusterPolicy) (map[string]string, error) {
+	data := map[string]string{}
+
+	for _, node := range netconf.Spec.GaudiScaleOut.AddressPlan.Nodes {
+		interfaces, err := yaml.Marshal(node.Interfaces)
+		if err != nil {
+			return nil, err
+		}
+		data[node.NodeName] = string(interfaces)
+	}
+
+	return data, nil
+}
+
+// usesAddressPlan returns true if the addresses come from the address plan,
+// in L3 mode or as the static L2 address source.
+func usesAddressPlan(netconf *networkv1alpha1.NetworkClusterPolicy) bool {
+	spec := netconf.Spec.GaudiScaleOut
+	if spec.AddressPlan == nil {
+		return false
+	}
+
+	return spec.Layer == layerSelectionL3 || (spec.Layer == layerSelectionL2 && spec.L2AddressSource == l2AddressSourceStatic)
+}
+
+// addressPlanArgs adds the address plan arguments and volume.
+func addressPlanArgs(ds *apps.DaemonSet, netconf *networkv1alpha1.NetworkClusterPolicy, args []string) []string {
+	if !usesAddressPlan(netconf) {
+		delHostVolumeIfExists(ds, addressPlanVolume)
+		return args
+	}
+
+	args = append(args, fmt.Sprintf("--address-plan=%s", addressPlanPathContainer))
+	if netconf.Spec.GaudiScaleOut.AddressPlan.VerifyLLDP {
+		args = append(args, "--verify-lldp")
+	}
+	addConfigMapVolume(ds, addressPlanVolume, addressPlanConfigMapName(netconf), addressPlanPathContainer)
+
+	return args
+}
+
+// reconcileAddressPlan creates or updates the address plan ConfigMap for
+// the node address plans in the policy and removes it when no longer used.
+// Returns a hash of the address plan used by the discover agents so that
+// the pods are restarted when the plan changes.
+func (r *GaudiNICReconciler) reconcileAddressPlan(ctx context.Context, log logr.Logger, netconf *networkv1alpha1.NetworkClusterPolicy) (string, error) {
+	addressPlan := netconf.Spec.GaudiScaleOut.AddressPlan
+
+	ownedName := netconf.Name + "-address-plan"
+	owned := &v1.ConfigMap{}
+	err := r.Get(ctx, client.ObjectKey{Name: ownedName, Namespace: r.Namespace}, owned)
+	if client.IgnoreNotFound(err) != nil {
+		log.Error(err, "unable to fetch address plan ConfigMap")
+
+		return "", err
+	}
+	exists := err == nil
+
+	if !usesAddressPlan(netconf) || len(addressPlan.Nodes) == 0 {
+		if exists && metav1.IsControlledBy(owned, netconf) {
+			if err := r.Delete(ctx, owned); client.IgnoreNotFound(err) != nil {
+				log.Error(err, "unable to delete address plan ConfigMap")
+
+				return "", err
+			}
+			log.Info("Address plan ConfigMap deleted", "name", ownedName)
+		}
+
+		if !usesAddressPlan(netconf) {
+			return "", nil
+		}
+
+		referenced := &v1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Name: addressPlan.ConfigMapName, Namespace: r.Namespace}, referenced); err != nil {
+			log.Error(err, "unable to fetch referenced address plan ConfigMap", "name", addressPlan.ConfigMapName)
+
+			return "", client.IgnoreNotFound(err)
+		}
+
+		return hashAddressPlan(referenced.Data), nil
+	}
+
+	data, err := addressPlanData(netconf)
+	if err != nil {
+		log.Error(err, "unable to render address plan")
+
+		return "", err
+	}
+
+	if !exists {
+		owned = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ownedName,
+				Namespace: r.Namespace,
+			},
+			Data: data,
+		}
+
+		if err := ctrl.SetControllerReference(netconf, owned, r.Scheme); err != nil {
+			log.Error(err, "unable to set controller reference (address plan)")
+
+			return "", err
+		}
+
+		if err := r.Create(ctx, owned); err != nil {
+			log.Error(err, "unable to create address plan ConfigMap")
+
+			return "", err
+		}
+
+		log.Info("Address plan ConfigMap created", "name", ownedName)
+	} else if !cmp.Equal(owned.Data, data, cmpopts.EquateEmpty()) {
+		owned.Data = data
+
+		if err := r.Update(ctx, owned); err != nil {
+			log.Error(err, "unable to update address plan ConfigMap")
+
+			return "", err
+		}
+
+		log.Info("Address plan ConfigMap updated", "name", ownedName)
+	}
+
+	return hashAddressPlan(data), nil
+}
+
+func hashAddressPlan(data map[string]string) string {
+	nodes := make([]string, 0, len(data))
+	for node := range data {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	h := sha256.New()
+	for _, node := range nodes {
+		h.Write([]byte(node))
+		h.Write([]byte{0})
+		h.Write([]byte(data[node]))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func setAddressPlanHash(ds *apps.DaemonSet, hash string) {
+	annotations := ds.Spec.Template.Annotations
+
+	if hash == "" {
+		delete(annotations, addressPlanHashKey)
+		return
+	}
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[addressPlanHashKey] = hash
+	ds.Spec.Template.Annotations = annotations
+}
+
+func (r *GaudiNICReconciler) createGaudiScaleOutDaemonset(netconf client.Object, ctx context.Context, log logr.Logger, addressPlanHash string) (ctrl.Result, error) {
 	ds := discovery.GaudiDiscoveryDaemonSet()
 
 	cr := netconf.(*networkv1alpha1.NetworkClusterPolicy)
 
 	log.Info("Creating Gaudi Scale-Out DaemonSet", "name", cr.Name)
 
-	saName := ""
-	if r.isOpenShift {
-		saName = cr.Name + "-sa"
-	}
+	saName := cr.Name + "-sa"
 
 	ds.Spec.Template.Spec.ServiceAccountName = saName
 
 	updateGaudiScaleOutDaemonSet(ds, cr, r.Namespace)
+	setAddressPlanHash(ds, addressPlanHash)
 
 	if err := ctrl.SetControllerReference(netconf.(metav1.Object), ds, r.Scheme); err != nil {
 		log.Error(err, "unable to set controller reference")
@@ -388,11 +1037,113 @@ func (r *GaudiNICReconciler) createGaudiScaleOutDaemonset(netconf client.Object,
 
 	log.Info("Gaudi scale-out daemonset created")
 
-	if saName != "" {
-		r.createOpenShiftCollateral(ctx, log, netconf.(metav1.Object), saName)
+	return ctrl.Result{}, nil
+}
+
+// failedNodes collects the nodes whose discover agent reported a failure
+// in their NetworkNodeState.
+func (r *GaudiNICReconciler) failedNodes(nc *networkv1alpha1.NetworkClusterPolicy, ctx context.Context) ([]networkv1alpha1.FailedNode, error) {
+	nodeStates := &networkv1alpha1.NetworkNodeStateList{}
+	if err := r.List(ctx, nodeStates, client.MatchingLabels{networkv1alpha1.NodeStatePolicyLabel: nc.Name}); err != nil {
+		return nil, err
 	}
 
-	return ctrl.Result{}, nil
+	failed := []networkv1alpha1.FailedNode{}
+	for _, nodeState := range nodeStates.Items {
+		if nodeState.Status.State != networkv1alpha1.NodeStateFailed {
+			continue
+		}
+
+		failed = append(failed, networkv1alpha1.FailedNode{
+			Name:    nodeState.Name,
+			Message: nodeState.Status.Message,
+		})
+	}
+
+	sort.Slice(failed, func(i, j int) bool {
+		return failed[i].Name < failed[j].Name
+	})
+
+	return failed, nil
+}
+
+// rolloutState returns the free-text State matching the policy's targets
+// and ready nodes.
+func rolloutState(nc *networkv1alpha1.NetworkClusterPolicy) string {
+	if nc.Status.Targets == 0 {
+		return "No targets"
+	} else if nc.Status.ReadyNodes < nc.Status.Targets {
+		return "Working on it.."
+	}
+
+	return "All good"
+}
+
+// policyConditions returns the Available, Progressing and Degraded
+// conditions matching the rollout state of the policy. The policy is
+// degraded when degradedReason is set.
+func policyConditions(nc *networkv1alpha1.NetworkClusterPolicy, degradedReason, degradedMessage string) []metav1.Condition {
+	available := metav1.Condition{
+		Type:               networkv1alpha1.ConditionAvailable,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: nc.Generation,
+	}
+	progressing := metav1.Condition{
+		Type:               networkv1alpha1.ConditionProgressing,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: nc.Generation,
+	}
+	degraded := metav1.Condition{
+		Type:               networkv1alpha1.ConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             "NoFailures",
+		Message:            "No node reported a failure",
+		ObservedGeneration: nc.Generation,
+	}
+
+	readyMessage := fmt.Sprintf("%d of %d nodes ready", nc.Status.ReadyNodes, nc.Status.Targets)
+
+	switch {
+	case nc.Status.Targets == 0:
+		available.Reason = "NoTargets"
+		available.Message = "No nodes match the node selector"
+		progressing.Reason = "NoTargets"
+		progressing.Message = available.Message
+	case nc.Status.ReadyNodes < nc.Status.Targets:
+		available.Reason = "RolloutInProgress"
+		available.Message = readyMessage
+		progressing.Status = metav1.ConditionTrue
+		progressing.Reason = "RolloutInProgress"
+		progressing.Message = readyMessage
+	default:
+		available.Status = metav1.ConditionTrue
+		available.Reason = "AllNodesReady"
+		available.Message = readyMessage
+		progressing.Reason = "RolloutComplete"
+		progressing.Message = readyMessage
+	}
+
+	if degradedReason != "" {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = degradedReason
+		degraded.Message = degradedMessage
+	}
+
+	return []metav1.Condition{available, progressing, degraded}
+}
+
+// setPolicyConditions sets the conditions to the policy status and returns
+// whether any of them changed.
+func setPolicyConditions(nc *networkv1alpha1.NetworkClusterPolicy, conditions []metav1.Condition) bool {
+	changed := false
+
+	for _, condition := range conditions {
+		if meta.SetStatusCondition(&nc.Status.Conditions, condition) {
+			changed = true
+		}
+	}
+
+	return changed
 }
 
 func (r *GaudiNICReconciler) updateStatus(nc *networkv1alpha1.NetworkClusterPolicy, ds *apps.DaemonSet, ctx context.Context, log logr.Logger) (ctrl.Result, error) {
@@ -409,19 +1160,43 @@ func (r *GaudiNICReconciler) updateStatus(nc *networkv1alpha1.NetworkClusterPoli
 		updated = true
 	}
 
-	nc.Status.Errors = []string{}
+	failedNodes, err := r.failedNodes(nc, ctx)
+	if err != nil {
+		log.Error(err, "unable to list network node states")
+		return ctrl.Result{}, err
+	}
+
+	nodeErrors := []string{}
+	for _, node := range failedNodes {
+		nodeErrors = append(nodeErrors, fmt.Sprintf("%s: %s", node.Name, node.Message))
+	}
+
+	if !cmp.Equal(nc.Status.FailedNodes, failedNodes, cmpopts.EquateEmpty()) {
+		nc.Status.FailedNodes = failedNodes
+		updated = true
+	}
+
+	if !cmp.Equal(nc.Status.Errors, nodeErrors, cmpopts.EquateEmpty()) {
+		updated = true
+	}
+
+	nc.Status.Errors = nodeErrors
 
 	// Update status if there's no State yet.
 	if len(nc.Status.State) == 0 {
 		updated = true
 	}
 
-	if nc.Status.Targets == 0 {
-		nc.Status.State = "No targets"
-	} else if nc.Status.ReadyNodes < nc.Status.Targets {
-		nc.Status.State = "Working on it.."
-	} else {
-		nc.Status.State = "All good"
+	nc.Status.State = rolloutState(nc)
+
+	degradedReason, degradedMessage := "", ""
+	if len(failedNodes) > 0 {
+		degradedReason = "NodesFailed"
+		degradedMessage = fmt.Sprintf("%d of %d nodes failed, see failedNodes", len(failedNodes), nc.Status.Targets)
+	}
+
+	if setPolicyConditions(nc, policyConditions(nc, degradedReason, degradedMessage)) {
+		updated = true
 	}
 
 	if updated {
@@ -438,12 +1213,31 @@ func (r *GaudiNICReconciler) updateStatus(nc *networkv1alpha1.NetworkClusterPoli
 
 func (r *GaudiNICReconciler) Reconcile(ctx context.Context, clusterPolicy *networkv1alpha1.NetworkClusterPolicy) (ctrl.Result, error) {
 
-	if clusterPolicy == nil || clusterPolicy.Spec.ConfigurationType != gaudiScaleOutSelection {
+	if clusterPolicy == nil {
 		return ctrl.Result{}, nil
 	}
 
 	log := log.FromContext(ctx)
 
+	if !clusterPolicy.DeletionTimestamp.IsZero() || clusterPolicy.Spec.ConfigurationType != gaudiScaleOutSelection {
+		return ctrl.Result{}, r.finalizeNodeReadiness(ctx, log, clusterPolicy)
+	}
+
+	if err := r.reconcileNodeStateCollateral(ctx, log, clusterPolicy, clusterPolicy.Name+"-sa"); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	addressPlanHash, err := r.reconcileAddressPlan(ctx, log, clusterPolicy)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if clusterPolicy.Spec.GaudiScaleOut.Readiness == readinessNode {
+		if err := r.grantNodeReadiness(ctx, log, clusterPolicy); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// fetch possible existing daemonset
 
 	ds := &apps.DaemonSet{}
@@ -453,14 +1247,15 @@ func (r *GaudiNICReconciler) Reconcile(ctx context.Context, clusterPolicy *netwo
 
 			return ctrl.Result{}, err
 		}
-		return r.createGaudiScaleOutDaemonset(clusterPolicy, ctx, log)
+		return r.createGaudiScaleOutDaemonset(clusterPolicy, ctx, log, addressPlanHash)
 	}
 
 	originalDs := ds.DeepCopy()
 
 	updateGaudiScaleOutDaemonSet(ds, clusterPolicy, r.Namespace)
+	setAddressPlanHash(ds, addressPlanHash)
 
-	dsDiff := cmp.Diff(originalDs.Spec.Template.Spec, ds.Spec.Template.Spec, cmpopts.EquateEmpty())
+	dsDiff := cmp.Diff(originalDs.Spec.Template, ds.Spec.Template, cmpopts.EquateEmpty())
 	if len(dsDiff) > 0 {
 		log.Info("DS difference", "diff", dsDiff)
 
@@ -471,6 +1266,14 @@ func (r *GaudiNICReconciler) Reconcile(ctx context.Context, clusterPolicy *netwo
 		}
 	}
 
+	// the agents may publish readiness on the Node until they are updated
+	if clusterPolicy.Spec.GaudiScaleOut.Readiness != readinessNode &&
+		controllerutil.ContainsFinalizer(clusterPolicy, nodeReadinessFinalizer) && daemonSetRolledOut(ds) {
+		if err := r.revokeNodeReadiness(ctx, log, clusterPolicy); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Update Pods Statuses
 
 	return r.updateStatus(clusterPolicy, ds, ctx, log)
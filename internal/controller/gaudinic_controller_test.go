@@ -0,0 +1,527 @@
+// Copyright 2026 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	rbac "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	discovery "github.com/intel/network-operator/config/discovery"
+)
+
+func testNodeState(name, policy, state, message string) *networkv1alpha1.NetworkNodeState {
+	return &networkv1alpha1.NetworkNodeState{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{networkv1alpha1.NodeStatePolicyLabel: policy},
+		},
+		Spec: networkv1alpha1.NetworkNodeStateSpec{
+			PolicyName: policy,
+		},
+		Status: networkv1alpha1.NetworkNodeStateStatus{
+			State:   state,
+			Message: message,
+		},
+	}
+}
+
+var _ = Describe("Gaudi NIC Controller", func() {
+
+	Context("Verify status reporting", func() {
+
+		It("Aggregates node failures into conditions", func() {
+			cp := &networkv1alpha1.NetworkClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "gaudi-policy",
+				},
+				Spec: networkv1alpha1.NetworkClusterPolicySpec{
+					ConfigurationType: "gaudi-so",
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(networkv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			r := GaudiNICReconciler{Scheme: scheme, Namespace: testNamespace}
+			r.Client = fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(cp,
+					testNodeState("node-a", cp.Name, networkv1alpha1.NodeStateConfigured, ""),
+					testNodeState("node-c", cp.Name, networkv1alpha1.NodeStateFailed, "Not all interfaces were configured (6/8)."),
+					testNodeState("node-b", cp.Name, networkv1alpha1.NodeStateFailed, "no peers found"),
+					testNodeState("node-d", "other-policy", networkv1alpha1.NodeStateFailed, "unrelated")).
+				WithStatusSubresource(cp).
+				Build()
+
+			ds := &apps.DaemonSet{}
+			ds.Status.DesiredNumberScheduled = 4
+			ds.Status.NumberReady = 2
+
+			_, err := r.updateStatus(cp, ds, ctx, log.FromContext(ctx))
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &networkv1alpha1.NetworkClusterPolicy{}
+			Expect(r.Get(ctx, client.ObjectKey{Name: cp.Name}, updated)).To(Succeed())
+
+			Expect(updated.Status.FailedNodes).To(Equal([]networkv1alpha1.FailedNode{
+				{Name: "node-b", Message: "no peers found"},
+				{Name: "node-c", Message: "Not all interfaces were configured (6/8)."},
+			}))
+			Expect(updated.Status.Errors).To(HaveLen(2))
+
+			Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, networkv1alpha1.ConditionAvailable)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, networkv1alpha1.ConditionProgressing)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, networkv1alpha1.ConditionDegraded)).To(BeTrue())
+
+			// All nodes recover
+			for _, name := range []string{"node-b", "node-c"} {
+				nodeState := &networkv1alpha1.NetworkNodeState{}
+				Expect(r.Get(ctx, client.ObjectKey{Name: name}, nodeState)).To(Succeed())
+				nodeState.Status.State = networkv1alpha1.NodeStateConfigured
+				nodeState.Status.Message = ""
+				Expect(r.Update(ctx, nodeState)).To(Succeed())
+			}
+
+			ds.Status.NumberReady = 4
+
+			_, err = r.updateStatus(updated, ds, ctx, log.FromContext(ctx))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, client.ObjectKey{Name: cp.Name}, updated)).To(Succeed())
+
+			Expect(updated.Status.FailedNodes).To(BeEmpty())
+			Expect(updated.Status.Errors).To(BeEmpty())
+			Expect(updated.Status.State).To(Equal("All good"))
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, networkv1alpha1.ConditionAvailable)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, networkv1alpha1.ConditionProgressing)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, networkv1alpha1.ConditionDegraded)).To(BeTrue())
+		})
+
+		It("Renders the address plan into a ConfigMap", func() {
+			cp := &networkv1alpha1.NetworkClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "gaudi-plan",
+					UID:  "1234",
+				},
+				Spec: networkv1alpha1.NetworkClusterPolicySpec{
+					ConfigurationType: "gaudi-so",
+					GaudiScaleOut: networkv1alpha1.GaudiScaleOutSpec{
+						Layer: "L3",
+						AddressPlan: &networkv1alpha1.AddressPlanSpec{
+							Nodes: []networkv1alpha1.NodeAddressPlan{
+								{
+									NodeName: "node-a",
+									Interfaces: []networkv1alpha1.InterfaceAddress{
+										{Interface: "eth0", Address: "10.210.8.121/30"},
+									},
+								},
+							},
+							VerifyLLDP: true,
+						},
+					},
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(networkv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			r := GaudiNICReconciler{Scheme: scheme, Namespace: testNamespace}
+			r.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cp).Build()
+
+			hash, err := r.reconcileAddressPlan(ctx, log.FromContext(ctx), cp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hash).NotTo(BeEmpty())
+
+			cm := &v1.ConfigMap{}
+			Expect(r.Get(ctx, client.ObjectKey{Name: "gaudi-plan-address-plan", Namespace: testNamespace}, cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKeyWithValue("node-a", "- address: 10.210.8.121/30\n  interface: eth0\n"))
+			Expect(metav1.IsControlledBy(cm, cp)).To(BeTrue())
+
+			ds := discovery.GaudiDiscoveryDaemonSet()
+			updateGaudiScaleOutDaemonSet(ds, cp, testNamespace)
+			setAddressPlanHash(ds, hash)
+
+			Expect(ds.Spec.Template.Spec.Containers[0].Args).To(ContainElements("--address-plan=/address-plan", "--verify-lldp"))
+			Expect(ds.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Name", addressPlanVolume)))
+			Expect(ds.Spec.Template.Annotations).To(HaveKeyWithValue(addressPlanHashKey, hash))
+
+			// A changed plan changes the hash
+			cp.Spec.GaudiScaleOut.AddressPlan.Nodes[0].Interfaces[0].Address = "10.210.8.125/30"
+			newHash, err := r.reconcileAddressPlan(ctx, log.FromContext(ctx), cp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(newHash).NotTo(Equal(hash))
+
+			// Switching to a referenced ConfigMap removes the rendered one
+			Expect(r.Create(ctx, &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "plan", Namespace: testNamespace},
+				Data:       map[string]string{"node-a": "- interface: eth0\n  address: 10.210.8.121/30\n"},
+			})).To(Succeed())
+			cp.Spec.GaudiScaleOut.AddressPlan = &networkv1alpha1.AddressPlanSpec{ConfigMapName: "plan"}
+
+			hash, err = r.reconcileAddressPlan(ctx, log.FromContext(ctx), cp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hash).NotTo(BeEmpty())
+			Expect(r.Get(ctx, client.ObjectKey{Name: "gaudi-plan-address-plan", Namespace: testNamespace}, cm)).NotTo(Succeed())
+
+			updateGaudiScaleOutDaemonSet(ds, cp, testNamespace)
+			Expect(ds.Spec.Template.Spec.Containers[0].Args).NotTo(ContainElement("--verify-lldp"))
+			Expect(ds.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("VolumeSource.ConfigMap.Name", "plan")))
+
+			// No address plan
+			cp.Spec.GaudiScaleOut.AddressPlan = nil
+			hash, err = r.reconcileAddressPlan(ctx, log.FromContext(ctx), cp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hash).To(BeEmpty())
+
+			updateGaudiScaleOutDaemonSet(ds, cp, testNamespace)
+			setAddressPlanHash(ds, hash)
+			Expect(ds.Spec.Template.Spec.Volumes).NotTo(ContainElement(HaveField("Name", addressPlanVolume)))
+			Expect(ds.Spec.Template.Annotations).NotTo(HaveKey(addressPlanHashKey))
+		})
+
+		It("Converts PFC priorities to the agent argument", func() {
+			Expect(pfcArgument("")).To(BeEmpty())
+			Expect(pfcArgument("00000000")).To(Equal("none"))
+			Expect(pfcArgument("11110000")).To(Equal("0,1,2,3"))
+			Expect(pfcArgument("00010000")).To(Equal("3"))
+			Expect(pfcArgument("3")).To(Equal("3"))
+			Expect(pfcArgument("6,2")).To(Equal("2,6"))
+			Expect(pfcArgument("9")).To(Equal("9"))
+			Expect(pfcArgument("1,1")).To(Equal("1,1"))
+		})
+
+		It("Passes the ETS and DSCP configuration to the agent", func() {
+			cp := &networkv1alpha1.NetworkClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "gaudi-ets"},
+				Spec: networkv1alpha1.NetworkClusterPolicySpec{
+					ConfigurationType: "gaudi-so",
+					GaudiScaleOut: networkv1alpha1.GaudiScaleOutSpec{
+						Layer:      "L3",
+						PFCBackend: "netlink",
+						ETS: &networkv1alpha1.ETSSpec{
+							PriorityTrafficClasses: []int{0, 0, 0, 1},
+							Bandwidths:             []int{50, 50},
+						},
+					},
+				},
+			}
+
+			ds := discovery.GaudiDiscoveryDaemonSet()
+			updateGaudiScaleOutDaemonSet(ds, cp, testNamespace)
+
+			Expect(ds.Spec.Template.Spec.Containers[0].Args).To(ContainElements(
+				"--ets-prio-tc=0,0,0,1", "--ets-tc-bw=50,50", "--pfc-backend=netlink"))
+
+			cp.Spec.GaudiScaleOut.ETS = nil
+			cp.Spec.GaudiScaleOut.DSCPPriorities = []networkv1alpha1.DSCPPriority{
+				{DSCP: 26, Priority: 3},
+				{DSCP: 48, Priority: 6},
+			}
+			updateGaudiScaleOutDaemonSet(ds, cp, testNamespace)
+
+			Expect(ds.Spec.Template.Spec.Containers[0].Args).To(ContainElements(
+				"--dscp-priorities=26:3,48:6", "--pfc-backend=netlink"))
+			Expect(ds.Spec.Template.Spec.Containers[0].Args).NotTo(ContainElement(HavePrefix("--ets")))
+
+			cp.Spec.GaudiScaleOut.DSCPPriorities = nil
+			updateGaudiScaleOutDaemonSet(ds, cp, testNamespace)
+
+			Expect(ds.Spec.Template.Spec.Containers[0].Args).NotTo(ContainElement(HavePrefix("--dscp")))
+			Expect(ds.Spec.Template.Spec.Containers[0].Args).NotTo(ContainElement(ContainSubstring("--pfc-backend")))
+		})
+
+		It("Passes the L2 address source to the agent", func() {
+			cp := &networkv1alpha1.NetworkClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "gaudi-l2"},
+				Spec: networkv1alpha1.NetworkClusterPolicySpec{
+					ConfigurationType: "gaudi-so",
+					GaudiScaleOut: networkv1alpha1.GaudiScaleOutSpec{
+						Layer: "L2",
+					},
+				},
+			}
+
+			ds := discovery.GaudiDiscoveryDaemonSet()
+			updateGaudiScaleOutDaemonSet(ds, cp, testNamespace)
+
+			Expect(ds.Spec.Template.Spec.Containers[0].Args).NotTo(ContainElement(HavePrefix("--gaudinet")))
+			Expect(ds.Spec.Template.Spec.Volumes).NotTo(ContainElement(HaveField("Name", "gaudinetpath")))
+
+			cp.Spec.GaudiScaleOut.L2AddressSource = "dhcp"
+			updateGaudiScaleOutDaemonSet(ds, cp, testNamespace)
+
+			Expect(ds.Spec.Template.Spec.Containers[0].Args).To(ContainElements(
+				"--l2-address-source=dhcp", "--gaudinet=/host/etc/habanalabs/gaudinet.json"))
+			Expect(ds.Spec.Template.Spec.Containers[0].Args).NotTo(ContainElement(HavePrefix("--address-plan")))
+			Expect(ds.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Name", "gaudinetpath")))
+
+			cp.Spec.GaudiScaleOut.L2AddressSource = "static"
+			cp.Spec.GaudiScaleOut.AddressPlan = &networkv1alpha1.AddressPlanSpec{ConfigMapName: "plan"}
+			updateGaudiScaleOutDaemonSet(ds, cp, testNamespace)
+
+			Expect(ds.Spec.Template.Spec.Containers[0].Args).To(ContainElements(
+				"--l2-address-source=static", "--address-plan=/address-plan"))
+			Expect(ds.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Name", addressPlanVolume)))
+		})
+
+		It("Passes the dry run to the agent", func() {
+			cp := &networkv1alpha1.NetworkClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "gaudi-dry-run"},
+				Spec: networkv1alpha1.NetworkClusterPolicySpec{
+					ConfigurationType: "gaudi-so",
+					GaudiScaleOut: networkv1alpha1.GaudiScaleOutSpec{
+						Layer: "L3",
+					},
+				},
+			}
+
+			ds := discovery.GaudiDiscoveryDaemonSet()
+			updateGaudiScaleOutDaemonSet(ds, cp, testNamespace)
+
+			Expect(ds.Spec.Template.Spec.Containers[0].Args).NotTo(ContainElement("--dry-run"))
+
+			cp.Spec.GaudiScaleOut.DryRun = true
+			updateGaudiScaleOutDaemonSet(ds, cp, testNamespace)
+
+			Expect(ds.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--dry-run"))
+		})
+
+		It("Keeps the agent cluster role up to date", func() {
+			cp := &networkv1alpha1.NetworkClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "gaudi-rbac",
+					UID:  "4321",
+				},
+				Spec: networkv1alpha1.NetworkClusterPolicySpec{
+					ConfigurationType: "gaudi-so",
+				},
+			}
+
+			// cluster role of an earlier operator version without binding
+			stale := discovery.GaudiLinkDiscoveryClusterRole()
+			stale.Name = "gaudi-rbac-linkdiscovery"
+			stale.Rules = stale.Rules[:1]
+
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(networkv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			r := GaudiNICReconciler{Scheme: scheme, Namespace: testNamespace}
+			r.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cp, stale).Build()
+
+			Expect(r.reconcileNodeStateCollateral(ctx, log.FromContext(ctx), cp, "gaudi-rbac-sa")).To(Succeed())
+			Expect(r.reconcileNodeStateCollateral(ctx, log.FromContext(ctx), cp, "gaudi-rbac-sa")).To(Succeed())
+
+			key := client.ObjectKey{Name: stale.Name}
+
+			cr := &rbac.ClusterRole{}
+			Expect(r.Get(ctx, key, cr)).To(Succeed())
+			Expect(cr.Rules).To(Equal(discovery.GaudiLinkDiscoveryClusterRole().Rules))
+
+			crb := &rbac.ClusterRoleBinding{}
+			Expect(r.Get(ctx, key, crb)).To(Succeed())
+			Expect(crb.RoleRef.Name).To(Equal(key.Name))
+			Expect(crb.Subjects).To(ConsistOf(HaveField("Name", "gaudi-rbac-sa")))
+			Expect(metav1.IsControlledBy(crb, cp)).To(BeTrue())
+
+			sa := &v1.ServiceAccount{}
+			Expect(r.Get(ctx, client.ObjectKey{Name: "gaudi-rbac-sa", Namespace: testNamespace}, sa)).To(Succeed())
+		})
+
+		It("Grants Node access only for Node readiness", func() {
+			cp := &networkv1alpha1.NetworkClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "gaudi-readiness",
+					UID:  "5678",
+				},
+				Spec: networkv1alpha1.NetworkClusterPolicySpec{
+					ConfigurationType: "gaudi-so",
+					NodeSelector:      map[string]string{"gaudi": "true"},
+					GaudiScaleOut: networkv1alpha1.GaudiScaleOutSpec{
+						Layer:     "L3",
+						Readiness: "nfd",
+					},
+				},
+			}
+			other := &networkv1alpha1.NetworkClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "gaudi-other",
+				},
+				Spec: networkv1alpha1.NetworkClusterPolicySpec{
+					ConfigurationType: "gaudi-so",
+					NodeSelector:      map[string]string{"other": "true"},
+					GaudiScaleOut: networkv1alpha1.GaudiScaleOutSpec{
+						Layer:     "L3",
+						Readiness: "node",
+					},
+				},
+			}
+
+			readyNode := func(name string, nodeLabels map[string]string) *v1.Node {
+				return &v1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   name,
+						Labels: nodeLabels,
+					},
+					Status: v1.NodeStatus{
+						Conditions: []v1.NodeCondition{
+							{Type: v1.NodeReady, Status: v1.ConditionTrue},
+							{Type: networkv1alpha1.ScaleOutReadyCondition, Status: v1.ConditionTrue},
+						},
+					},
+				}
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(networkv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			r := GaudiNICReconciler{Scheme: scheme, Namespace: testNamespace}
+			r.Client = fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(cp, other,
+					readyNode("node-a", map[string]string{"gaudi": "true", networkv1alpha1.ScaleOutLabel: "true", networkv1alpha1.ScaleOutLabel + "-ports": "3"}),
+					readyNode("node-b", map[string]string{"gaudi": "true", "other": "true", networkv1alpha1.ScaleOutLabel: "true"})).
+				WithStatusSubresource(cp, &apps.DaemonSet{}, &v1.Node{}).Build()
+
+			key := client.ObjectKey{Name: "gaudi-readiness-linkdiscovery-node"}
+
+			_, err := r.Reconcile(ctx, cp)
+			Expect(err).To(Succeed())
+			Expect(apierrors.IsNotFound(r.Get(ctx, key, &rbac.ClusterRole{}))).To(BeTrue())
+			Expect(cp.Finalizers).To(BeEmpty())
+
+			cp.Spec.GaudiScaleOut.Readiness = "node"
+			Expect(r.Update(ctx, cp)).To(Succeed())
+			for range 2 {
+				_, err = r.Reconcile(ctx, cp)
+				Expect(err).To(Succeed())
+			}
+			Expect(cp.Finalizers).To(ConsistOf(nodeReadinessFinalizer))
+
+			cr := &rbac.ClusterRole{}
+			Expect(r.Get(ctx, key, cr)).To(Succeed())
+			Expect(cr.Rules).To(Equal(discovery.GaudiLinkDiscoveryNodeClusterRole().Rules))
+			Expect(metav1.IsControlledBy(cr, cp)).To(BeTrue())
+
+			crb := &rbac.ClusterRoleBinding{}
+			Expect(r.Get(ctx, key, crb)).To(Succeed())
+			Expect(crb.RoleRef.Name).To(Equal(key.Name))
+			Expect(crb.Subjects).To(ConsistOf(HaveField("Name", "gaudi-readiness-sa")))
+
+			// the old agents still publish readiness on the Node
+			ds := &apps.DaemonSet{}
+			Expect(r.Get(ctx, client.ObjectKey{Name: cp.Name, Namespace: testNamespace}, ds)).To(Succeed())
+			ds.Status.DesiredNumberScheduled = 2
+			Expect(r.Status().Update(ctx, ds)).To(Succeed())
+
+			cp.Spec.GaudiScaleOut.Readiness = ""
+			Expect(r.Update(ctx, cp)).To(Succeed())
+			_, err = r.Reconcile(ctx, cp)
+			Expect(err).To(Succeed())
+			Expect(r.Get(ctx, key, &rbac.ClusterRole{})).To(Succeed())
+			Expect(r.Get(ctx, key, &rbac.ClusterRoleBinding{})).To(Succeed())
+			Expect(cp.Finalizers).To(ConsistOf(nodeReadinessFinalizer))
+
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(ds), ds)).To(Succeed())
+			ds.Status.UpdatedNumberScheduled = 2
+			Expect(r.Status().Update(ctx, ds)).To(Succeed())
+
+			_, err = r.Reconcile(ctx, cp)
+			Expect(err).To(Succeed())
+			Expect(apierrors.IsNotFound(r.Get(ctx, key, &rbac.ClusterRole{}))).To(BeTrue())
+			Expect(apierrors.IsNotFound(r.Get(ctx, key, &rbac.ClusterRoleBinding{}))).To(BeTrue())
+			Expect(cp.Finalizers).To(BeEmpty())
+
+			node := &v1.Node{}
+			Expect(r.Get(ctx, client.ObjectKey{Name: "node-a"}, node)).To(Succeed())
+			Expect(node.Labels).To(Equal(map[string]string{"gaudi": "true"}))
+			Expect(node.Status.Conditions).To(ConsistOf(HaveField("Type", v1.NodeReady)))
+
+			// published by the other policy
+			Expect(r.Get(ctx, client.ObjectKey{Name: "node-b"}, node)).To(Succeed())
+			Expect(node.Labels).To(HaveKey(networkv1alpha1.ScaleOutLabel))
+			Expect(node.Status.Conditions).To(HaveLen(2))
+		})
+
+		It("Removes Node readiness when the policy is deleted", func() {
+			cp := &networkv1alpha1.NetworkClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "gaudi-readiness",
+					UID:  "5678",
+				},
+				Spec: networkv1alpha1.NetworkClusterPolicySpec{
+					ConfigurationType: "gaudi-so",
+					NodeSelector:      map[string]string{"gaudi": "true"},
+					GaudiScaleOut: networkv1alpha1.GaudiScaleOutSpec{
+						Layer:     "L3",
+						Readiness: "node",
+					},
+				},
+			}
+			node := &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "node-a",
+					Labels: map[string]string{"gaudi": "true", networkv1alpha1.ScaleOutLabel: "true"},
+				},
+				Status: v1.NodeStatus{
+					Conditions: []v1.NodeCondition{
+						{Type: networkv1alpha1.ScaleOutReadyCondition, Status: v1.ConditionTrue},
+					},
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(networkv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			r := GaudiNICReconciler{Scheme: scheme, Namespace: testNamespace}
+			r.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cp, node).
+				WithStatusSubresource(cp, &v1.Node{}).Build()
+
+			_, err := r.Reconcile(ctx, cp)
+			Expect(err).To(Succeed())
+			Expect(cp.Finalizers).To(ConsistOf(nodeReadinessFinalizer))
+
+			Expect(r.Delete(ctx, cp)).To(Succeed())
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cp), cp)).To(Succeed())
+
+			// the agents are stopped first
+			_, err = r.Reconcile(ctx, cp)
+			Expect(err).To(Succeed())
+			Expect(apierrors.IsNotFound(r.Get(ctx, client.ObjectKey{Name: cp.Name, Namespace: testNamespace}, &apps.DaemonSet{}))).To(BeTrue())
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cp), cp)).To(Succeed())
+
+			_, err = r.Reconcile(ctx, cp)
+			Expect(err).To(Succeed())
+			Expect(apierrors.IsNotFound(r.Get(ctx, client.ObjectKeyFromObject(cp), cp))).To(BeTrue())
+
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+			Expect(node.Labels).To(Equal(map[string]string{"gaudi": "true"}))
+			Expect(node.Status.Conditions).To(BeEmpty())
+		})
+	})
+})
@@ -16,6 +16,8 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -23,6 +25,8 @@ import (
 	core "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
 	resource "k8s.io/api/resource/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -410,27 +414,137 @@ func (r *HostNICReconciler) removeHostNICObjects(ctx context.Context) {
 
 }
 
-func (r *HostNICReconciler) Reconcile(ctx context.Context, cp *networkv1alpha1.NetworkClusterPolicy) (ctrl.Result, error) {
-	if cp == nil || cp.Spec.ConfigurationType != hostNicScaleOutSelection || !cp.Spec.HostNicScaleOut.InstallDRANet {
-		r.removeHostNICObjects(ctx)
-		return ctrl.Result{}, nil
+func (r *HostNICReconciler) deviceClassCondition(ctx context.Context, cp *networkv1alpha1.NetworkClusterPolicy) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               networkv1alpha1.ConditionDeviceClassReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: cp.Generation,
+	}
+
+	if cp.Spec.HostNicScaleOut.Dranet.RDMADeviceClass == nil {
+		condition.Reason = "NotRequested"
+		condition.Message = "No DeviceClass defined in the policy"
+		return condition
+	}
+
+	name := deployments.DranetRDMADeviceClass().Name
+	if cp.Spec.HostNicScaleOut.Dranet.RDMADeviceClass.Name != "" {
+		name = cp.Spec.HostNicScaleOut.Dranet.RDMADeviceClass.Name
+	}
+
+	var dc resource.DeviceClass
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, &dc); err != nil {
+		condition.Reason = "NotFound"
+		condition.Message = fmt.Sprintf("DeviceClass %s does not exist: %v", name, err)
+		return condition
+	}
+
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = "Found"
+	condition.Message = fmt.Sprintf("DeviceClass %s exists", name)
+
+	return condition
+}
+
+func (r *HostNICReconciler) updateStatus(ctx context.Context, cp *networkv1alpha1.NetworkClusterPolicy, reconcileErrors []string) (ctrl.Result, error) {
+	updated := false
+
+	var targets, ready int32
+
+	if cp.Spec.HostNicScaleOut.InstallDRANet {
+		var ds apps.DaemonSet
+		if err := r.Get(ctx, client.ObjectKey{Name: deployments.DranetDaemonSet().Name, Namespace: r.Namespace}, &ds); err == nil {
+			targets = ds.Status.DesiredNumberScheduled
+			ready = ds.Status.NumberReady
+		} else if client.IgnoreNotFound(err) != nil {
+			klog.Errorf("could not fetch DRANet DaemonSet: %v", err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	if cp.Status.Targets != targets {
+		cp.Status.Targets = targets
+		updated = true
+	}
+
+	if cp.Status.ReadyNodes != ready {
+		cp.Status.ReadyNodes = ready
+		updated = true
+	}
+
+	if !cmp.Equal(cp.Status.Errors, reconcileErrors, cmpopts.EquateEmpty()) {
+		updated = true
 	}
 
-	if err := r.updateClusterRole(ctx, cp); err != nil {
-		return ctrl.Result{}, err
+	cp.Status.Errors = reconcileErrors
+
+	state := rolloutState(cp)
+	if !cp.Spec.HostNicScaleOut.InstallDRANet {
+		state = "DRANet not installed by the operator"
 	}
-	if err := r.updateClusterRoleBinding(ctx, cp); err != nil {
-		return ctrl.Result{}, err
+
+	if cp.Status.State != state {
+		cp.Status.State = state
+		updated = true
 	}
-	if err := r.updateServiceAccount(ctx, cp); err != nil {
-		return ctrl.Result{}, err
+
+	degradedReason, degradedMessage := "", ""
+	if len(reconcileErrors) > 0 {
+		degradedReason = "ReconcileFailed"
+		degradedMessage = strings.Join(reconcileErrors, "; ")
 	}
-	if err := r.updateDeviceClass(ctx, cp); err != nil {
-		return ctrl.Result{}, err
+
+	conditions := policyConditions(cp, degradedReason, degradedMessage)
+	conditions = append(conditions, r.deviceClassCondition(ctx, cp))
+
+	if setPolicyConditions(cp, conditions) {
+		updated = true
 	}
-	if err := r.updateDranetDaemonSet(ctx, cp); err != nil {
-		return ctrl.Result{}, err
+
+	if updated {
+		if err := r.Status().Update(ctx, cp); apierrors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		} else if err != nil {
+			klog.Errorf("unable to update HostNIC policy status: %v", err)
+			return ctrl.Result{}, err
+		}
 	}
 
 	return ctrl.Result{}, nil
 }
+
+func (r *HostNICReconciler) Reconcile(ctx context.Context, cp *networkv1alpha1.NetworkClusterPolicy) (ctrl.Result, error) {
+	if cp == nil || cp.Spec.ConfigurationType != hostNicScaleOutSelection || !cp.Spec.HostNicScaleOut.InstallDRANet {
+		r.removeHostNICObjects(ctx)
+
+		if cp != nil && cp.Spec.ConfigurationType == hostNicScaleOutSelection {
+			return r.updateStatus(ctx, cp, []string{})
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	updates := []struct {
+		object string
+		update func(context.Context, *networkv1alpha1.NetworkClusterPolicy) error
+	}{
+		{"ClusterRole", r.updateClusterRole},
+		{"ClusterRoleBinding", r.updateClusterRoleBinding},
+		{"ServiceAccount", r.updateServiceAccount},
+		{"DeviceClass", r.updateDeviceClass},
+		{"DaemonSet", r.updateDranetDaemonSet},
+	}
+
+	for _, u := range updates {
+		if err := u.update(ctx, cp); err != nil {
+			msg := fmt.Sprintf("unable to reconcile DRANet %s: %v", u.object, err)
+			if _, statusErr := r.updateStatus(ctx, cp, []string{msg}); statusErr != nil {
+				klog.Errorf("unable to report error in HostNIC policy status: %v", statusErr)
+			}
+
+			return ctrl.Result{}, err
+		}
+	}
+
+	return r.updateStatus(ctx, cp, []string{})
+}
@@ -25,6 +25,8 @@ import (
 	core "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
 	resource "k8s.io/api/resource/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -198,5 +200,72 @@ var _ = Describe("DRANet Controller", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 		})
+
+		It("Verify status reporting", func() {
+			cp := &networkv1alpha1.NetworkClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "hostnic-policy",
+				},
+				Spec: networkv1alpha1.NetworkClusterPolicySpec{
+					ConfigurationType: "hostnic-so",
+					HostNicScaleOut: networkv1alpha1.HostNicScaleOutSpec{
+						InstallDRANet: true,
+						Dranet: networkv1alpha1.DranetSpec{
+							RDMADeviceClass: &networkv1alpha1.RDMADeviceClassSpec{
+								Name: testDeviceClass,
+							},
+						},
+					},
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(core.AddToScheme(scheme)).To(Succeed())
+			Expect(rbac.AddToScheme(scheme)).To(Succeed())
+			Expect(apps.AddToScheme(scheme)).To(Succeed())
+			Expect(resource.AddToScheme(scheme)).To(Succeed())
+			Expect(networkv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			r := HostNICReconciler{Scheme: scheme, Namespace: testNamespace, ReqName: cp.Name}
+			r.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cp).WithStatusSubresource(cp).Build()
+
+			_, err := r.Reconcile(ctx, cp)
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &networkv1alpha1.NetworkClusterPolicy{}
+			Expect(r.Get(ctx, client.ObjectKey{Name: cp.Name}, updated)).To(Succeed())
+			Expect(updated.Status.State).To(Equal("No targets"))
+			Expect(updated.Status.Errors).To(BeEmpty())
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, networkv1alpha1.ConditionDeviceClassReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, networkv1alpha1.ConditionDegraded)).To(BeTrue())
+
+			// DaemonSet rolls out
+			ds := apps.DaemonSet{}
+			Expect(r.Get(ctx, client.ObjectKey{Name: deployments.DranetDaemonSet().Name, Namespace: testNamespace}, &ds)).To(Succeed())
+			ds.Status.DesiredNumberScheduled = 3
+			ds.Status.NumberReady = 3
+			Expect(r.Status().Update(ctx, &ds)).To(Succeed())
+
+			_, err = r.Reconcile(ctx, updated)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, client.ObjectKey{Name: cp.Name}, updated)).To(Succeed())
+			Expect(updated.Status.Targets).To(BeEquivalentTo(3))
+			Expect(updated.Status.ReadyNodes).To(BeEquivalentTo(3))
+			Expect(updated.Status.State).To(Equal("All good"))
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, networkv1alpha1.ConditionAvailable)).To(BeTrue())
+
+			// DeviceClass goes missing
+			Expect(r.Delete(ctx, &resource.DeviceClass{ObjectMeta: metav1.ObjectMeta{Name: testDeviceClass}})).To(Succeed())
+			updated.Spec.HostNicScaleOut.Dranet.RDMADeviceClass = nil
+
+			_, err = r.updateStatus(ctx, updated, []string{"unable to reconcile DRANet DaemonSet: boom"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, client.ObjectKey{Name: cp.Name}, updated)).To(Succeed())
+			Expect(updated.Status.Errors).To(HaveLen(1))
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, networkv1alpha1.ConditionDegraded)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, networkv1alpha1.ConditionDeviceClassReady)).To(BeTrue())
+		})
 	})
 })
@@ -21,9 +21,12 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
 )
@@ -31,11 +34,16 @@ import (
 //+kubebuilder:rbac:groups=intel.com,resources=networkclusterpolicies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=intel.com,resources=networkclusterpolicies/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=intel.com,resources=networkclusterpolicies/finalizers,verbs=update
+//+kubebuilder:rbac:groups=intel.com,resources=networknodestates,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=intel.com,resources=networknodestates/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;create;delete
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;create;delete
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
-//+kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=get;patch
+//+kubebuilder:rbac:groups="",resources=nodes/status,verbs=patch
 
 // NetworkClusterPolicyReconciler reconciles a NetworkClusterPolicy object
 type NetworkClusterPolicyReconciler struct {
@@ -137,6 +145,38 @@ func indexPods(ctx context.Context, mgr ctrl.Manager) error {
 		})
 }
 
+// nodeStateToPolicy maps NetworkNodeStates to the policy they are reported for.
+func nodeStateToPolicy(ctx context.Context, obj client.Object) []reconcile.Request {
+	policyName, ok := obj.GetLabels()[networkv1alpha1.NodeStatePolicyLabel]
+	if !ok || policyName == "" {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: policyName}}}
+}
+
+// addressPlanToPolicies maps ConfigMaps in the operator namespace to the
+// policies referencing them as their address plan.
+func (r *NetworkClusterPolicyReconciler) addressPlanToPolicies(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetNamespace() != r.Namespace {
+		return nil
+	}
+
+	policies := &networkv1alpha1.NetworkClusterPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, policy := range policies.Items {
+		if addressPlan := policy.Spec.GaudiScaleOut.AddressPlan; addressPlan != nil && addressPlan.ConfigMapName == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: policy.Name}})
+		}
+	}
+
+	return requests
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *NetworkClusterPolicyReconciler) SetupWithManager(mgr ctrl.Manager, isOpenShift bool) error {
 	r.Scheme = mgr.GetScheme()
@@ -159,5 +199,8 @@ func (r *NetworkClusterPolicyReconciler) SetupWithManager(mgr ctrl.Manager, isOp
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&networkv1alpha1.NetworkClusterPolicy{}).
 		Owns(&apps.DaemonSet{}).
+		Owns(&v1.ConfigMap{}).
+		Watches(&networkv1alpha1.NetworkNodeState{}, handler.EnqueueRequestsFromMapFunc(nodeStateToPolicy)).
+		Watches(&v1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.addressPlanToPolicies)).
 		Complete(r)
 }
@@ -24,6 +24,7 @@ import (
 	core "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
 	resource "k8s.io/api/resource/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	resourceApi "k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -101,6 +102,8 @@ var _ = Describe("NetworkClusterPolicy Controller", Serial, Ordered, func() {
 				g.Expect(nicpolicy.Spec.ConfigurationType).To(BeEquivalentTo("gaudi-so"))
 				g.Expect(nicpolicy.Status.Targets).To(BeIdenticalTo(int32(0)))
 				g.Expect(nicpolicy.Status.State).To(BeIdenticalTo("No targets"))
+				g.Expect(meta.IsStatusConditionFalse(nicpolicy.Status.Conditions, networkv1alpha1.ConditionAvailable)).To(BeTrue())
+				g.Expect(meta.IsStatusConditionFalse(nicpolicy.Status.Conditions, networkv1alpha1.ConditionDegraded)).To(BeTrue())
 			}, timeout, interval).Should(Succeed())
 
 			var ds apps.DaemonSet
@@ -111,6 +114,8 @@ var _ = Describe("NetworkClusterPolicy Controller", Serial, Ordered, func() {
 				"--configure=true",
 				"--keep-running",
 				"--mode=L3",
+				"--policy=test-resource",
+				"--state-file=/var/lib/network-operator/test-resource.json",
 				"--mtu=8000",
 				"--wait=90s",
 				"--gaudinet=/host/etc/habanalabs/gaudinet.json",
@@ -121,6 +126,7 @@ var _ = Describe("NetworkClusterPolicy Controller", Serial, Ordered, func() {
 				"nfd-features",
 				"lldpad",
 				"gaudinetpath",
+				"statepath",
 			}
 
 			Eventually(func(g Gomega) {
@@ -152,9 +158,10 @@ var _ = Describe("NetworkClusterPolicy Controller", Serial, Ordered, func() {
 
 				g.Expect(verified).To(HaveLen(len(expectedVolumes)))
 
-				g.Expect(ds.Spec.Template.Spec.Containers[0].VolumeMounts).To(HaveLen(2))
+				g.Expect(ds.Spec.Template.Spec.Containers[0].VolumeMounts).To(HaveLen(3))
 				g.Expect(ds.Spec.Template.Spec.Containers[0].VolumeMounts[0].Name).To(BeEquivalentTo("nfd-features"))
 				g.Expect(ds.Spec.Template.Spec.Containers[0].VolumeMounts[1].Name).To(BeEquivalentTo("gaudinetpath"))
+				g.Expect(ds.Spec.Template.Spec.Containers[0].VolumeMounts[2].Name).To(BeEquivalentTo("statepath"))
 
 				g.Expect(ds.Spec.Template.Spec.Containers[1].VolumeMounts).To(HaveLen(2))
 				g.Expect(ds.Spec.Template.Spec.Containers[1].VolumeMounts[0].Name).To(BeEquivalentTo("lldpad"))
@@ -167,6 +174,15 @@ var _ = Describe("NetworkClusterPolicy Controller", Serial, Ordered, func() {
 				g.Expect(rb.Subjects[0].Name).To(BeEquivalentTo(resourceName + "-sa"))
 				g.Expect(rb.Subjects[0].Namespace).To(BeEquivalentTo(defaultNs))
 
+				// Check for node state cluster role and its binding
+				var cr rbac.ClusterRole
+				var crb rbac.ClusterRoleBinding
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName + "-linkdiscovery"}, &cr)).To(Succeed())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName + "-linkdiscovery"}, &crb)).To(Succeed())
+				g.Expect(crb.RoleRef.Name).To(BeEquivalentTo(resourceName + "-linkdiscovery"))
+				g.Expect(crb.Subjects).To(HaveLen(1))
+				g.Expect(crb.Subjects[0].Name).To(BeEquivalentTo(resourceName + "-sa"))
+
 			}, timeout, interval).Should(Succeed())
 
 			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
@@ -178,6 +194,8 @@ var _ = Describe("NetworkClusterPolicy Controller", Serial, Ordered, func() {
 				"--configure=true",
 				"--keep-running",
 				"--mode=L2",
+				"--policy=test-resource",
+				"--state-file=/var/lib/network-operator/test-resource.json",
 				"--mtu=8000",
 			}
 
@@ -208,14 +226,18 @@ var _ = Describe("NetworkClusterPolicy Controller", Serial, Ordered, func() {
 			resource.Spec.GaudiScaleOut.MTU = 0
 			resource.Spec.GaudiScaleOut.PFCPriorities = "00000000"
 			resource.Spec.GaudiScaleOut.NetworkMetrics = true
+			resource.Spec.GaudiScaleOut.RoutedPrefixLength = 20
 
 			expectedArgs = []string{
 				"--configure=true",
 				"--keep-running",
 				"--mode=L3",
+				"--policy=test-resource",
+				"--state-file=/var/lib/network-operator/test-resource.json",
 				"--disable-networkmanager",
 				"--wait=90s",
 				"--gaudinet=/host/etc/habanalabs/gaudinet.json",
+				"--routed-prefix=20",
 				"--pfc=none",
 				"--metrics-bind-address=:50152",
 			}
@@ -226,6 +248,7 @@ var _ = Describe("NetworkClusterPolicy Controller", Serial, Ordered, func() {
 				"var-run-dbus",
 				"networkmanager",
 				"gaudinetpath",
+				"statepath",
 			}
 
 			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
@@ -270,14 +293,27 @@ var _ = Describe("NetworkClusterPolicy Controller", Serial, Ordered, func() {
 
 			resource.Spec.GaudiScaleOut.EnableLLDPAD = true
 			resource.Spec.GaudiScaleOut.NetworkMetrics = false
+			resource.Spec.GaudiScaleOut.RouteDestinations = []string{"10.210.0.0/20", "10.220.0.0/16"}
+			resource.Spec.GaudiScaleOut.VLAN = 100
+			resource.Spec.GaudiScaleOut.LLDPAddress = &networkv1alpha1.LLDPAddressSpec{
+				Format: "regex",
+				Regex:  `addr:(\S+)`,
+			}
 
 			expectedArgs = []string{
 				"--configure=true",
 				"--keep-running",
 				"--mode=L3",
+				"--policy=test-resource",
+				"--state-file=/var/lib/network-operator/test-resource.json",
 				"--disable-networkmanager",
 				"--wait=90s",
 				"--gaudinet=/host/etc/habanalabs/gaudinet.json",
+				"--routed-prefix=20",
+				"--route-destinations=10.210.0.0/20,10.220.0.0/16",
+				"--lldp-address-format=regex",
+				`--lldp-address-regex=addr:(\S+)`,
+				"--vlan=100",
 				"--pfc=none",
 			}
 
@@ -286,6 +322,7 @@ var _ = Describe("NetworkClusterPolicy Controller", Serial, Ordered, func() {
 				"var-run-dbus",
 				"networkmanager",
 				"gaudinetpath",
+				"statepath",
 				"lldpad",
 			}
 
@@ -294,6 +331,7 @@ var _ = Describe("NetworkClusterPolicy Controller", Serial, Ordered, func() {
 				"var-run-dbus",
 				"networkmanager",
 				"gaudinetpath",
+				"statepath",
 			}
 
 			expectedVolMountPathsC1 := []string{
@@ -0,0 +1,199 @@
+// Copyright 2026 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"reflect"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+)
+
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;patch
+
+// NodeTaintReconciler taints the nodes selected by Gaudi policies with
+// TaintNotReadyNodes enabled until the discover agent reports their
+// scale-out interfaces as configured. The taint is added back when the
+// agent reports a failure or stops. Policies in dry run mode never get
+// their nodes configured and are ignored.
+type NodeTaintReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// hasScaleOutNotReadyTaint returns true if the node has the taint.
+func hasScaleOutNotReadyTaint(node *v1.Node) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == networkv1alpha1.ScaleOutNotReadyTaint {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setScaleOutNotReadyTaint adds or removes the taint of the node.
+func setScaleOutNotReadyTaint(node *v1.Node, tainted bool) {
+	taints := []v1.Taint{}
+	for _, taint := range node.Spec.Taints {
+		if taint.Key != networkv1alpha1.ScaleOutNotReadyTaint {
+			taints = append(taints, taint)
+		}
+	}
+
+	if tainted {
+		taints = append(taints, v1.Taint{
+			Key:    networkv1alpha1.ScaleOutNotReadyTaint,
+			Effect: v1.TaintEffectNoSchedule,
+		})
+	}
+
+	node.Spec.Taints = taints
+}
+
+// nodeNeedsTaint returns true if a policy tainting not ready nodes selects
+// the node and the node has not been configured for it.
+func (r *NodeTaintReconciler) nodeNeedsTaint(ctx context.Context, node *v1.Node) (bool, error) {
+	policies := &networkv1alpha1.NetworkClusterPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		return false, err
+	}
+
+	for _, policy := range policies.Items {
+		if policy.Spec.ConfigurationType != gaudiScaleOutSelection || !policy.Spec.TaintNotReadyNodes {
+			continue
+		}
+
+		if policy.Spec.GaudiScaleOut.DryRun {
+			continue
+		}
+
+		if !labels.SelectorFromSet(policy.Spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+			continue
+		}
+
+		nodeState := &networkv1alpha1.NetworkNodeState{}
+		if err := r.Get(ctx, client.ObjectKey{Name: node.Name}, nodeState); err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+
+			return false, err
+		}
+
+		if nodeState.Spec.PolicyName != policy.Name || nodeState.Status.State != networkv1alpha1.NodeStateConfigured {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *NodeTaintReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	node := &v1.Node{}
+	if err := r.Get(ctx, req.NamespacedName, node); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			log.Error(err, "unable to fetch Node")
+		}
+
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	tainted, err := r.nodeNeedsTaint(ctx, node)
+	if err != nil {
+		log.Error(err, "unable to get node scale-out state")
+
+		return ctrl.Result{}, err
+	}
+
+	if tainted == hasScaleOutNotReadyTaint(node) {
+		return ctrl.Result{}, nil
+	}
+
+	patch := client.MergeFromWithOptions(node.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	setScaleOutNotReadyTaint(node, tainted)
+
+	if err := r.Patch(ctx, node, patch); err != nil {
+		log.Error(err, "unable to update Node taints")
+
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Scale-out taint updated", "node", node.Name, "tainted", tainted)
+
+	return ctrl.Result{}, nil
+}
+
+// policyToNodes maps Gaudi policies to all nodes, as policies changing
+// their node selector may leave tainted nodes behind.
+func (r *NodeTaintReconciler) policyToNodes(ctx context.Context, obj client.Object) []reconcile.Request {
+	policy, ok := obj.(*networkv1alpha1.NetworkClusterPolicy)
+	if !ok || policy.Spec.ConfigurationType != gaudiScaleOutSelection {
+		return nil
+	}
+
+	nodes := &v1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, node := range nodes.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: node.Name}})
+	}
+
+	return requests
+}
+
+// nodeStateToNode maps NetworkNodeStates to the node they are named after.
+func nodeStateToNode(ctx context.Context, obj client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: obj.GetName()}}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NodeTaintReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Scheme = mgr.GetScheme()
+
+	// node status updates do not change the taint
+	nodeChanged := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) ||
+				!reflect.DeepEqual(e.ObjectOld.(*v1.Node).Spec.Taints, e.ObjectNew.(*v1.Node).Spec.Taints)
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("nodetaint").
+		For(&v1.Node{}, builder.WithPredicates(nodeChanged)).
+		Watches(&networkv1alpha1.NetworkClusterPolicy{}, handler.EnqueueRequestsFromMapFunc(r.policyToNodes)).
+		Watches(&networkv1alpha1.NetworkNodeState{}, handler.EnqueueRequestsFromMapFunc(nodeStateToNode)).
+		Complete(r)
+}
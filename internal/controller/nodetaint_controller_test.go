@@ -0,0 +1,114 @@
+// Copyright 2026 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Node Taint Controller", func() {
+
+	Context("Verify node tainting", func() {
+
+		It("Taints selected nodes until they are configured", func() {
+			cp := &networkv1alpha1.NetworkClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "gaudi-taint"},
+				Spec: networkv1alpha1.NetworkClusterPolicySpec{
+					ConfigurationType:  "gaudi-so",
+					NodeSelector:       map[string]string{"gaudi": "true"},
+					TaintNotReadyNodes: true,
+					GaudiScaleOut: networkv1alpha1.GaudiScaleOutSpec{
+						Layer: "L3",
+					},
+				},
+			}
+
+			otherTaint := v1.Taint{Key: "other", Effect: v1.TaintEffectNoExecute}
+			node := &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"gaudi": "true"}},
+				Spec:       v1.NodeSpec{Taints: []v1.Taint{otherTaint}},
+			}
+			unselected := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}}
+
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(networkv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			r := NodeTaintReconciler{Scheme: scheme}
+			r.Client = fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(cp, node, unselected).
+				Build()
+
+			reconcileNode := func(name string) *v1.Node {
+				req := ctrl.Request{}
+				req.Name = name
+
+				_, err := r.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				n := &v1.Node{}
+				Expect(r.Get(ctx, client.ObjectKey{Name: name}, n)).To(Succeed())
+
+				return n
+			}
+
+			notReadyTaint := v1.Taint{Key: networkv1alpha1.ScaleOutNotReadyTaint, Effect: v1.TaintEffectNoSchedule}
+
+			// no node state yet
+			Expect(reconcileNode(node.Name).Spec.Taints).To(ConsistOf(otherTaint, notReadyTaint))
+			Expect(reconcileNode(unselected.Name).Spec.Taints).To(BeEmpty())
+
+			nodeState := testNodeState(node.Name, cp.Name, networkv1alpha1.NodeStateConfigured, "")
+			Expect(r.Create(ctx, nodeState)).To(Succeed())
+
+			Expect(reconcileNode(node.Name).Spec.Taints).To(ConsistOf(otherTaint))
+
+			for _, state := range []string{networkv1alpha1.NodeStateFailed, networkv1alpha1.NodeStateStopped} {
+				nodeState.Status.State = state
+				Expect(r.Update(ctx, nodeState)).To(Succeed())
+
+				Expect(reconcileNode(node.Name).Spec.Taints).To(ConsistOf(otherTaint, notReadyTaint), "state %s", state)
+			}
+
+			// dry run never configures the node
+			Expect(r.Get(ctx, client.ObjectKey{Name: cp.Name}, cp)).To(Succeed())
+			cp.Spec.GaudiScaleOut.DryRun = true
+			Expect(r.Update(ctx, cp)).To(Succeed())
+
+			Expect(reconcileNode(node.Name).Spec.Taints).To(ConsistOf(otherTaint))
+
+			cp.Spec.GaudiScaleOut.DryRun = false
+			Expect(r.Update(ctx, cp)).To(Succeed())
+
+			Expect(reconcileNode(node.Name).Spec.Taints).To(ConsistOf(otherTaint, notReadyTaint))
+
+			// opting out removes the taint
+			Expect(r.Get(ctx, client.ObjectKey{Name: cp.Name}, cp)).To(Succeed())
+			cp.Spec.TaintNotReadyNodes = false
+			Expect(r.Update(ctx, cp)).To(Succeed())
+
+			Expect(reconcileNode(node.Name).Spec.Taints).To(ConsistOf(otherTaint))
+		})
+	})
+})
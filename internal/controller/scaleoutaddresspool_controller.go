@@ -0,0 +1,424 @@
+// Copyright 2026 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+)
+
+//+kubebuilder:rbac:groups=intel.com,resources=scaleoutaddresspools,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=intel.com,resources=scaleoutaddresspools/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=list;watch
+
+// ScaleOutAddressPoolReconciler allocates point-to-point subnets from a
+// ScaleOutAddressPool to the scale-out interfaces reported in the
+// NetworkNodeStates of the policies using the pool. Allocations of removed
+// nodes are released at once, allocations of interfaces no longer reported
+// for a policy selecting the node after addressReleaseGracePeriod.
+type ScaleOutAddressPoolReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// addressReleaseGracePeriod is how long the subnet of an interface that is
+// no longer reported is kept, so that agent restarts keep their addresses.
+const addressReleaseGracePeriod = 10 * time.Minute
+
+// nodeInterface identifies a scale-out interface of a node.
+type nodeInterface struct {
+	node  string
+	iface string
+}
+
+// addressPoolNetwork describes the point-to-point subnets of a pool.
+type addressPoolNetwork struct {
+	base      *big.Int
+	bits      int
+	prefixLen int
+	count     *big.Int
+}
+
+func newAddressPoolNetwork(spec networkv1alpha1.ScaleOutAddressPoolSpec) (*addressPoolNetwork, error) {
+	_, network, err := net.ParseCIDR(spec.CIDR)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR '%s'", spec.CIDR)
+	}
+
+	ones, bits := network.Mask.Size()
+
+	prefixLen := spec.PrefixLength
+	switch {
+	case prefixLen == 0 && bits == 32:
+		prefixLen = 30
+	case prefixLen == 0:
+		prefixLen = 127
+	case bits == 32 && prefixLen != 30, bits == 128 && prefixLen == 30:
+		return nil, fmt.Errorf("prefix length /%d does not match the address family of '%s'", prefixLen, spec.CIDR)
+	}
+
+	if ones > prefixLen {
+		return nil, fmt.Errorf("network '%s' is smaller than a /%d subnet", spec.CIDR, prefixLen)
+	}
+
+	ip := network.IP.To4()
+	if bits == 128 {
+		ip = network.IP.To16()
+	}
+
+	return &addressPoolNetwork{
+		base:      new(big.Int).SetBytes(ip),
+		bits:      bits,
+		prefixLen: prefixLen,
+		count:     new(big.Int).Lsh(big.NewInt(1), uint(prefixLen-ones)),
+	}, nil
+}
+
+func (n *addressPoolNetwork) toIP(i *big.Int) net.IP {
+	ip := make(net.IP, n.bits/8)
+	return i.FillBytes(ip)
+}
+
+// index returns the subnet index of the address or nil if the address is
+// not in the pool.
+func (n *addressPoolNetwork) index(address string) *big.Int {
+	ip, network, err := net.ParseCIDR(address)
+	if err != nil {
+		return nil
+	}
+
+	if ones, bits := network.Mask.Size(); ones != n.prefixLen || bits != n.bits {
+		return nil
+	}
+
+	if n.bits == 32 {
+		ip = ip.To4()
+	}
+
+	offset := new(big.Int).Sub(new(big.Int).SetBytes(ip), n.base)
+	if offset.Sign() < 0 {
+		return nil
+	}
+
+	index := offset.Rsh(offset, uint(n.bits-n.prefixLen))
+	if index.Cmp(n.count) >= 0 {
+		return nil
+	}
+
+	return index
+}
+
+// allocation returns the local address and the peer address of the subnet.
+// The local address is the first address after the subnet address, the
+// peer address the other address of the point-to-point subnet.
+func (n *addressPoolNetwork) allocation(index *big.Int) (string, string) {
+	subnet := new(big.Int).Lsh(index, uint(n.bits-n.prefixLen))
+	subnet.Add(subnet, n.base)
+
+	local := new(big.Int).Add(subnet, big.NewInt(1))
+
+	peer := new(big.Int).Add(subnet, big.NewInt(2))
+	if n.prefixLen == 127 {
+		peer = subnet
+	}
+
+	return fmt.Sprintf("%s/%d", n.toIP(local), n.prefixLen), n.toIP(peer).String()
+}
+
+// allocateAddresses keeps the allocations of existing nodes matching the
+// pool network and allocates subnets for the new node interfaces. The
+// allocations of interfaces no longer reported are released once they have
+// been unused for the grace period. Returns the time until the next of them
+// is to be released, or zero.
+func allocateAddresses(pool *networkv1alpha1.ScaleOutAddressPool, nodes map[string]bool, interfaces []nodeInterface, now time.Time) (networkv1alpha1.ScaleOutAddressPoolStatus, time.Duration) {
+	status := networkv1alpha1.ScaleOutAddressPoolStatus{
+		Allocations: []networkv1alpha1.AddressAllocation{},
+	}
+
+	network, err := newAddressPoolNetwork(pool.Spec)
+	if err != nil {
+		// keep the allocations until the pool is fixed
+		status = *pool.Status.DeepCopy()
+		status.Message = err.Error()
+		return status, 0
+	}
+
+	reported := map[nodeInterface]bool{}
+	for _, iface := range interfaces {
+		reported[iface] = true
+	}
+
+	used := map[string]bool{}
+	allocated := map[nodeInterface]bool{}
+	var release time.Duration
+
+	for _, a := range pool.Status.Allocations {
+		allocation := *a.DeepCopy()
+		key := nodeInterface{node: allocation.NodeName, iface: allocation.Interface}
+		index := network.index(allocation.Address)
+
+		if !nodes[allocation.NodeName] || index == nil || used[index.String()] || allocated[key] {
+			continue
+		}
+
+		switch {
+		case reported[key]:
+			allocation.UnusedSince = nil
+		case allocation.UnusedSince == nil:
+			unusedSince := metav1.NewTime(now)
+			allocation.UnusedSince = &unusedSince
+		}
+
+		if allocation.UnusedSince != nil {
+			remaining := addressReleaseGracePeriod - now.Sub(allocation.UnusedSince.Time)
+			if remaining <= 0 {
+				continue
+			}
+
+			if release == 0 || remaining < release {
+				release = remaining
+			}
+		}
+
+		used[index.String()] = true
+		allocated[key] = true
+		status.Allocations = append(status.Allocations, allocation)
+	}
+
+	next := big.NewInt(0)
+	for _, iface := range interfaces {
+		if allocated[iface] || !nodes[iface.node] {
+			continue
+		}
+
+		for used[next.String()] {
+			next.Add(next, big.NewInt(1))
+		}
+
+		if next.Cmp(network.count) >= 0 {
+			status.Message = fmt.Sprintf("address pool exhausted, no subnet for node '%s' interface '%s'", iface.node, iface.iface)
+			break
+		}
+
+		address, peer := network.allocation(next)
+		status.Allocations = append(status.Allocations, networkv1alpha1.AddressAllocation{
+			NodeName:  iface.node,
+			Interface: iface.iface,
+			Address:   address,
+			Peer:      peer,
+		})
+
+		used[next.String()] = true
+		allocated[iface] = true
+	}
+
+	sort.Slice(status.Allocations, func(i, j int) bool {
+		a, b := status.Allocations[i], status.Allocations[j]
+		if a.NodeName != b.NodeName {
+			return a.NodeName < b.NodeName
+		}
+		return a.Interface < b.Interface
+	})
+
+	status.Allocated = len(status.Allocations)
+
+	available := new(big.Int).Sub(network.count, big.NewInt(int64(status.Allocated)))
+	if available.IsInt64() {
+		status.Available = available.Int64()
+	} else {
+		status.Available = math.MaxInt64
+	}
+
+	return status, release
+}
+
+// poolInterfaces returns the node interfaces reported in the NetworkNodeStates
+// of the policies using the pool, for the nodes the policies still select.
+func (r *ScaleOutAddressPoolReconciler) poolInterfaces(ctx context.Context, poolName string, nodes map[string]labels.Set) ([]nodeInterface, error) {
+	policies := &networkv1alpha1.NetworkClusterPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		return nil, err
+	}
+
+	interfaces := []nodeInterface{}
+
+	for _, policy := range policies.Items {
+		if policy.Spec.GaudiScaleOut.AddressPool != poolName {
+			continue
+		}
+
+		nodeStates := &networkv1alpha1.NetworkNodeStateList{}
+		if err := r.List(ctx, nodeStates, client.MatchingLabels{networkv1alpha1.NodeStatePolicyLabel: policy.Name}); err != nil {
+			return nil, err
+		}
+
+		selector := labels.SelectorFromSet(policy.Spec.NodeSelector)
+
+		for _, nodeState := range nodeStates.Items {
+			nodeLabels, ok := nodes[nodeState.Name]
+			if !ok || !selector.Matches(nodeLabels) {
+				continue
+			}
+
+			for _, iface := range nodeState.Status.Interfaces {
+				interfaces = append(interfaces, nodeInterface{node: nodeState.Name, iface: iface.Name})
+			}
+		}
+	}
+
+	sort.Slice(interfaces, func(i, j int) bool {
+		if interfaces[i].node != interfaces[j].node {
+			return interfaces[i].node < interfaces[j].node
+		}
+		return interfaces[i].iface < interfaces[j].iface
+	})
+
+	return interfaces, nil
+}
+
+func (r *ScaleOutAddressPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	pool := &networkv1alpha1.ScaleOutAddressPool{}
+	if err := r.Get(ctx, req.NamespacedName, pool); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			log.Error(err, "unable to fetch ScaleOutAddressPool")
+		}
+
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	nodeList := &v1.NodeList{}
+	if err := r.List(ctx, nodeList); err != nil {
+		log.Error(err, "unable to list nodes")
+
+		return ctrl.Result{}, err
+	}
+
+	nodes := map[string]bool{}
+	nodeLabels := map[string]labels.Set{}
+	for _, node := range nodeList.Items {
+		nodes[node.Name] = true
+		nodeLabels[node.Name] = node.Labels
+	}
+
+	interfaces, err := r.poolInterfaces(ctx, pool.Name, nodeLabels)
+	if err != nil {
+		log.Error(err, "unable to list pool interfaces")
+
+		return ctrl.Result{}, err
+	}
+
+	status, release := allocateAddresses(pool, nodes, interfaces, time.Now())
+	if cmp.Equal(pool.Status, status, cmpopts.EquateEmpty()) {
+		return ctrl.Result{RequeueAfter: release}, nil
+	}
+
+	pool.Status = status
+	if err := r.Status().Update(ctx, pool); err != nil {
+		log.Error(err, "unable to update ScaleOutAddressPool status")
+
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Address pool updated", "allocated", status.Allocated, "available", status.Available)
+
+	return ctrl.Result{RequeueAfter: release}, nil
+}
+
+// policyToAddressPool maps policies to the address pool they use.
+func policyToAddressPool(ctx context.Context, obj client.Object) []reconcile.Request {
+	policy, ok := obj.(*networkv1alpha1.NetworkClusterPolicy)
+	if !ok || policy.Spec.GaudiScaleOut.AddressPool == "" {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: policy.Spec.GaudiScaleOut.AddressPool}}}
+}
+
+// nodeStateToAddressPool maps NetworkNodeStates to the address pool of
+// the policy they are reported for.
+func (r *ScaleOutAddressPoolReconciler) nodeStateToAddressPool(ctx context.Context, obj client.Object) []reconcile.Request {
+	policyName, ok := obj.GetLabels()[networkv1alpha1.NodeStatePolicyLabel]
+	if !ok || policyName == "" {
+		return nil
+	}
+
+	policy := &networkv1alpha1.NetworkClusterPolicy{}
+	if err := r.Get(ctx, client.ObjectKey{Name: policyName}, policy); err != nil {
+		return nil
+	}
+
+	return policyToAddressPool(ctx, policy)
+}
+
+// nodeToAddressPools maps removed nodes and nodes with changed labels to all
+// address pools.
+func (r *ScaleOutAddressPoolReconciler) nodeToAddressPools(ctx context.Context, obj client.Object) []reconcile.Request {
+	pools := &networkv1alpha1.ScaleOutAddressPoolList{}
+	if err := r.List(ctx, pools); err != nil {
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, pool := range pools.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: pool.Name}})
+	}
+
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ScaleOutAddressPoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Scheme = mgr.GetScheme()
+
+	nodeChanged := predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !labels.Equals(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&networkv1alpha1.ScaleOutAddressPool{}).
+		Watches(&networkv1alpha1.NetworkClusterPolicy{}, handler.EnqueueRequestsFromMapFunc(policyToAddressPool)).
+		Watches(&networkv1alpha1.NetworkNodeState{}, handler.EnqueueRequestsFromMapFunc(r.nodeStateToAddressPool)).
+		Watches(&v1.Node{}, handler.EnqueueRequestsFromMapFunc(r.nodeToAddressPools), builder.WithPredicates(nodeChanged)).
+		Complete(r)
+}
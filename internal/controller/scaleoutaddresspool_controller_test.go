@@ -0,0 +1,227 @@
+// Copyright 2026 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"time"
+
+	networkv1alpha1 "github.com/intel/network-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testPoolNodeState(name, policy string, interfaces ...string) *networkv1alpha1.NetworkNodeState {
+	nodeState := testNodeState(name, policy, networkv1alpha1.NodeStateDiscovered, "")
+	for _, iface := range interfaces {
+		nodeState.Status.Interfaces = append(nodeState.Status.Interfaces, networkv1alpha1.InterfaceState{Name: iface})
+	}
+	return nodeState
+}
+
+var _ = Describe("ScaleOutAddressPool Controller", func() {
+
+	Context("Verify address allocation", func() {
+
+		It("Allocates point-to-point subnets", func() {
+			now := time.Now()
+			pool := &networkv1alpha1.ScaleOutAddressPool{
+				Spec: networkv1alpha1.ScaleOutAddressPoolSpec{CIDR: "10.220.0.0/29"},
+			}
+			nodes := map[string]bool{"node-a": true, "node-b": true}
+
+			status, _ := allocateAddresses(pool, nodes, []nodeInterface{
+				{node: "node-a", iface: "eth0"},
+				{node: "node-a", iface: "eth1"},
+				{node: "node-b", iface: "eth0"},
+				{node: "node-c", iface: "eth0"},
+			}, now)
+
+			Expect(status.Allocations).To(Equal([]networkv1alpha1.AddressAllocation{
+				{NodeName: "node-a", Interface: "eth0", Address: "10.220.0.1/30", Peer: "10.220.0.2"},
+				{NodeName: "node-a", Interface: "eth1", Address: "10.220.0.5/30", Peer: "10.220.0.6"},
+			}))
+			Expect(status.Available).To(BeZero())
+			Expect(status.Message).To(ContainSubstring("exhausted"))
+
+			// node-a leaves, node-b gets its subnet
+			pool.Status = status
+			status, _ = allocateAddresses(pool, map[string]bool{"node-b": true}, []nodeInterface{
+				{node: "node-b", iface: "eth0"},
+			}, now)
+
+			Expect(status.Allocations).To(Equal([]networkv1alpha1.AddressAllocation{
+				{NodeName: "node-b", Interface: "eth0", Address: "10.220.0.1/30", Peer: "10.220.0.2"},
+			}))
+			Expect(status.Available).To(BeEquivalentTo(1))
+			Expect(status.Message).To(BeEmpty())
+
+			// IPv6
+			pool = &networkv1alpha1.ScaleOutAddressPool{
+				Spec: networkv1alpha1.ScaleOutAddressPoolSpec{CIDR: "2001:db8::/64"},
+			}
+			status, _ = allocateAddresses(pool, nodes, []nodeInterface{
+				{node: "node-a", iface: "eth0"},
+				{node: "node-a", iface: "eth1"},
+			}, now)
+
+			Expect(status.Allocations).To(Equal([]networkv1alpha1.AddressAllocation{
+				{NodeName: "node-a", Interface: "eth0", Address: "2001:db8::1/127", Peer: "2001:db8::"},
+				{NodeName: "node-a", Interface: "eth1", Address: "2001:db8::3/127", Peer: "2001:db8::2"},
+			}))
+
+			pool.Spec.PrefixLength = 126
+			pool.Status = status
+			status, _ = allocateAddresses(pool, nodes, []nodeInterface{{node: "node-a", iface: "eth0"}}, now)
+
+			Expect(status.Allocations).To(Equal([]networkv1alpha1.AddressAllocation{
+				{NodeName: "node-a", Interface: "eth0", Address: "2001:db8::1/126", Peer: "2001:db8::2"},
+			}))
+
+			// Invalid pools keep the allocations
+			pool.Status = status
+			pool.Spec.PrefixLength = 30
+			status, _ = allocateAddresses(pool, nodes, []nodeInterface{{node: "node-a", iface: "eth0"}}, now)
+
+			Expect(status.Allocations).To(HaveLen(1))
+			Expect(status.Message).NotTo(BeEmpty())
+		})
+
+		It("Releases unused allocations after the grace period", func() {
+			pool := &networkv1alpha1.ScaleOutAddressPool{
+				Spec: networkv1alpha1.ScaleOutAddressPoolSpec{CIDR: "10.220.0.0/29"},
+			}
+			nodes := map[string]bool{"node-a": true, "node-b": true}
+			now := time.Now()
+
+			status, release := allocateAddresses(pool, nodes, []nodeInterface{
+				{node: "node-a", iface: "eth0"},
+				{node: "node-b", iface: "eth0"},
+			}, now)
+			Expect(status.Allocations).To(HaveLen(2))
+			Expect(release).To(BeZero())
+
+			// node-b is no longer reported
+			pool.Status = status
+			status, release = allocateAddresses(pool, nodes, []nodeInterface{{node: "node-a", iface: "eth0"}}, now)
+			Expect(status.Allocations).To(HaveLen(2))
+			Expect(status.Allocations[0].UnusedSince).To(BeNil())
+			Expect(status.Allocations[1].UnusedSince).NotTo(BeNil())
+			Expect(release).To(Equal(addressReleaseGracePeriod))
+
+			// reported again within the grace period
+			pool.Status = status
+			status, release = allocateAddresses(pool, nodes, []nodeInterface{
+				{node: "node-a", iface: "eth0"},
+				{node: "node-b", iface: "eth0"},
+			}, now.Add(time.Minute))
+			Expect(status.Allocations[1].UnusedSince).To(BeNil())
+			Expect(release).To(BeZero())
+
+			pool.Status = status
+			status, _ = allocateAddresses(pool, nodes, []nodeInterface{{node: "node-a", iface: "eth0"}}, now)
+			pool.Status = status
+			status, release = allocateAddresses(pool, nodes, []nodeInterface{{node: "node-a", iface: "eth0"}}, now.Add(time.Minute))
+			Expect(status.Allocations).To(HaveLen(2))
+			Expect(release).To(Equal(addressReleaseGracePeriod - time.Minute))
+
+			pool.Status = status
+			status, release = allocateAddresses(pool, nodes, []nodeInterface{{node: "node-a", iface: "eth0"}}, now.Add(addressReleaseGracePeriod))
+			Expect(status.Allocations).To(Equal([]networkv1alpha1.AddressAllocation{
+				{NodeName: "node-a", Interface: "eth0", Address: "10.220.0.1/30", Peer: "10.220.0.2"},
+			}))
+			Expect(status.Available).To(BeEquivalentTo(1))
+			Expect(release).To(BeZero())
+		})
+
+		It("Keeps allocations stable across reconciles", func() {
+			pool := &networkv1alpha1.ScaleOutAddressPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+				Spec:       networkv1alpha1.ScaleOutAddressPoolSpec{CIDR: "10.220.0.0/24"},
+			}
+			cp := &networkv1alpha1.NetworkClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "gaudi-pool"},
+				Spec: networkv1alpha1.NetworkClusterPolicySpec{
+					ConfigurationType: "gaudi-so",
+					GaudiScaleOut: networkv1alpha1.GaudiScaleOutSpec{
+						Layer:       "L3",
+						AddressPool: pool.Name,
+					},
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(networkv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			nodeB := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}}
+
+			r := ScaleOutAddressPoolReconciler{Scheme: scheme}
+			r.Client = fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(pool, cp, nodeB,
+					&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+					testPoolNodeState("node-b", cp.Name, "eth0", "eth1"),
+					testPoolNodeState("node-a", "other-policy", "eth0")).
+				WithStatusSubresource(pool).
+				Build()
+
+			req := ctrl.Request{}
+			req.Name = pool.Name
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, client.ObjectKey{Name: pool.Name}, pool)).To(Succeed())
+			Expect(pool.Status.Allocations).To(HaveLen(2))
+			Expect(pool.Status.Allocated).To(Equal(2))
+			Expect(pool.Status.Available).To(BeEquivalentTo(62))
+
+			allocations := pool.Status.Allocations
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, client.ObjectKey{Name: pool.Name}, pool)).To(Succeed())
+			Expect(pool.Status.Allocations).To(Equal(allocations))
+
+			// node-b no longer selected by the policy
+			Expect(r.Get(ctx, client.ObjectKey{Name: cp.Name}, cp)).To(Succeed())
+			cp.Spec.NodeSelector = map[string]string{"gaudi": "true"}
+			Expect(r.Update(ctx, cp)).To(Succeed())
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			Expect(r.Get(ctx, client.ObjectKey{Name: pool.Name}, pool)).To(Succeed())
+			Expect(pool.Status.Allocations).To(HaveLen(2))
+			Expect(pool.Status.Allocations).To(HaveEach(HaveField("UnusedSince", Not(BeNil()))))
+
+			Expect(r.Delete(ctx, nodeB)).To(Succeed())
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, client.ObjectKey{Name: pool.Name}, pool)).To(Succeed())
+			Expect(pool.Status.Allocations).To(BeEmpty())
+		})
+	})
+})
@@ -27,6 +27,7 @@ package lldp
 import (
 	"context"
 	"fmt"
+	"net"
 	"reflect"
 	"time"
 
@@ -49,12 +50,21 @@ type Client struct {
 	ctx           context.Context
 }
 
+// OrgSpecificTLV holds an organizationally specific TLV of a lldp frame.
+type OrgSpecificTLV struct {
+	OUI     uint32
+	SubType uint8
+	Info    []byte
+}
+
 // DiscoveryResult holds optional TLV SysName and SysDescription fields of a real lldp frame.
 type DiscoveryResult struct {
 	InterfaceName   string
 	SysName         string
 	SysDescription  string
 	PortDescription string
+	PortID          string
+	OrgTLVs         []OrgSpecificTLV
 	PeerMAC         []byte
 }
 
@@ -69,8 +79,19 @@ func NewClient(ctx context.Context, ifacename string, hwAddr []byte) *Client {
 
 // Start searches on the configured interface for lldp packages and
 // pushes the optional TLV SysName and SysDescription fields of each
-// found lldp package into the given channel.
-func (l *Client) Start(resultChan chan<- DiscoveryResult, portDescriptionFilter func(string) bool) error {
+// found lldp package into the given channel. Packages for which the
+// filter returns false are ignored.
+func (l *Client) Start(resultChan chan<- DiscoveryResult, filter func(DiscoveryResult) bool) error {
+	return l.receive(resultChan, filter, true)
+}
+
+// Listen is like Start, but keeps pushing the information of every
+// matching lldp package into the given channel until the context is done.
+func (l *Client) Listen(resultChan chan<- DiscoveryResult, filter func(DiscoveryResult) bool) error {
+	return l.receive(resultChan, filter, false)
+}
+
+func (l *Client) receive(resultChan chan<- DiscoveryResult, filter func(DiscoveryResult) bool, once bool) error {
 	defer l.Close()
 
 	var packetSource *gopacket.PacketSource
@@ -101,68 +122,108 @@ func (l *Client) Start(resultChan chan<- DiscoveryResult, portDescriptionFilter
 				continue
 			}
 
-			if packet.LinkLayer().LayerType() != layers.LayerTypeEthernet {
+			dr, ok := l.parsePacket(packet)
+			if !ok {
 				continue
 			}
 
-			// Ignore LLDP packets sent by us
-			if reflect.DeepEqual(packet.LinkLayer().LinkFlow().Src().Raw(), l.InterfaceMac) {
+			if filter != nil && !filter(dr) {
+				// Filter function did not match, ignore this packet
 				continue
 			}
 
-			infoFound := false
+			select {
+			case resultChan <- dr:
+			case <-l.ctx.Done():
+				return nil
+			}
 
-			dr := DiscoveryResult{InterfaceName: l.InterfaceName}
-			for _, layer := range packet.Layers() {
-				if layer.LayerType() == layers.LayerTypeLinkLayerDiscovery {
-					info, ok := layer.(*layers.LinkLayerDiscovery)
-					if !ok {
-						continue
-					}
+			if once {
+				return nil
+			}
 
-					if info.ChassisID.Subtype == layers.LLDPChassisIDSubTypeMACAddr {
-						dr.PeerMAC = info.ChassisID.ID
-					}
+		case <-l.ctx.Done():
+			return nil
+		}
+	}
+}
 
-					if info.PortID.Subtype == layers.LLDPPortIDSubtypeMACAddr {
-						dr.PeerMAC = info.PortID.ID
-					}
+// parsePacket returns the information of an lldp packet received from a
+// peer.
+func (l *Client) parsePacket(packet gopacket.Packet) (DiscoveryResult, bool) {
+	dr := DiscoveryResult{InterfaceName: l.InterfaceName}
 
-					continue
-				}
+	if packet.LinkLayer() == nil || packet.LinkLayer().LayerType() != layers.LayerTypeEthernet {
+		return dr, false
+	}
 
-				if layer.LayerType() == layers.LayerTypeLinkLayerDiscoveryInfo {
-					info, ok := layer.(*layers.LinkLayerDiscoveryInfo)
-					if !ok {
-						continue
-					}
+	// Ignore LLDP packets sent by us
+	if reflect.DeepEqual(packet.LinkLayer().LinkFlow().Src().Raw(), l.InterfaceMac) {
+		return dr, false
+	}
 
-					if portDescriptionFilter != nil && !portDescriptionFilter(info.PortDescription) {
-						// Filter function did not match, ignore this packet
-						continue
-					}
+	infoFound := false
 
-					dr.SysName = info.SysName
-					dr.SysDescription = info.SysDescription
-					dr.PortDescription = info.PortDescription
+	for _, layer := range packet.Layers() {
+		if layer.LayerType() == layers.LayerTypeLinkLayerDiscovery {
+			info, ok := layer.(*layers.LinkLayerDiscovery)
+			if !ok {
+				continue
+			}
 
-					infoFound = true
-				}
+			if info.ChassisID.Subtype == layers.LLDPChassisIDSubTypeMACAddr {
+				dr.PeerMAC = info.ChassisID.ID
+			}
 
+			if info.PortID.Subtype == layers.LLDPPortIDSubtypeMACAddr {
+				dr.PeerMAC = info.PortID.ID
 			}
 
-			if !infoFound {
-				// We did not find the info layer, ignore this packet
+			dr.PortID = portIDString(info.PortID)
+
+			continue
+		}
+
+		if layer.LayerType() == layers.LayerTypeLinkLayerDiscoveryInfo {
+			info, ok := layer.(*layers.LinkLayerDiscoveryInfo)
+			if !ok {
 				continue
 			}
 
-			resultChan <- dr
-			return nil
+			dr.SysName = info.SysName
+			dr.SysDescription = info.SysDescription
+			dr.PortDescription = info.PortDescription
 
-		case <-l.ctx.Done():
-			return nil
+			for _, tlv := range info.OrgTLVs {
+				dr.OrgTLVs = append(dr.OrgTLVs, OrgSpecificTLV{
+					OUI:     uint32(tlv.OUI),
+					SubType: tlv.SubType,
+					Info:    tlv.Info,
+				})
+			}
+
+			infoFound = true
+		}
+	}
+
+	// Without the info layer the packet is ignored
+	return dr, infoFound
+}
+
+// portIDString returns the peer port ID in a printable form. MAC and
+// network addresses are formatted, the other subtypes are strings.
+func portIDString(portID layers.LLDPPortID) string {
+	switch portID.Subtype {
+	case layers.LLDPPortIDSubtypeMACAddr:
+		return net.HardwareAddr(portID.ID).String()
+	case layers.LLDPPortIDSubtypeNetworkAddr:
+		// The first octet is the IANA address family
+		if len(portID.ID) == net.IPv4len+1 || len(portID.ID) == net.IPv6len+1 {
+			return net.IP(portID.ID[1:]).String()
 		}
 	}
+
+	return string(portID.ID)
 }
 
 // Close the LLDP client
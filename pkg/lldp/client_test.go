@@ -0,0 +1,77 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lldp
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestParsePacket(t *testing.T) {
+	peerMAC := []byte{0x01, 0x01, 0x02, 0x02, 0x03, 0x03}
+	peer := NewClient(context.Background(), "swp1", peerMAC)
+
+	frame, err := peer.Frame(Advertisement{
+		PortDescription: "no-alert 10.210.8.122/30",
+		SysName:         "switch-a",
+	}, time.Minute)
+	if err != nil {
+		t.Fatalf("cannot build frame: %v", err)
+	}
+	packet := gopacket.NewPacket(frame, layers.LayerTypeEthernet, gopacket.Default)
+
+	client := NewClient(context.Background(), "eth0", []byte{0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f})
+
+	dr, ok := client.parsePacket(packet)
+	if !ok {
+		t.Fatal("expected the packet to be accepted")
+	}
+	if dr.InterfaceName != "eth0" || dr.PortDescription != "no-alert 10.210.8.122/30" ||
+		dr.SysName != "switch-a" || dr.PortID != "swp1" || !bytes.Equal(dr.PeerMAC, peerMAC) {
+		t.Errorf("unexpected result %+v", dr)
+	}
+
+	// packets sent by us are ignored
+	if _, ok := peer.parsePacket(packet); ok {
+		t.Error("expected own packet to be ignored")
+	}
+}
+
+func TestPortIDString(t *testing.T) {
+	tcases := []struct {
+		portID   layers.LLDPPortID
+		expected string
+	}{
+		{layers.LLDPPortID{Subtype: layers.LLDPPortIDSubtypeIfaceName, ID: []byte("Ethernet12")}, "Ethernet12"},
+		{layers.LLDPPortID{Subtype: layers.LLDPPortIDSubtypeLocal, ID: []byte("12")}, "12"},
+		{layers.LLDPPortID{Subtype: layers.LLDPPortIDSubtypeMACAddr,
+			ID: []byte{0xb4, 0x96, 0x91, 0xaa, 0xbb, 0xcc}}, "b4:96:91:aa:bb:cc"},
+		{layers.LLDPPortID{Subtype: layers.LLDPPortIDSubtypeNetworkAddr,
+			ID: []byte{1, 10, 210, 8, 122}}, "10.210.8.122"},
+	}
+
+	for _, tc := range tcases {
+		if portID := portIDString(tc.portID); portID != tc.expected {
+			t.Errorf("expected port ID %s, got %s", tc.expected, portID)
+		}
+	}
+}
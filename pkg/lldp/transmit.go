@@ -0,0 +1,183 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lldp
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+)
+
+const (
+	// Default transmit interval and time to live multiplier of IEEE 802.1AB.
+	DefaultTransmitInterval = 30 * time.Second
+	ttlMultiplier           = 4
+)
+
+// Nearest bridge group address lldp frames are sent to.
+var nearestBridgeMAC = net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x0e}
+
+// Advertisement holds the information transmitted in lldp frames. The
+// chassis ID is the MAC address and the port ID the name of the interface.
+type Advertisement struct {
+	PortDescription string
+	SysName         string
+	SysDescription  string
+	OrgTLVs         []OrgSpecificTLV
+}
+
+func stringTLV(t layers.LLDPTLVType, value string) layers.LinkLayerDiscoveryValue {
+	return layers.LinkLayerDiscoveryValue{
+		Type:   t,
+		Length: uint16(len(value)),
+		Value:  []byte(value),
+	}
+}
+
+// Frame returns the lldp Ethernet frame advertising the information
+// with the given time to live. A zero time to live tells the peers to
+// forget the information.
+func (l *Client) Frame(adv Advertisement, ttl time.Duration) ([]byte, error) {
+	if len(l.InterfaceMac) != 6 {
+		return nil, fmt.Errorf("interface %s has no MAC address", l.InterfaceName)
+	}
+
+	lldp := &layers.LinkLayerDiscovery{
+		ChassisID: layers.LLDPChassisID{
+			Subtype: layers.LLDPChassisIDSubTypeMACAddr,
+			ID:      l.InterfaceMac,
+		},
+		PortID: layers.LLDPPortID{
+			Subtype: layers.LLDPPortIDSubtypeIfaceName,
+			ID:      []byte(l.InterfaceName),
+		},
+		TTL: uint16(min(ttl.Seconds(), math.MaxUint16)),
+	}
+
+	for _, tlv := range []struct {
+		t     layers.LLDPTLVType
+		value string
+	}{
+		{layers.LLDPTLVPortDescription, adv.PortDescription},
+		{layers.LLDPTLVSysName, adv.SysName},
+		{layers.LLDPTLVSysDescription, adv.SysDescription},
+	} {
+		if tlv.value != "" {
+			lldp.Values = append(lldp.Values, stringTLV(tlv.t, tlv.value))
+		}
+	}
+
+	for _, tlv := range adv.OrgTLVs {
+		value := append([]byte{byte(tlv.OUI >> 16), byte(tlv.OUI >> 8), byte(tlv.OUI), tlv.SubType}, tlv.Info...)
+		lldp.Values = append(lldp.Values, layers.LinkLayerDiscoveryValue{
+			Type:   layers.LLDPTLVOrgSpecific,
+			Length: uint16(len(value)),
+			Value:  value,
+		})
+	}
+
+	for _, value := range lldp.Values {
+		// the TLV length field has 9 bits
+		if value.Length > 511 {
+			return nil, fmt.Errorf("lldp TLV %d is too long (%d bytes)", value.Type, value.Length)
+		}
+	}
+
+	eth := &layers.Ethernet{
+		SrcMAC:       l.InterfaceMac,
+		DstMAC:       nearestBridgeMAC,
+		EthernetType: layers.EthernetTypeLinkLayerDiscovery,
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{}, eth, lldp); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Transmit sends lldp frames with the advertisement returned by the given
+// function on the configured interface at the given interval until the
+// context is done. The advertisement is fetched again for every frame so
+// that it can change while transmitting. When frames cannot be sent, e.g.
+// while the link is down or the interface is being reset, the interface is
+// opened again for the next frame and the first error of the failing frames
+// is passed to onError. When done,
+// a frame with a zero time to live is sent to have the peers remove the
+// information.
+func (l *Client) Transmit(interval time.Duration, advertisement func() Advertisement, onError func(error)) error {
+	if interval <= 0 {
+		interval = DefaultTransmitInterval
+	}
+
+	handle, err := pcap.OpenLive(l.InterfaceName, 1600, false, pcap.BlockForever)
+	if err != nil {
+		return fmt.Errorf("unable to open interface:%s for transmit: %w", l.InterfaceName, err)
+	}
+	defer func() {
+		if handle != nil {
+			handle.Close()
+		}
+	}()
+
+	send := func(ttl time.Duration) error {
+		if handle == nil {
+			if handle, err = pcap.OpenLive(l.InterfaceName, 1600, false, pcap.BlockForever); err != nil {
+				return fmt.Errorf("unable to open interface:%s for transmit: %w", l.InterfaceName, err)
+			}
+		}
+
+		frame, err := l.Frame(advertisement(), ttl)
+		if err != nil {
+			return err
+		}
+
+		if err := handle.WritePacketData(frame); err != nil {
+			handle.Close()
+			handle = nil
+
+			return fmt.Errorf("unable to send lldp frame on interface:%s: %w", l.InterfaceName, err)
+		}
+
+		return nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	failing := false
+
+	for {
+		err := send(ttlMultiplier * interval)
+		if err != nil && !failing && onError != nil {
+			onError(err)
+		}
+		failing = err != nil
+
+		select {
+		case <-ticker.C:
+		case <-l.ctx.Done():
+			return send(0)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright (C) 2026 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lldp
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestFrame(t *testing.T) {
+	mac := []byte{0x01, 0x01, 0x02, 0x02, 0x03, 0x03}
+	client := NewClient(context.Background(), "eth0", mac)
+
+	adv := Advertisement{
+		PortDescription: "module 3 eth0",
+		SysName:         "node-a",
+		SysDescription:  "Intel Gaudi scale-out",
+		OrgTLVs: []OrgSpecificTLV{
+			{OUI: 0x0012bb, SubType: 7, Info: []byte("10.210.8.121/30")},
+		},
+	}
+
+	frame, err := client.Frame(adv, 120*time.Second)
+	if err != nil {
+		t.Fatalf("cannot build frame: %v", err)
+	}
+
+	packet := gopacket.NewPacket(frame, layers.LayerTypeEthernet, gopacket.Default)
+
+	eth, ok := packet.Layer(layers.LayerTypeEthernet).(*layers.Ethernet)
+	if !ok || !bytes.Equal(eth.SrcMAC, mac) || !bytes.Equal(eth.DstMAC, nearestBridgeMAC) {
+		t.Fatalf("unexpected Ethernet layer %+v", eth)
+	}
+
+	lldp, ok := packet.Layer(layers.LayerTypeLinkLayerDiscovery).(*layers.LinkLayerDiscovery)
+	if !ok {
+		t.Fatalf("no lldp layer in frame: %v", packet.ErrorLayer())
+	}
+	if !bytes.Equal(lldp.ChassisID.ID, mac) || string(lldp.PortID.ID) != "eth0" || lldp.TTL != 120 {
+		t.Errorf("unexpected lldp layer %+v", lldp)
+	}
+
+	info, ok := packet.Layer(layers.LayerTypeLinkLayerDiscoveryInfo).(*layers.LinkLayerDiscoveryInfo)
+	if !ok {
+		t.Fatalf("no lldp info layer in frame")
+	}
+	if info.PortDescription != adv.PortDescription || info.SysName != adv.SysName || info.SysDescription != adv.SysDescription {
+		t.Errorf("unexpected lldp info %+v", info)
+	}
+	if len(info.OrgTLVs) != 1 || info.OrgTLVs[0].OUI != 0x0012bb || info.OrgTLVs[0].SubType != 7 ||
+		string(info.OrgTLVs[0].Info) != "10.210.8.121/30" {
+		t.Errorf("unexpected organizationally specific TLVs %+v", info.OrgTLVs)
+	}
+
+	// shutdown frame
+	frame, err = client.Frame(adv, 0)
+	if err != nil {
+		t.Fatalf("cannot build frame: %v", err)
+	}
+	packet = gopacket.NewPacket(frame, layers.LayerTypeEthernet, gopacket.Default)
+	if lldp, ok := packet.Layer(layers.LayerTypeLinkLayerDiscovery).(*layers.LinkLayerDiscovery); !ok || lldp.TTL != 0 {
+		t.Errorf("unexpected shutdown frame %+v", lldp)
+	}
+}
+
+func TestFrameErrors(t *testing.T) {
+	client := NewClient(context.Background(), "eth0", nil)
+	if _, err := client.Frame(Advertisement{}, time.Minute); err == nil {
+		t.Error("expected an error without MAC address")
+	}
+
+	client = NewClient(context.Background(), "eth0", []byte{0x01, 0x01, 0x02, 0x02, 0x03, 0x03})
+	if _, err := client.Frame(Advertisement{SysDescription: strings.Repeat("x", 512)}, time.Minute); err == nil {
+		t.Error("expected an error with a too long TLV")
+	}
+}